@@ -0,0 +1,13 @@
+
+CREATE TABLE audit_event (
+  id UUID NOT NULL,
+  actor TEXT NOT NULL,
+  action TEXT NOT NULL,
+  resource TEXT NOT NULL,
+  request_id TEXT NOT NULL,
+  diff JSONB NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX audit_event_created_at_index ON audit_event (created_at);
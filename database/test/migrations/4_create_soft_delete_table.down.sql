@@ -0,0 +1,2 @@
+
+DROP TABLE soft_delete_table;
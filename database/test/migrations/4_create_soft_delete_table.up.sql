@@ -0,0 +1,7 @@
+
+CREATE TABLE soft_delete_table (
+  id UUID NOT NULL,
+  name TEXT NOT NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE,
+  PRIMARY KEY (id)
+);
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type Diff map[string]FieldChange
+
+// NewDiff computes the fields which changed between before and after.
+// Both values are converted to their JSON representation so the keys
+// of the diff are the JSON names of the fields. Either value can be
+// nil, typically when a resource is created or deleted.
+func NewDiff(before any, after any) (Diff, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(Diff)
+
+	for key, beforeValue := range beforeFields {
+		afterValue, ok := afterFields[key]
+		if !ok || !reflect.DeepEqual(beforeValue, afterValue) {
+			out[key] = FieldChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for key, afterValue := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			out[key] = FieldChange{After: afterValue}
+		}
+	}
+
+	return out, nil
+}
+
+func toFields(in any) (map[string]any, error) {
+	out := make(map[string]any)
+	if in == nil {
+		return out, nil
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
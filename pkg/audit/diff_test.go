@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleResource struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestUnit_NewDiff(t *testing.T) {
+	t.Run("returns empty diff when nothing changed", func(t *testing.T) {
+		in := sampleResource{Name: "name", Count: 2}
+
+		actual, err := NewDiff(in, in)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.Empty(t, actual)
+	})
+
+	t.Run("returns changed fields", func(t *testing.T) {
+		before := sampleResource{Name: "name", Count: 2}
+		after := sampleResource{Name: "name", Count: 3}
+
+		actual, err := NewDiff(before, after)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		expected := Diff{
+			"count": FieldChange{Before: float64(2), After: float64(3)},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("returns all fields when resource is created", func(t *testing.T) {
+		after := sampleResource{Name: "name", Count: 3}
+
+		actual, err := NewDiff(nil, after)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		expected := Diff{
+			"name":  FieldChange{After: "name"},
+			"count": FieldChange{After: float64(3)},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("returns all fields when resource is deleted", func(t *testing.T) {
+		before := sampleResource{Name: "name", Count: 3}
+
+		actual, err := NewDiff(before, nil)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		expected := Diff{
+			"name":  FieldChange{Before: "name"},
+			"count": FieldChange{Before: float64(3)},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("returns error when value can't be marshalled", func(t *testing.T) {
+		_, err := NewDiff(make(chan int), nil)
+
+		assert.Error(t, err)
+	})
+}
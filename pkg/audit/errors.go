@@ -0,0 +1,22 @@
+package audit
+
+import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+
+const (
+	errInvalidEvent     errors.ErrorCode = 500
+	errInvalidRetention errors.ErrorCode = 501
+)
+
+var (
+	ErrInvalidEvent     = errors.FromCode(errInvalidEvent)
+	ErrInvalidRetention = errors.FromCode(errInvalidRetention)
+)
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errInvalidEvent, Name: "audit.invalid_event"},
+		{Code: errInvalidRetention, Name: "audit.invalid_retention"},
+	} {
+		errors.Register(info)
+	}
+}
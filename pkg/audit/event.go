@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Event struct {
+	Id        uuid.UUID
+	Actor     string
+	Action    string
+	Resource  string
+	RequestId string
+	Diff      Diff
+	CreatedAt time.Time
+}
+
+func (e Event) Valid() bool {
+	return e.Actor != "" && e.Action != "" && e.Resource != ""
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultQueryLimit = 100
+
+type Filter struct {
+	Actor     string
+	Action    string
+	Resource  string
+	RequestId string
+	Since     time.Time
+	Until     time.Time
+	// Limit caps the number of events returned. It defaults to 100 when
+	// not set.
+	Limit int
+}
+
+func (f Filter) toSql() (string, []any) {
+	var conditions []string
+	var args []any
+
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.Actor != "" {
+		addCondition("actor = $%d", f.Actor)
+	}
+	if f.Action != "" {
+		addCondition("action = $%d", f.Action)
+	}
+	if f.Resource != "" {
+		addCondition("resource = $%d", f.Resource)
+	}
+	if f.RequestId != "" {
+		addCondition("request_id = $%d", f.RequestId)
+	}
+	if !f.Since.IsZero() {
+		addCondition("created_at >= $%d", f.Since)
+	}
+	if !f.Until.IsZero() {
+		addCondition("created_at < $%d", f.Until)
+	}
+
+	sql := "SELECT id, actor, action, resource, request_id, diff, created_at FROM " + eventsTable
+
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	args = append(args, limit)
+	sql += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	return sql, args
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Filter_ToSql(t *testing.T) {
+	t.Run("uses default limit when filter is empty", func(t *testing.T) {
+		sql, args := Filter{}.toSql()
+
+		expected := "SELECT id, actor, action, resource, request_id, diff, created_at FROM audit_event ORDER BY created_at DESC LIMIT $1"
+		assert.Equal(t, expected, sql)
+		assert.Equal(t, []any{100}, args)
+	})
+
+	t.Run("combines all conditions", func(t *testing.T) {
+		since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		until := since.Add(time.Hour)
+		filter := Filter{
+			Actor:     "actor",
+			Action:    "action",
+			Resource:  "resource",
+			RequestId: "request-id",
+			Since:     since,
+			Until:     until,
+			Limit:     12,
+		}
+
+		sql, args := filter.toSql()
+
+		expected := "SELECT id, actor, action, resource, request_id, diff, created_at FROM audit_event " +
+			"WHERE actor = $1 AND action = $2 AND resource = $3 AND request_id = $4 AND created_at >= $5 AND created_at < $6 " +
+			"ORDER BY created_at DESC LIMIT $7"
+		assert.Equal(t, expected, sql)
+		assert.Equal(t, []any{"actor", "action", "resource", "request-id", since, until, 12}, args)
+	})
+}
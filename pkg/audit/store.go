@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/google/uuid"
+)
+
+// eventsTable is the table where the events are persisted. The expected
+// schema is available in the migrations of the test database.
+const eventsTable = "audit_event"
+
+type Store interface {
+	Record(ctx context.Context, event Event) (Event, error)
+	Query(ctx context.Context, filter Filter) ([]Event, error)
+	// Purge deletes the events older than the provided retention and
+	// returns how many were deleted.
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type storeImpl struct {
+	conn db.Connection
+}
+
+func NewStore(conn db.Connection) Store {
+	return &storeImpl{
+		conn: conn,
+	}
+}
+
+func (s *storeImpl) Record(ctx context.Context, event Event) (Event, error) {
+	if !event.Valid() {
+		return event, ErrInvalidEvent
+	}
+
+	if event.Id == uuid.Nil {
+		event.Id = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.Diff == nil {
+		event.Diff = Diff{}
+	}
+
+	sql := "INSERT INTO " + eventsTable + " (id, actor, action, resource, request_id, diff, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	_, err := s.conn.Exec(
+		ctx,
+		sql,
+		event.Id,
+		event.Actor,
+		event.Action,
+		event.Resource,
+		event.RequestId,
+		event.Diff,
+		event.CreatedAt,
+	)
+
+	return event, err
+}
+
+func (s *storeImpl) Query(ctx context.Context, filter Filter) ([]Event, error) {
+	sql, args := filter.toSql()
+	return db.QueryAll[Event](ctx, s.conn, sql, args...)
+}
+
+func (s *storeImpl) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, ErrInvalidRetention
+	}
+
+	threshold := time.Now().Add(-retention)
+	sql := "DELETE FROM " + eventsTable + " WHERE created_at < $1"
+
+	return s.conn.Exec(ctx, sql, threshold)
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db/postgresql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dbTestConfig = postgresql.NewConfigForLocalhost("test_db", "test_user", "test_password")
+
+func TestUnit_Store_Record_WhenEventIsInvalid_ExpectError(t *testing.T) {
+	s := NewStore(nil)
+
+	_, err := s.Record(t.Context(), Event{Actor: "actor"})
+
+	assert.Equal(t, ErrInvalidEvent, err, "Actual err: %v", err)
+}
+
+func TestUnit_Store_Purge_WhenRetentionIsInvalid_ExpectError(t *testing.T) {
+	s := NewStore(nil)
+
+	_, err := s.Purge(t.Context(), 0)
+
+	assert.Equal(t, ErrInvalidRetention, err, "Actual err: %v", err)
+}
+
+func TestIT_Store_Record(t *testing.T) {
+	s := newTestStore(t)
+
+	diff := Diff{"name": FieldChange{Before: "old", After: "new"}}
+	event := Event{
+		Actor:     "actor",
+		Action:    "update",
+		Resource:  uuid.NewString(),
+		RequestId: uuid.NewString(),
+		Diff:      diff,
+	}
+
+	recorded, err := s.Record(t.Context(), event)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.NotEqual(t, uuid.Nil, recorded.Id)
+
+	actual, err := s.Query(t.Context(), Filter{Resource: event.Resource})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	require.Len(t, actual, 1)
+	assert.Equal(t, recorded.Id, actual[0].Id)
+	assert.Equal(t, event.RequestId, actual[0].RequestId)
+	assert.Equal(t, diff, actual[0].Diff)
+}
+
+func TestIT_Store_Purge(t *testing.T) {
+	s := newTestStore(t)
+
+	old := Event{
+		Actor:     "actor",
+		Action:    "create",
+		Resource:  uuid.NewString(),
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+	}
+	_, err := s.Record(t.Context(), old)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	recent := Event{
+		Actor:    "actor",
+		Action:   "create",
+		Resource: uuid.NewString(),
+	}
+	_, err = s.Record(t.Context(), recent)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	deleted, err := s.Purge(t.Context(), time.Hour)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	actual, err := s.Query(t.Context(), Filter{Resource: old.Resource})
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Empty(t, actual)
+
+	actual, err = s.Query(t.Context(), Filter{Resource: recent.Resource})
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Len(t, actual, 1)
+}
+
+func newTestStore(t *testing.T) Store {
+	t.Helper()
+
+	conn, err := db.New(t.Context(), dbTestConfig)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	t.Cleanup(func() {
+		conn.Close(t.Context())
+	})
+
+	return NewStore(conn)
+}
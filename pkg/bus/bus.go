@@ -0,0 +1,133 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+)
+
+type Handler[T any] func(ctx context.Context, event T) error
+
+// Outbox persists the published events before they are dispatched. This
+// allows to replay them in case the process stops before the handlers
+// are done.
+type Outbox interface {
+	Store(ctx context.Context, topic string, payload []byte) error
+}
+
+type subscription struct {
+	id      uint64
+	handler func(ctx context.Context, event any) error
+}
+
+// Bus dispatches events to the handlers subscribed to their type within
+// the process. Handlers are called asynchronously: a failing or panicking
+// handler does not impact the publisher nor the other handlers.
+type Bus struct {
+	log    *slog.Logger
+	outbox Outbox
+
+	lock          sync.RWMutex
+	nextId        uint64
+	subscriptions map[reflect.Type][]subscription
+
+	inFlight sync.WaitGroup
+}
+
+func New(log *slog.Logger) *Bus {
+	return NewWithOutbox(log, nil)
+}
+
+func NewWithOutbox(log *slog.Logger, outbox Outbox) *Bus {
+	return &Bus{
+		log:           log,
+		outbox:        outbox,
+		subscriptions: make(map[reflect.Type][]subscription),
+	}
+}
+
+// Subscribe registers the handler for the events of type T. The returned
+// function removes the subscription.
+func Subscribe[T any](b *Bus, handler Handler[T]) func() {
+	topic := reflect.TypeFor[T]()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	id := b.nextId
+	b.nextId++
+
+	sub := subscription{
+		id: id,
+		handler: func(ctx context.Context, event any) error {
+			return handler(ctx, event.(T))
+		},
+	}
+	b.subscriptions[topic] = append(b.subscriptions[topic], sub)
+
+	return func() {
+		b.unsubscribe(topic, id)
+	}
+}
+
+// Publish dispatches the event to the handlers subscribed to its type.
+// When the bus has an outbox the event is persisted first: an error is
+// returned and nothing is dispatched if this fails.
+func Publish[T any](ctx context.Context, b *Bus, event T) error {
+	topic := reflect.TypeFor[T]()
+
+	if b.outbox != nil {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if err := b.outbox.Store(ctx, topic.String(), payload); err != nil {
+			return err
+		}
+	}
+
+	b.lock.RLock()
+	subscriptions := b.subscriptions[topic]
+	b.lock.RUnlock()
+
+	// The handlers outlive the publisher which is typically a request: its
+	// cancellation should not be propagated.
+	ctx = context.WithoutCancel(ctx)
+
+	for _, sub := range subscriptions {
+		b.inFlight.Go(func() {
+			err := process.SafeRunSync(func() error {
+				return sub.handler(ctx, event)
+			})
+			if err != nil {
+				b.log.Warn("Event handler failed", slog.String("topic", topic.String()), slog.Any("error", err))
+			}
+		})
+	}
+
+	return nil
+}
+
+// Wait blocks until all the events published so far are handled.
+func (b *Bus) Wait() {
+	b.inFlight.Wait()
+}
+
+func (b *Bus) unsubscribe(topic reflect.Type, id uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var remaining []subscription
+	for _, sub := range b.subscriptions[topic] {
+		if sub.id != id {
+			remaining = append(remaining, sub)
+		}
+	}
+
+	b.subscriptions[topic] = remaining
+}
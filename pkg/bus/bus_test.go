@@ -0,0 +1,162 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userCreated struct {
+	Name string `json:"name"`
+}
+
+type userDeleted struct {
+	Name string `json:"name"`
+}
+
+func TestUnit_Bus_DispatchesEventToSubscribers(t *testing.T) {
+	b := New(slog.Default())
+
+	var lock sync.Mutex
+	var received []string
+	handler := func(ctx context.Context, event userCreated) error {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, event.Name)
+		return nil
+	}
+	Subscribe(b, handler)
+	Subscribe(b, handler)
+
+	err := Publish(t.Context(), b, userCreated{Name: "alice"})
+	require.NoError(t, err, "Actual err: %v", err)
+	b.Wait()
+
+	assert.Equal(t, []string{"alice", "alice"}, received)
+}
+
+func TestUnit_Bus_OnlyDispatchesToSubscribersOfTheType(t *testing.T) {
+	b := New(slog.Default())
+
+	var created, deleted atomic.Int32
+	Subscribe(b, func(ctx context.Context, event userCreated) error {
+		created.Add(1)
+		return nil
+	})
+	Subscribe(b, func(ctx context.Context, event userDeleted) error {
+		deleted.Add(1)
+		return nil
+	})
+
+	err := Publish(t.Context(), b, userDeleted{Name: "bob"})
+	require.NoError(t, err, "Actual err: %v", err)
+	b.Wait()
+
+	assert.Equal(t, int32(0), created.Load())
+	assert.Equal(t, int32(1), deleted.Load())
+}
+
+func TestUnit_Bus_WhenUnsubscribed_ExpectHandlerNotCalled(t *testing.T) {
+	b := New(slog.Default())
+
+	var called atomic.Int32
+	unsubscribe := Subscribe(b, func(ctx context.Context, event userCreated) error {
+		called.Add(1)
+		return nil
+	})
+	unsubscribe()
+
+	err := Publish(t.Context(), b, userCreated{Name: "alice"})
+	require.NoError(t, err, "Actual err: %v", err)
+	b.Wait()
+
+	assert.Equal(t, int32(0), called.Load())
+}
+
+func TestUnit_Bus_WhenHandlerPanics_ExpectOtherHandlersCalled(t *testing.T) {
+	b := New(slog.Default())
+
+	var called atomic.Int32
+	Subscribe(b, func(ctx context.Context, event userCreated) error {
+		panic("handler panicked")
+	})
+	Subscribe(b, func(ctx context.Context, event userCreated) error {
+		called.Add(1)
+		return fmt.Errorf("handler failed")
+	})
+
+	err := Publish(t.Context(), b, userCreated{Name: "alice"})
+	require.NoError(t, err, "Actual err: %v", err)
+	b.Wait()
+
+	assert.Equal(t, int32(1), called.Load())
+}
+
+func TestUnit_Bus_WhenPublisherContextIsCancelled_ExpectHandlerContextNotCancelled(t *testing.T) {
+	b := New(slog.Default())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	release := make(chan struct{})
+	var handlerErr atomic.Value
+	Subscribe(b, func(ctx context.Context, event userCreated) error {
+		<-release
+		if ctx.Err() != nil {
+			handlerErr.Store(ctx.Err())
+		}
+		return nil
+	})
+
+	err := Publish(ctx, b, userCreated{Name: "alice"})
+	require.NoError(t, err, "Actual err: %v", err)
+	cancel()
+	close(release)
+	b.Wait()
+
+	assert.Nil(t, handlerErr.Load())
+}
+
+func TestUnit_Bus_WithOutbox_PersistsEventBeforeDispatch(t *testing.T) {
+	outbox := &testOutbox{}
+	b := NewWithOutbox(slog.Default(), outbox)
+
+	err := Publish(t.Context(), b, userCreated{Name: "alice"})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, "bus.userCreated", outbox.topic)
+	assert.JSONEq(t, `{"name":"alice"}`, string(outbox.payload))
+}
+
+func TestUnit_Bus_WhenOutboxFails_ExpectErrorAndNoDispatch(t *testing.T) {
+	outbox := &testOutbox{err: fmt.Errorf("outbox failed")}
+	b := NewWithOutbox(slog.Default(), outbox)
+
+	var called atomic.Int32
+	Subscribe(b, func(ctx context.Context, event userCreated) error {
+		called.Add(1)
+		return nil
+	})
+
+	err := Publish(t.Context(), b, userCreated{Name: "alice"})
+	b.Wait()
+
+	assert.Equal(t, fmt.Errorf("outbox failed"), err)
+	assert.Equal(t, int32(0), called.Load())
+}
+
+type testOutbox struct {
+	topic   string
+	payload []byte
+	err     error
+}
+
+func (o *testOutbox) Store(ctx context.Context, topic string, payload []byte) error {
+	o.topic = topic
+	o.payload = payload
+	return o.err
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"flag"
+	"io"
+)
+
+const (
+	defaultConfigName = "config"
+	defaultLogLevel   = "info"
+)
+
+type Options struct {
+	ConfigName  string
+	LogLevel    string
+	PrintConfig bool
+	Healthcheck bool
+	Preflight   bool
+	Version     bool
+}
+
+func ParseOptions(name string, args []string, out io.Writer) (Options, error) {
+	var opts Options
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(out)
+
+	flags.StringVar(&opts.ConfigName, "config", defaultConfigName, "name of the configuration file to load from the configs folder")
+	flags.StringVar(&opts.LogLevel, "log-level", defaultLogLevel, "minimum level of the logs to print (debug, info, warn, error)")
+	flags.BoolVar(&opts.PrintConfig, "print-config", false, "print the loaded configuration and exit")
+	flags.BoolVar(&opts.Healthcheck, "healthcheck", false, "run the healthcheck of the service and exit")
+	flags.BoolVar(&opts.Preflight, "preflight", false, "check the configuration and the dependencies of the service and exit")
+	flags.BoolVar(&opts.Version, "version", false, "print the version of the service and exit")
+
+	err := flags.Parse(args)
+
+	return opts, err
+}
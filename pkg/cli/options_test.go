@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_ParseOptions_WhenNoArguments_ExpectDefaults(t *testing.T) {
+	var out bytes.Buffer
+
+	actual, err := ParseOptions("service", nil, &out)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	expected := Options{
+		ConfigName: "config",
+		LogLevel:   "info",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_ParseOptions_ParsesAllFlags(t *testing.T) {
+	var out bytes.Buffer
+
+	args := []string{
+		"--config", "my-config",
+		"--log-level", "debug",
+		"--print-config",
+		"--healthcheck",
+		"--preflight",
+		"--version",
+	}
+	actual, err := ParseOptions("service", args, &out)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	expected := Options{
+		ConfigName:  "my-config",
+		LogLevel:    "debug",
+		PrintConfig: true,
+		Healthcheck: true,
+		Preflight:   true,
+		Version:     true,
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_ParseOptions_WhenFlagIsUnknown_ExpectErrorAndUsage(t *testing.T) {
+	var out bytes.Buffer
+
+	_, err := ParseOptions("service", []string{"--not-a-flag"}, &out)
+
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), "Usage of service")
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+)
+
+const (
+	configCheckName         = "config"
+	defaultPreflightTimeout = 10 * time.Second
+
+	preflightStatusOk     = "ok"
+	preflightStatusFailed = "failed"
+)
+
+type PreflightFunc[Configuration any] func(ctx context.Context, conf Configuration) error
+
+// PreflightCheck verifies that a dependency of the service is usable. The
+// configuration can also be validated with a check that does not contact
+// any external system.
+type PreflightCheck[Configuration any] struct {
+	Name  string
+	Check PreflightFunc[Configuration]
+	// Timeout defaults to 10 seconds when not set.
+	Timeout time.Duration
+}
+
+type PreflightReport struct {
+	Success bool              `json:"success"`
+	Checks  []PreflightResult `json:"checks"`
+}
+
+type PreflightResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// DatabaseCheck verifies that a connection to the database can be
+// established with the configuration returned by the provided function.
+func DatabaseCheck[Configuration any](
+	name string,
+	dbConfig func(conf Configuration) db.Config,
+) PreflightCheck[Configuration] {
+	return PreflightCheck[Configuration]{
+		Name: name,
+		Check: func(ctx context.Context, conf Configuration) error {
+			conn, err := db.New(ctx, dbConfig(conf))
+			if conn != nil {
+				// The connection is returned along with a failed ping.
+				defer conn.Close(ctx)
+			}
+			return err
+		},
+	}
+}
+
+// DialCheck verifies that a TCP connection can be opened to the address
+// returned by the provided function. This is enough to detect most of the
+// misconfigured hosts or ports for dependencies such as a cache or a
+// message broker.
+func DialCheck[Configuration any](
+	name string,
+	address func(conf Configuration) string,
+) PreflightCheck[Configuration] {
+	return PreflightCheck[Configuration]{
+		Name: name,
+		Check: func(ctx context.Context, conf Configuration) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", address(conf))
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+func runPreflight[Configuration any](
+	ctx context.Context,
+	out io.Writer,
+	checks []PreflightCheck[Configuration],
+	conf Configuration,
+) int {
+	report := PreflightReport{
+		Success: true,
+		Checks:  []PreflightResult{{Name: configCheckName, Status: preflightStatusOk, Duration: "0s"}},
+	}
+
+	for _, check := range checks {
+		result := runPreflightCheck(ctx, check, conf)
+		report.Success = report.Success && result.Status == preflightStatusOk
+		report.Checks = append(report.Checks, result)
+	}
+
+	return printPreflightReport(out, report)
+}
+
+func runPreflightCheck[Configuration any](
+	ctx context.Context,
+	check PreflightCheck[Configuration],
+	conf Configuration,
+) PreflightResult {
+	timeout := check.Timeout
+	if timeout == 0 {
+		timeout = defaultPreflightTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx, conf)
+
+	result := PreflightResult{
+		Name:     check.Name,
+		Status:   preflightStatusOk,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Status = preflightStatusFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// reportConfigFailure is used when the configuration can't be loaded: in
+// this case none of the checks can run.
+func reportConfigFailure(out io.Writer, err error) int {
+	report := PreflightReport{
+		Checks: []PreflightResult{
+			{
+				Name:     configCheckName,
+				Status:   preflightStatusFailed,
+				Error:    err.Error(),
+				Duration: "0s",
+			},
+		},
+	}
+
+	return printPreflightReport(out, report)
+}
+
+func printPreflightReport(out io.Writer, report PreflightReport) int {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(out, "Failed to print preflight report: %v\n", err)
+		return ExitFailure
+	}
+
+	fmt.Fprintln(out, string(data))
+
+	if !report.Success {
+		return ExitFailure
+	}
+	return ExitSuccess
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Run_Preflight(t *testing.T) {
+	configName := writeConfigFile(t, "Port: 1234\n")
+	args := []string{"--config", configName, "--preflight"}
+
+	t.Run("succeeds when no check is defined", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitSuccess, actual)
+		report := parsePreflightReport(t, out.Bytes())
+		assert.True(t, report.Success)
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, configCheckName, report.Checks[0].Name)
+	})
+
+	t.Run("forwards configuration to checks", func(t *testing.T) {
+		var out bytes.Buffer
+		var actualConf sampleConfig
+		service := Service[sampleConfig]{
+			Preflight: []PreflightCheck[sampleConfig]{
+				{
+					Name: "sample",
+					Check: func(ctx context.Context, conf sampleConfig) error {
+						actualConf = conf
+						return nil
+					},
+				},
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitSuccess, actual)
+		assert.Equal(t, sampleConfig{Port: 1234}, actualConf)
+	})
+
+	t.Run("reports all checks when one fails", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{
+			Preflight: []PreflightCheck[sampleConfig]{
+				{
+					Name: "failing",
+					Check: func(ctx context.Context, conf sampleConfig) error {
+						return errSample
+					},
+				},
+				{
+					Name: "passing",
+					Check: func(ctx context.Context, conf sampleConfig) error {
+						return nil
+					},
+				},
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+		report := parsePreflightReport(t, out.Bytes())
+		assert.False(t, report.Success)
+		require.Len(t, report.Checks, 3)
+		assert.Equal(t, preflightStatusFailed, report.Checks[1].Status)
+		assert.Equal(t, errSample.Error(), report.Checks[1].Error)
+		assert.Equal(t, preflightStatusOk, report.Checks[2].Status)
+	})
+
+	t.Run("reports configuration failure", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{}
+
+		actual := Run(context.Background(), []string{"--config", "does-not-exist", "--preflight"}, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+		report := parsePreflightReport(t, out.Bytes())
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, configCheckName, report.Checks[0].Name)
+		assert.Equal(t, preflightStatusFailed, report.Checks[0].Status)
+	})
+}
+
+func TestUnit_DialCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Actual err: %v", err)
+	address := listener.Addr().String()
+
+	check := DialCheck("dial", func(conf sampleConfig) string { return address })
+
+	err = check.Check(context.Background(), sampleConfig{})
+	assert.NoError(t, err, "Actual err: %v", err)
+
+	listener.Close()
+
+	err = check.Check(context.Background(), sampleConfig{})
+	assert.Error(t, err)
+}
+
+func parsePreflightReport(t *testing.T, data []byte) PreflightReport {
+	t.Helper()
+
+	var report PreflightReport
+	err := json.Unmarshal(data, &report)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return report
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/config"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+)
+
+const (
+	ExitSuccess      = 0
+	ExitFailure      = 1
+	ExitInvalidUsage = 2
+)
+
+type HealthcheckFunc[Configuration any] func(ctx context.Context, conf Configuration) error
+type CreateFunc[Configuration any] func(conf Configuration, log *slog.Logger) (process.Runnable, error)
+
+type Service[Configuration any] struct {
+	Name          string
+	Version       string
+	DefaultConfig Configuration
+
+	// Healthcheck is run instead of the service when the healthcheck
+	// flag is provided. When not set the healthcheck always succeeds.
+	Healthcheck HealthcheckFunc[Configuration]
+	// Preflight lists the checks run when the preflight flag is provided.
+	// They are meant to be used in CI/CD to detect a bad deployment before
+	// it receives traffic.
+	Preflight []PreflightCheck[Configuration]
+	// Create builds the process to run from the loaded configuration.
+	Create CreateFunc[Configuration]
+}
+
+// Run parses the command line arguments and runs the service accordingly.
+// The returned value is meant to be used as the exit code of the binary.
+func Run[Configuration any](
+	ctx context.Context,
+	args []string,
+	out io.Writer,
+	service Service[Configuration],
+) int {
+	opts, err := ParseOptions(service.Name, args, out)
+	if err != nil {
+		return ExitInvalidUsage
+	}
+
+	if opts.Version {
+		fmt.Fprintln(out, service.Version)
+		return ExitSuccess
+	}
+
+	level, err := logger.ParseLevel(opts.LogLevel)
+	if err != nil {
+		fmt.Fprintf(out, "Invalid log level %q: %v\n", opts.LogLevel, err)
+		return ExitInvalidUsage
+	}
+	log := logger.NewWithLevel(out, level).With(slog.String("service", service.Name))
+
+	conf, err := config.Load(opts.ConfigName, service.DefaultConfig)
+	if err != nil && opts.Preflight {
+		return reportConfigFailure(out, err)
+	}
+	if err != nil {
+		log.Error("Failed to load configuration", slog.String("config", opts.ConfigName), slog.Any("error", err))
+		return ExitFailure
+	}
+
+	if opts.PrintConfig {
+		return printConfig(out, conf)
+	}
+
+	if opts.Healthcheck {
+		return runHealthcheck(ctx, service.Healthcheck, conf, log)
+	}
+
+	if opts.Preflight {
+		return runPreflight(ctx, out, service.Preflight, conf)
+	}
+
+	return runService(ctx, service, conf, log)
+}
+
+// printConfig renders the configuration with the secrets masked so that
+// the output can be shared safely.
+func printConfig(out io.Writer, conf any) int {
+	fmt.Fprint(out, config.Dump(conf))
+	return ExitSuccess
+}
+
+func runHealthcheck[Configuration any](
+	ctx context.Context,
+	healthcheck HealthcheckFunc[Configuration],
+	conf Configuration,
+	log *slog.Logger,
+) int {
+	if healthcheck == nil {
+		return ExitSuccess
+	}
+
+	if err := healthcheck(ctx, conf); err != nil {
+		log.Error("Healthcheck failed", slog.Any("error", err))
+		return ExitFailure
+	}
+
+	return ExitSuccess
+}
+
+func runService[Configuration any](
+	ctx context.Context,
+	service Service[Configuration],
+	conf Configuration,
+	log *slog.Logger,
+) int {
+	if service.Create == nil {
+		log.Error("No process defined for the service")
+		return ExitFailure
+	}
+
+	runnable, err := service.Create(conf, log)
+	if err != nil {
+		log.Error("Failed to create service", slog.Any("error", err))
+		return ExitFailure
+	}
+
+	wait, err := process.StartWithSignalHandler(ctx, runnable)
+	if err != nil {
+		log.Error("Failed to start service", slog.Any("error", err))
+		return ExitFailure
+	}
+
+	if err := wait(); err != nil {
+		log.Error("Service failed", slog.Any("error", err))
+		return ExitFailure
+	}
+
+	return ExitSuccess
+}
@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleConfig struct {
+	Port uint16
+}
+
+var errSample = fmt.Errorf("sample error")
+
+func TestMain(m *testing.M) {
+	err := os.MkdirAll("configs", 0777)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	err = os.RemoveAll("configs")
+	if err != nil {
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
+
+func TestUnit_Run_WhenVersionRequested_ExpectVersionPrinted(t *testing.T) {
+	var out bytes.Buffer
+	service := Service[sampleConfig]{Version: "v1.2.3"}
+
+	actual := Run(context.Background(), []string{"--version"}, &out, service)
+
+	assert.Equal(t, ExitSuccess, actual)
+	assert.Equal(t, "v1.2.3\n", out.String())
+}
+
+func TestUnit_Run_WhenArgumentsAreInvalid_ExpectInvalidUsage(t *testing.T) {
+	var out bytes.Buffer
+	service := Service[sampleConfig]{}
+
+	actual := Run(context.Background(), []string{"--not-a-flag"}, &out, service)
+
+	assert.Equal(t, ExitInvalidUsage, actual)
+}
+
+func TestUnit_Run_WhenLogLevelIsInvalid_ExpectInvalidUsage(t *testing.T) {
+	var out bytes.Buffer
+	service := Service[sampleConfig]{}
+
+	actual := Run(context.Background(), []string{"--log-level", "not-a-level"}, &out, service)
+
+	assert.Equal(t, ExitInvalidUsage, actual)
+}
+
+func TestUnit_Run_WhenConfigDoesNotExist_ExpectFailure(t *testing.T) {
+	var out bytes.Buffer
+	service := Service[sampleConfig]{}
+
+	actual := Run(context.Background(), []string{"--config", "does-not-exist"}, &out, service)
+
+	assert.Equal(t, ExitFailure, actual)
+}
+
+func TestUnit_Run_WhenPrintConfigRequested_ExpectConfigPrinted(t *testing.T) {
+	configName := writeConfigFile(t, "Port: 1234\n")
+
+	var out bytes.Buffer
+	service := Service[sampleConfig]{}
+
+	args := []string{"--config", configName, "--print-config"}
+	actual := Run(context.Background(), args, &out, service)
+
+	assert.Equal(t, ExitSuccess, actual)
+	assert.Equal(t, "port: 1234\n", out.String())
+}
+
+func TestUnit_Run_WhenPrintConfigRequested_ExpectSecretsMasked(t *testing.T) {
+	type secretConfig struct {
+		Port     uint16
+		Password string `secret:"true"`
+	}
+	configName := writeConfigFile(t, "Port: 1234\nPassword: my-password\n")
+
+	var out bytes.Buffer
+	service := Service[secretConfig]{}
+
+	args := []string{"--config", configName, "--print-config"}
+	actual := Run(context.Background(), args, &out, service)
+
+	assert.Equal(t, ExitSuccess, actual)
+	assert.NotContains(t, out.String(), "my-password")
+	assert.Contains(t, out.String(), "password: ******")
+}
+
+func TestUnit_Run_Healthcheck(t *testing.T) {
+	configName := writeConfigFile(t, "Port: 1234\n")
+	args := []string{"--config", configName, "--healthcheck"}
+
+	t.Run("succeeds when no healthcheck is defined", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitSuccess, actual)
+	})
+
+	t.Run("forwards configuration to healthcheck", func(t *testing.T) {
+		var out bytes.Buffer
+		var actualConf sampleConfig
+		service := Service[sampleConfig]{
+			Healthcheck: func(ctx context.Context, conf sampleConfig) error {
+				actualConf = conf
+				return nil
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitSuccess, actual)
+		assert.Equal(t, sampleConfig{Port: 1234}, actualConf)
+	})
+
+	t.Run("fails when healthcheck fails", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{
+			Healthcheck: func(ctx context.Context, conf sampleConfig) error {
+				return errSample
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+	})
+}
+
+func TestUnit_Run_Service(t *testing.T) {
+	configName := writeConfigFile(t, "Port: 1234\n")
+	args := []string{"--config", configName}
+
+	t.Run("fails when no process is defined", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+	})
+
+	t.Run("fails when process can't be created", func(t *testing.T) {
+		var out bytes.Buffer
+		service := Service[sampleConfig]{
+			Create: func(conf sampleConfig, log *slog.Logger) (process.Runnable, error) {
+				return nil, errSample
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+	})
+
+	t.Run("runs process until completion", func(t *testing.T) {
+		var out bytes.Buffer
+		r := &sampleRunnable{}
+		service := Service[sampleConfig]{
+			Create: func(conf sampleConfig, log *slog.Logger) (process.Runnable, error) {
+				return r, nil
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitSuccess, actual)
+		assert.True(t, r.started)
+	})
+
+	t.Run("fails when process fails", func(t *testing.T) {
+		var out bytes.Buffer
+		r := &sampleRunnable{err: errSample}
+		service := Service[sampleConfig]{
+			Create: func(conf sampleConfig, log *slog.Logger) (process.Runnable, error) {
+				return r, nil
+			},
+		}
+
+		actual := Run(context.Background(), args, &out, service)
+
+		assert.Equal(t, ExitFailure, actual)
+	})
+}
+
+type sampleRunnable struct {
+	started bool
+	err     error
+}
+
+func (r *sampleRunnable) Start() error {
+	r.started = true
+	return r.err
+}
+
+func (r *sampleRunnable) Stop() error {
+	return nil
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	configName := fmt.Sprintf("config-%s", uuid.New())
+	configFileName := fmt.Sprintf("configs/%s.yml", configName)
+	err := os.WriteFile(configFileName, []byte(content), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return configName
+}
@@ -1,41 +1,278 @@
 package config
 
 import (
+	"encoding"
+	"errors"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-func Load[Configuration any](configName string, defaultConf Configuration) (Configuration, error) {
+// ConfigFileEnvVar defines the path to the configuration file. It takes
+// precedence over the search paths, which is useful when the file is
+// mounted at an arbitrary location (e.g. in a container).
+const ConfigFileEnvVar = "CONFIG_FILE"
+
+var defaultSearchPaths = []string{"configs"}
+
+type Option func(*options)
+
+type options struct {
+	searchPaths []string
+	file        string
+	overlays    []string
+	flags       *pflag.FlagSet
+	strictKeys  bool
+	// unknownKeysLogger reports the unknown keys without failing.
+	unknownKeysLogger *slog.Logger
+}
+
+// WithSearchPaths replaces the default configs directory with the provided
+// directories. They are searched in order for a file named after the
+// configuration with one of the supported extensions.
+func WithSearchPaths(paths ...string) Option {
+	return func(o *options) {
+		o.searchPaths = paths
+	}
+}
+
+// WithConfigFile loads the configuration from the provided file instead of
+// searching for it. Its format is deduced from its extension.
+func WithConfigFile(path string) Option {
+	return func(o *options) {
+		o.file = path
+	}
+}
+
+// WithOverlays merges the provided configurations on top of the main one,
+// in order. They are searched like the main configuration (or in the
+// directory of the configuration file when it is provided) and are skipped
+// when they do not exist.
+func WithOverlays(names ...string) Option {
+	return func(o *options) {
+		o.overlays = append(o.overlays, names...)
+	}
+}
+
+// LoadWithOverrides loads the base configuration and merges the overlays
+// on top of it, e.g. LoadWithOverrides("config", defaultConf, "config.prod").
+// See WithOverlays for the precedence rules.
+func LoadWithOverrides[Configuration any](base string, defaultConf Configuration, overlays ...string) (Configuration, error) {
+	return Load(base, defaultConf, WithOverlays(overlays...))
+}
+
+// Load reads the configuration from a YAML, JSON or TOML file and from the
+// environment variables prefixed with ENV_. By default the file is named
+// after the configuration and searched in the configs directory.
+// The values are taken from, by order of precedence: the flags (see
+// WithFlags), the environment variables (or the file they point to with the _FILE suffix, e.g.
+// ENV_DATABASE_PASSWORD_FILE), the overlays (the last one first) and the main file.
+// The result is then validated, see Validator.
+func Load[Configuration any](configName string, defaultConf Configuration, opts ...Option) (Configuration, error) {
+	loaderOpts := options{
+		searchPaths: defaultSearchPaths,
+		file:        os.Getenv(ConfigFileEnvVar),
+	}
+	for _, opt := range opts {
+		opt(&loaderOpts)
+	}
+
 	loader := viper.NewWithOptions(viper.ExperimentalBindStruct())
 
 	// https://github.com/spf13/viper#reading-config-files
-	loader.SetConfigType("yaml")
-	loader.AddConfigPath("configs")
+	if loaderOpts.file != "" {
+		loader.SetConfigFile(loaderOpts.file)
+	} else {
+		for _, path := range loaderOpts.searchPaths {
+			loader.AddConfigPath(path)
+		}
+		loader.SetConfigName(configName)
+	}
 
 	// https://stackoverflow.com/questions/61585304/issues-with-overriding-config-using-env-variables-in-viper
 	loader.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	loader.SetEnvPrefix("ENV")
 	loader.AutomaticEnv()
 
-	loader.SetConfigName(configName)
 	if err := loader.ReadInConfig(); err != nil {
 		return defaultConf, err
 	}
 
+	if err := resolveFileEnvVars(loader, reflect.TypeOf(defaultConf), loaderOpts.flags); err != nil {
+		return defaultConf, err
+	}
+
+	if loaderOpts.flags != nil {
+		if err := bindFlags(loader, loaderOpts.flags); err != nil {
+			return defaultConf, err
+		}
+	}
+
+	if len(loaderOpts.overlays) > 0 {
+		if err := mergeOverlays(loader, loaderOpts); err != nil {
+			return defaultConf, err
+		}
+	}
+
+	if err := checkUnknownKeys(loader, reflect.TypeOf(defaultConf), loaderOpts); err != nil {
+		return defaultConf, err
+	}
+
 	// https://stackoverflow.com/questions/71056755/mapping-string-to-uuid-in-go
-	opts := func(decoderConf *mapstructure.DecoderConfig) {
+	decoderOpts := func(decoderConf *mapstructure.DecoderConfig) {
+		// The default hooks split strings to slices: net.IP being a slice
+		// its hook should come first.
 		decoderConf.DecodeHook = mapstructure.ComposeDecodeHookFunc(
-			decoderConf.DecodeHook,
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToURLHookFunc(),
+			mapstructure.StringToIPHookFunc(),
 			stringToUUIDHookFunc(),
+			stringToSecretFileHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+			decoderConf.DecodeHook,
 		)
 	}
 
 	out := defaultConf
-	if err := loader.Unmarshal(&out, opts); err != nil {
+	if err := loader.Unmarshal(&out, decoderOpts); err != nil {
+		return defaultConf, err
+	}
+
+	if err := validateConfiguration(out); err != nil {
 		return defaultConf, err
 	}
 
 	return out, nil
 }
+
+func mergeOverlays(loader *viper.Viper, opts options) error {
+	if opts.file != "" {
+		loader.AddConfigPath(filepath.Dir(opts.file))
+	}
+
+	for _, overlay := range opts.overlays {
+		// Setting the name also resets the file of the main configuration.
+		loader.SetConfigName(overlay)
+
+		err := loader.MergeInConfig()
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveFileEnvVars applies the convention used for docker and kubernetes
+// secrets: the value of a key is read from the file pointed to by the
+// environment variable suffixed with _FILE, unless the variable without
+// the suffix is also defined.
+// The values are overrides for viper, above the flags: the keys provided
+// as flags are skipped to keep the flags first.
+func resolveFileEnvVars(loader *viper.Viper, configType reflect.Type, flags *pflag.FlagSet) error {
+	secrets := make(map[string]bool)
+	keys := loader.AllKeys()
+	for _, field := range structFields(configType, "") {
+		keys = append(keys, field.key)
+		secrets[field.key] = (field.typ == reflect.TypeOf(SecretFile("")))
+	}
+
+	for _, key := range keys {
+		envVar := "ENV_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if _, ok := os.LookupEnv(envVar); ok {
+			continue
+		}
+		if flags != nil {
+			if flag := flags.Lookup(key); flag != nil && flag.Changed {
+				continue
+			}
+		}
+
+		path, ok := os.LookupEnv(envVar + "_FILE")
+		if !ok {
+			continue
+		}
+
+		// Secret files expect the path and read the file when decoded.
+		if secrets[key] {
+			loader.Set(key, path)
+			continue
+		}
+
+		value, err := readSecretFile(path)
+		if err != nil {
+			return err
+		}
+		loader.Set(key, value)
+	}
+
+	return nil
+}
+
+type structField struct {
+	// key is in the format used by viper: lower case, separated by dots.
+	key   string
+	typ   reflect.Type
+	index []int
+}
+
+// structFields lists the leaf fields of the configuration.
+func structFields(typ reflect.Type, prefix string) []structField {
+	return structFieldsWithIndex(typ, prefix, nil)
+}
+
+func structFieldsWithIndex(typ reflect.Type, prefix string, index []int) []structField {
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || isDecodedFromString(typ) {
+		return nil
+	}
+
+	var out []structField
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		key := strings.ToLower(prefix + name)
+		fieldIndex := append(slices.Clone(index), i)
+
+		if nested := structFieldsWithIndex(field.Type, key+".", fieldIndex); len(nested) > 0 {
+			out = append(out, nested...)
+		} else {
+			out = append(out, structField{key: key, typ: field.Type, index: fieldIndex})
+		}
+	}
+
+	return out
+}
+
+// isDecodedFromString returns true for the structures which are decoded
+// from a single value (see the decode hooks) rather than from their fields.
+func isDecodedFromString(typ reflect.Type) bool {
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	return typ == reflect.TypeOf(url.URL{}) || reflect.PointerTo(typ).Implements(textUnmarshaler)
+}
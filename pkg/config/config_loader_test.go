@@ -2,10 +2,15 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -168,6 +173,246 @@ func TestUnit_Load_WhenUuidInConfigAndInvalidData_ExpectFailure(t *testing.T) {
 	assert.Equal(t, in.Service.Id, actual.Service.Id)
 }
 
+func TestUnit_Load_WhenJsonOrTomlFile_ExpectSuccess(t *testing.T) {
+	samples := map[string]string{
+		"json": `{"Server": {"Port": 20}}`,
+		"toml": "[Server]\nPort = 20\n",
+	}
+
+	for extension, content := range samples {
+		t.Run(extension, func(t *testing.T) {
+			configName := fmt.Sprintf("config-%s", uuid.New())
+			configFileName := fmt.Sprintf("configs/%s.%s", configName, extension)
+			err := os.WriteFile(configFileName, []byte(content), 0666)
+			require.NoError(t, err, "Actual err: %v", err)
+
+			actual, err := Load(configName, sampleConfig{})
+			assert.Nil(t, err)
+			assert.Equal(t, uint16(20), actual.Server.Port)
+		})
+	}
+}
+
+func TestUnit_Load_WithSearchPaths_ExpectFileFoundInAnyPath(t *testing.T) {
+	dir := t.TempDir()
+	configName := "sample"
+	err := os.WriteFile(filepath.Join(dir, configName+".yaml"), []byte("Server:\n  Port: 21\n"), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load(configName, sampleConfig{}, WithSearchPaths(t.TempDir(), dir))
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(21), actual.Server.Port)
+}
+
+func TestUnit_Load_WithConfigFile_ExpectFileToBeUsed(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "mounted.json")
+	err := os.WriteFile(configFile, []byte(`{"Server": {"Port": 23}}`), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load("not-used", sampleConfig{}, WithConfigFile(configFile))
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(23), actual.Server.Port)
+}
+
+func TestUnit_Load_WhenConfigFileEnvironmentVariableIsSet_ExpectFileToBeUsed(t *testing.T) {
+	configName := writeSampleConfigFile(t)
+	configFile := filepath.Join(t.TempDir(), "mounted.toml")
+	err := os.WriteFile(configFile, []byte("[Server]\nPort = 24\n"), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+	t.Setenv(ConfigFileEnvVar, configFile)
+
+	actual, err := Load(configName, sampleConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(24), actual.Server.Port)
+}
+
+func TestUnit_Load_WhenConfigFileDoesNotExist_ExpectError(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "missing.yaml")
+
+	_, err := Load("not-used", sampleConfig{}, WithConfigFile(configFile))
+	assert.NotNil(t, err)
+}
+
+type layeredConfig struct {
+	Server  sampleServerConfig
+	Service struct {
+		Name  string
+		Level string
+	}
+}
+
+func TestUnit_LoadWithOverrides_ExpectOverlaysMergedInOrder(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Server:\n  Port: 20\nService:\n  Name: base\n  Level: info\n"))
+	writeConfigFileWithName(t, configName+".prod", "Service:\n  Level: warn\n")
+	writeConfigFileWithName(t, configName+".eu", "Service:\n  Level: error\n")
+
+	actual, err := LoadWithOverrides(configName, layeredConfig{}, configName+".prod", configName+".eu")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(20), actual.Server.Port)
+	assert.Equal(t, "base", actual.Service.Name)
+	assert.Equal(t, "error", actual.Service.Level)
+}
+
+func TestUnit_LoadWithOverrides_WhenOverlayDoesNotExist_ExpectSkipped(t *testing.T) {
+	configName := writeSampleConfigFile(t)
+
+	actual, err := LoadWithOverrides(configName, sampleConfig{}, configName+".missing")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(20), actual.Server.Port)
+}
+
+func TestUnit_LoadWithOverrides_WhenEnvironmentVariableExists_ExpectTakesPrecedenceOverOverlays(t *testing.T) {
+	configName := writeSampleConfigFile(t)
+	writeConfigFileWithName(t, configName+".prod", "Server:\n  Port: 25\n")
+	t.Setenv("ENV_SERVER_PORT", "26")
+
+	actual, err := LoadWithOverrides(configName, sampleConfig{}, configName+".prod")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(26), actual.Server.Port)
+}
+
+func TestUnit_Load_WithConfigFileAndOverlays_ExpectOverlaysSearchedNextToFile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	err := os.WriteFile(configFile, []byte("Server:\n  Port: 20\n"), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+	err = os.WriteFile(filepath.Join(dir, "config.prod.json"), []byte(`{"Server": {"Port": 27}}`), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load("config", sampleConfig{}, WithConfigFile(configFile), WithOverlays("config.prod"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(27), actual.Server.Port)
+}
+
+func TestUnit_Load_WhenTypedValuesInConfig_ExpectDecoded(t *testing.T) {
+	type sampleTypedConfig struct {
+		Timeout  time.Duration
+		Deadline time.Time
+		Endpoint *url.URL
+		Ip       net.IP
+	}
+
+	sampleYaml := "Timeout: 30s\nDeadline: \"2024-05-06T07:08:09Z\"\nEndpoint: https://example.com/api\nIp: 192.0.2.10\n"
+	configName := writeConfigFile(t, []byte(sampleYaml))
+
+	actual, err := Load(configName, sampleTypedConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, actual.Timeout)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), actual.Deadline)
+	require.NotNil(t, actual.Endpoint)
+	assert.Equal(t, "example.com", actual.Endpoint.Host)
+	assert.Equal(t, "192.0.2.10", actual.Ip.String())
+}
+
+func TestUnit_Load_WhenTypedValuesInEnvironmentVariables_ExpectDecoded(t *testing.T) {
+	type sampleTypedConfig struct {
+		Timeout time.Duration
+		Ip      net.IP
+	}
+
+	configName := writeConfigFile(t, nil)
+	t.Setenv("ENV_TIMEOUT", "1m")
+	t.Setenv("ENV_IP", "::1")
+
+	actual, err := Load(configName, sampleTypedConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, actual.Timeout)
+	assert.Equal(t, "::1", actual.Ip.String())
+}
+
+func TestUnit_Load_WhenTextUnmarshalerInConfig_ExpectDecoded(t *testing.T) {
+	type sampleLevelConfig struct {
+		Level      zerolog.Level
+		DebugLevel zerolog.Level
+	}
+
+	configName := writeConfigFile(t, []byte("Level: warn\n"))
+	t.Setenv("ENV_DEBUGLEVEL", "debug")
+
+	actual, err := Load(configName, sampleLevelConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, zerolog.WarnLevel, actual.Level)
+	assert.Equal(t, zerolog.DebugLevel, actual.DebugLevel)
+}
+
+func TestUnit_Load_WhenTypedValueIsInvalid_ExpectFailure(t *testing.T) {
+	type sampleTypedConfig struct {
+		Ip net.IP
+	}
+
+	configName := writeConfigFile(t, []byte("Ip: not-an-ip\n"))
+
+	_, err := Load(configName, sampleTypedConfig{})
+
+	assert.NotNil(t, err)
+}
+
+type secretConfig struct {
+	Database struct {
+		User     string
+		Password string
+		Token    SecretFile
+	}
+}
+
+func TestUnit_Load_WhenSecretFileInConfig_ExpectContentOfTheFile(t *testing.T) {
+	secretFile := writeSecretFile(t, "my-token\n")
+	configName := writeConfigFile(t, []byte("Database:\n  Token: "+secretFile+"\n"))
+
+	actual, err := Load(configName, secretConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, SecretFile("my-token"), actual.Database.Token)
+}
+
+func TestUnit_Load_WhenSecretFileDoesNotExist_ExpectError(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Database:\n  Token: /does/not/exist\n"))
+
+	_, err := Load(configName, secretConfig{})
+
+	assert.NotNil(t, err)
+}
+
+func TestUnit_Load_WhenFileEnvironmentVariableIsSet_ExpectContentOfTheFile(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Database:\n  User: user\n  Password: from-config\n"))
+	t.Setenv("ENV_DATABASE_PASSWORD_FILE", writeSecretFile(t, "  from-file\n"))
+	t.Setenv("ENV_DATABASE_TOKEN_FILE", writeSecretFile(t, "my-token\n"))
+
+	actual, err := Load(configName, secretConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "user", actual.Database.User)
+	assert.Equal(t, "from-file", actual.Database.Password)
+	assert.Equal(t, SecretFile("my-token"), actual.Database.Token)
+}
+
+func TestUnit_Load_WhenFileAndPlainEnvironmentVariablesAreSet_ExpectPlainOneToWin(t *testing.T) {
+	configName := writeConfigFile(t, nil)
+	t.Setenv("ENV_DATABASE_PASSWORD_FILE", writeSecretFile(t, "from-file"))
+	t.Setenv("ENV_DATABASE_PASSWORD", "from-env")
+
+	actual, err := Load(configName, secretConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "from-env", actual.Database.Password)
+}
+
+func TestUnit_Load_WhenFileEnvironmentVariablePointsToMissingFile_ExpectError(t *testing.T) {
+	configName := writeConfigFile(t, nil)
+	t.Setenv("ENV_DATABASE_PASSWORD_FILE", "/does/not/exist")
+
+	_, err := Load(configName, secretConfig{})
+
+	assert.NotNil(t, err)
+}
+
 func writeSampleConfigFile(t *testing.T) string {
 	// https://stackoverflow.com/questions/19975954/a-yaml-file-cannot-contain-tabs-as-indentation
 	sampleYaml := "Server:\n  Port: 20\n"
@@ -182,3 +427,17 @@ func writeConfigFile(t *testing.T, content []byte) string {
 
 	return configName
 }
+
+func writeConfigFileWithName(t *testing.T, configName string, content string) {
+	configFileName := fmt.Sprintf("configs/%s.yml", configName)
+	err := os.WriteFile(configFileName, []byte(content), 0666)
+	require.NoError(t, err, "Actual err: %v", err)
+}
+
+func writeSecretFile(t *testing.T, content string) string {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	err := os.WriteFile(secretFile, []byte(content), 0600)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return secretFile
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const redacted = "******"
+
+// Dump renders the configuration with one line per field, named after the
+// key used in the environment variables and flags, e.g.:
+//
+//	server.port: 8080
+//	database.password: ******
+//
+// Fields tagged with `secret:"true"` and secret files are masked so that
+// the output can safely be logged or exposed (see server.NewConfigRoute).
+func Dump(conf any) string {
+	if conf == nil {
+		return ""
+	}
+
+	var out strings.Builder
+	dumpValue(&out, reflect.ValueOf(conf), "")
+	return out.String()
+}
+
+type redactable interface {
+	Redacted() string
+}
+
+var (
+	secretFileType = reflect.TypeOf(SecretFile(""))
+	stringerType   = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func dumpValue(out *strings.Builder, value reflect.Value, key string) {
+	if value.Kind() == reflect.Pointer && !value.IsNil() && !value.Type().Implements(stringerType) {
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Struct && !value.Type().Implements(stringerType) {
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if name == "" {
+				name = field.Name
+			}
+			fieldKey := strings.ToLower(name)
+			if key != "" {
+				fieldKey = key + "." + fieldKey
+			}
+
+			if field.Tag.Get("secret") == "true" {
+				fmt.Fprintf(out, "%s: %s\n", fieldKey, redacted)
+				continue
+			}
+
+			dumpValue(out, value.Field(i), fieldKey)
+		}
+		return
+	}
+
+	fmt.Fprintf(out, "%s: %s\n", key, formatValue(value))
+}
+
+func formatValue(value reflect.Value) string {
+	switch {
+	case !value.IsValid():
+		return "<nil>"
+	case value.Type() == secretFileType:
+		return redacted
+	case value.Kind() == reflect.Pointer && value.IsNil():
+		return "<nil>"
+	case value.Kind() == reflect.String:
+		return fmt.Sprintf("%q", value.String())
+	}
+
+	// URLs may contain credentials.
+	if r, ok := value.Interface().(redactable); ok {
+		return r.Redacted()
+	}
+
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// WithFlags overrides the configuration with the flags of the set which
+// were provided on the command line. The flags are named after the keys
+// of the configuration, e.g. --server.port=8080. They take precedence over
+// all the other sources.
+func WithFlags(flags *pflag.FlagSet) Option {
+	return func(o *options) {
+		o.flags = flags
+	}
+}
+
+// Flags generates a flag set with one flag per field of the configuration.
+// The default configuration is only used to document the default values.
+// The set should be parsed before loading the configuration with WithFlags.
+func Flags[Configuration any](name string, defaultConf Configuration) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+
+	value := reflect.ValueOf(defaultConf)
+	for _, field := range structFields(reflect.TypeOf(defaultConf), "") {
+		var defaultValue any
+		if fieldValue, err := value.FieldByIndexErr(field.index); err == nil {
+			defaultValue = fieldValue.Interface()
+		}
+
+		usage := fmt.Sprintf("overrides the %s of the configuration", field.key)
+		if field.typ.Kind() == reflect.Bool {
+			b, _ := defaultValue.(bool)
+			flags.Bool(field.key, b, usage)
+			continue
+		}
+
+		defaultString := ""
+		if defaultValue != nil {
+			defaultString = fmt.Sprint(defaultValue)
+		}
+		flags.String(field.key, defaultString, usage)
+	}
+
+	return flags
+}
+
+func bindFlags(loader *viper.Viper, flags *pflag.FlagSet) error {
+	var err error
+
+	// Only the flags which were provided override the configuration:
+	// the default values of the others are already in the configuration.
+	flags.Visit(func(flag *pflag.Flag) {
+		if bindErr := loader.BindPFlag(flag.Name, flag); bindErr != nil && err == nil {
+			err = bindErr
+		}
+	})
+
+	return err
+}
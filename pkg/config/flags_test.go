@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flagsServerConfig struct {
+	Port    uint16
+	Timeout time.Duration
+}
+
+type flagsConfig struct {
+	Server flagsServerConfig
+	Debug  bool
+	Name   string
+}
+
+var defaultFlagsConfig = flagsConfig{
+	Server: flagsServerConfig{Port: 80, Timeout: time.Second},
+	Name:   "default",
+}
+
+func TestUnit_Flags_GeneratesOneFlagPerField(t *testing.T) {
+	flags := Flags("service", defaultFlagsConfig)
+
+	port := flags.Lookup("server.port")
+	require.NotNil(t, port)
+	assert.Equal(t, "80", port.DefValue)
+	timeout := flags.Lookup("server.timeout")
+	require.NotNil(t, timeout)
+	assert.Equal(t, "1s", timeout.DefValue)
+	debug := flags.Lookup("debug")
+	require.NotNil(t, debug)
+	assert.Equal(t, "bool", debug.Value.Type())
+	assert.NotNil(t, flags.Lookup("name"))
+}
+
+func TestUnit_Load_WithFlags_ExpectFlagsToTakePrecedence(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Server:\n  Port: 20\n  Timeout: 5s\nName: from-file\n"))
+	t.Setenv("ENV_SERVER_PORT", "26")
+	flags := Flags("service", defaultFlagsConfig)
+	err := flags.Parse([]string{"--server.port=8080", "--debug"})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load(configName, defaultFlagsConfig, WithFlags(flags))
+
+	assert.Nil(t, err)
+	expected := flagsConfig{
+		Server: flagsServerConfig{Port: 8080, Timeout: 5 * time.Second},
+		Debug:  true,
+		Name:   "from-file",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_Load_WithFlagsAndFileEnvironmentVariable_ExpectFlagsToTakePrecedence(t *testing.T) {
+	configName := writeConfigFile(t, nil)
+	t.Setenv("ENV_DATABASE_PASSWORD_FILE", writeSecretFile(t, "from-file"))
+	t.Setenv("ENV_DATABASE_USER_FILE", writeSecretFile(t, "user-from-file"))
+	flags := Flags("service", secretConfig{})
+	err := flags.Parse([]string{"--database.password=from-flag"})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load(configName, secretConfig{}, WithFlags(flags))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "from-flag", actual.Database.Password)
+	assert.Equal(t, "user-from-file", actual.Database.User)
+}
+
+func TestUnit_Load_WithFlagsNotProvided_ExpectDefaultConfigKept(t *testing.T) {
+	configName := writeConfigFile(t, nil)
+	flags := Flags("service", defaultFlagsConfig)
+	err := flags.Parse(nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := Load(configName, defaultFlagsConfig, WithFlags(flags))
+
+	assert.Nil(t, err)
+	assert.Equal(t, defaultFlagsConfig, actual)
+}
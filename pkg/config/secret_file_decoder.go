@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// SecretFile is a value read from a file, typically a secret mounted by
+// docker or kubernetes. The configuration provides the path of the file
+// and the field holds its content, without the surrounding whitespaces.
+type SecretFile string
+
+func stringToSecretFileHookFunc() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+		if to != reflect.TypeOf(SecretFile("")) {
+			return data, nil
+		}
+
+		content, err := readSecretFile(data.(string))
+		return SecretFile(content), err
+	}
+}
+
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// WithStrictKeys makes Load fail with a ValidationError when the files
+// contain keys which do not correspond to any field of the configuration,
+// typically because of a typo.
+func WithStrictKeys() Option {
+	return func(o *options) {
+		o.strictKeys = true
+	}
+}
+
+// WithUnknownKeysWarning logs a warning for each key of the files which
+// does not correspond to any field of the configuration.
+func WithUnknownKeysWarning(log *slog.Logger) Option {
+	return func(o *options) {
+		o.unknownKeysLogger = log
+	}
+}
+
+func checkUnknownKeys(loader *viper.Viper, configType reflect.Type, opts options) error {
+	if !opts.strictKeys && opts.unknownKeysLogger == nil {
+		return nil
+	}
+
+	unknown := unknownKeys(loader.AllKeys(), structFields(configType, ""))
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if opts.unknownKeysLogger != nil {
+		for _, key := range unknown {
+			opts.unknownKeysLogger.Warn("Unknown configuration key", slog.String("key", key))
+		}
+	}
+
+	if !opts.strictKeys {
+		return nil
+	}
+
+	out := &ValidationError{}
+	for _, key := range unknown {
+		out.Fields = append(out.Fields, FieldError{Field: key, Message: "unknown key"})
+	}
+
+	return out
+}
+
+func unknownKeys(keys []string, fields []structField) []string {
+	var out []string
+
+	for _, key := range keys {
+		// Keys below a field which is not a structure (e.g. a map) are
+		// considered to be part of the field.
+		known := slices.ContainsFunc(fields, func(field structField) bool {
+			return key == field.key || strings.HasPrefix(key, field.key+".")
+		})
+		if !known {
+			out = append(out, key)
+		}
+	}
+
+	slices.Sort(out)
+	return out
+}
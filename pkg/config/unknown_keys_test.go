@@ -0,0 +1,66 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strictConfig struct {
+	Server   sampleServerConfig
+	Endpoint *url.URL
+	Labels   map[string]string
+}
+
+const strictYaml = "Sever:\n  Port: 20\nServer:\n  Port: 21\n  Prot: 22\nEndpoint: https://example.com\nLabels:\n  team: core\n"
+
+func TestUnit_Load_WhenUnknownKeysAndNotStrict_ExpectSuccess(t *testing.T) {
+	configName := writeConfigFile(t, []byte(strictYaml))
+
+	actual, err := Load(configName, strictConfig{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(21), actual.Server.Port)
+}
+
+func TestUnit_Load_WithStrictKeys_WhenUnknownKeys_ExpectError(t *testing.T) {
+	configName := writeConfigFile(t, []byte(strictYaml))
+	in := strictConfig{Labels: map[string]string{"default": "value"}}
+
+	actual, err := Load(configName, in, WithStrictKeys())
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	expected := []FieldError{
+		{Field: "server.prot", Message: "unknown key"},
+		{Field: "sever.port", Message: "unknown key"},
+	}
+	assert.Equal(t, expected, validationErr.Fields)
+	assert.Equal(t, in, actual)
+}
+
+func TestUnit_Load_WithStrictKeys_WhenAllKeysAreKnown_ExpectSuccess(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Server:\n  Port: 21\nLabels:\n  team: core\n"))
+
+	actual, err := Load(configName, strictConfig{}, WithStrictKeys())
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "core"}, actual.Labels)
+}
+
+func TestUnit_Load_WithUnknownKeysWarning_ExpectKeysLogged(t *testing.T) {
+	configName := writeConfigFile(t, []byte(strictYaml))
+	var out bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&out, nil))
+
+	actual, err := Load(configName, strictConfig{}, WithUnknownKeysWarning(log))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(21), actual.Server.Port)
+	assert.Contains(t, out.String(), `msg="Unknown configuration key" key=server.prot`)
+	assert.Contains(t, out.String(), `msg="Unknown configuration key" key=sever.port`)
+}
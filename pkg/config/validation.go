@@ -0,0 +1,129 @@
+package config
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator can be implemented by the configuration, or any of the
+// structures it contains, to perform checks which can't be expressed with
+// the `validate` tags.
+type Validator interface {
+	Validate() error
+}
+
+type FieldError struct {
+	// Field is the path to the invalid field, e.g. Server.Port. It is
+	// empty when the error is reported by the configuration itself.
+	Field   string
+	Message string
+}
+
+// ValidationError lists all the invalid fields of the configuration.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		if field.Field == "" {
+			fields = append(fields, field.Message)
+		} else {
+			fields = append(fields, fmt.Sprintf("%s: %s", field.Field, field.Message))
+		}
+	}
+
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(fields, "; "))
+}
+
+var validate = validator.New(validator.WithRequiredStructEnabled())
+
+// validateConfiguration checks the `validate` tags of the configuration
+// and calls the Validate method of the structures implementing Validator.
+func validateConfiguration(conf any) error {
+	value := reflect.ValueOf(conf)
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []FieldError
+
+	var validationErrs validator.ValidationErrors
+	if err := validate.Struct(conf); stderrors.As(err, &validationErrs) {
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, newFieldError(fieldErr))
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// Work on a copy so that the methods with a pointer receiver can be
+	// called as well.
+	addressable := reflect.New(value.Type()).Elem()
+	addressable.Set(value)
+	fields = append(fields, callValidators(addressable, "")...)
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+
+	return nil
+}
+
+func callValidators(value reflect.Value, path string) []FieldError {
+	var out []FieldError
+
+	if value.CanAddr() {
+		if v, ok := value.Addr().Interface().(Validator); ok {
+			if err := v.Validate(); err != nil {
+				out = append(out, FieldError{Field: path, Message: err.Error()})
+			}
+		}
+	}
+
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		out = append(out, callValidators(fieldValue, fieldPath)...)
+	}
+
+	return out
+}
+
+func newFieldError(err validator.FieldError) FieldError {
+	// The namespace starts with the name of the configuration type.
+	_, field, found := strings.Cut(err.Namespace(), ".")
+	if !found {
+		field = err.Field()
+	}
+
+	message := fmt.Sprintf("failed on the '%s' rule", err.Tag())
+	if err.Param() != "" {
+		message = fmt.Sprintf("failed on the '%s=%s' rule", err.Tag(), err.Param())
+	}
+
+	return FieldError{Field: field, Message: message}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedServerConfig struct {
+	Port uint16 `validate:"required,gte=1024"`
+	Mode string `validate:"oneof=debug release"`
+}
+
+type validatedDatabaseConfig struct {
+	Host string
+	Port uint16
+}
+
+func (c validatedDatabaseConfig) Validate() error {
+	if c.Host == "" && c.Port != 0 {
+		return fmt.Errorf("port is set without host")
+	}
+	return nil
+}
+
+type validatedConfig struct {
+	Server   validatedServerConfig
+	Database *validatedDatabaseConfig
+	Name     string
+}
+
+func (c *validatedConfig) Validate() error {
+	if c.Name == "forbidden" {
+		return fmt.Errorf("name is forbidden")
+	}
+	return nil
+}
+
+func TestUnit_ValidateConfiguration_WhenValid_ExpectNoError(t *testing.T) {
+	conf := validatedConfig{
+		Server:   validatedServerConfig{Port: 8080, Mode: "release"},
+		Database: &validatedDatabaseConfig{Host: "localhost", Port: 5432},
+	}
+
+	err := validateConfiguration(conf)
+
+	assert.NoError(t, err, "Actual err: %v", err)
+}
+
+func TestUnit_ValidateConfiguration_WhenInvalid_ExpectAllFieldsReported(t *testing.T) {
+	conf := validatedConfig{
+		Server:   validatedServerConfig{Port: 80, Mode: "prod"},
+		Database: &validatedDatabaseConfig{Port: 5432},
+		Name:     "forbidden",
+	}
+
+	err := validateConfiguration(conf)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{
+		{Field: "Server.Port", Message: "failed on the 'gte=1024' rule"},
+		{Field: "Server.Mode", Message: "failed on the 'oneof=debug release' rule"},
+		{Message: "name is forbidden"},
+		{Field: "Database", Message: "port is set without host"},
+	}
+	assert.Equal(t, expected, actual.Fields)
+	expectedMessage := "invalid configuration: " +
+		"Server.Port: failed on the 'gte=1024' rule; " +
+		"Server.Mode: failed on the 'oneof=debug release' rule; " +
+		"name is forbidden; " +
+		"Database: port is set without host"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestUnit_ValidateConfiguration_WhenNotAStruct_ExpectNoError(t *testing.T) {
+	err := validateConfiguration(map[string]int{"key": 1})
+
+	assert.NoError(t, err, "Actual err: %v", err)
+}
+
+func TestUnit_Load_WhenConfigurationIsInvalid_ExpectErrorAndDefaultConfig(t *testing.T) {
+	configName := writeConfigFile(t, []byte("Server:\n  Prot: 8080\n  Mode: release\n"))
+	in := validatedConfig{Name: "default"}
+
+	actual, err := Load(configName, in)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{{Field: "Server.Port", Message: "failed on the 'required' rule"}}, validationErr.Fields)
+	assert.Equal(t, in, actual)
+}
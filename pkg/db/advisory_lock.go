@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Lock is a distributed lock backed by a postgres advisory lock. It is held
+// until Close is called or the connection holding it is lost.
+type Lock interface {
+	Key() int64
+	// Close releases the lock. It is safe to call it several times.
+	Close(ctx context.Context) error
+}
+
+// Locker is implemented by the connections able to hold advisory locks, on
+// which AcquireLock and TryAcquireLock operate. The fakes of the dbtest
+// package also satisfy it.
+type Locker interface {
+	AcquireLock(ctx context.Context, key int64) (Lock, error)
+	TryAcquireLock(ctx context.Context, key int64) (Lock, bool, error)
+}
+
+const (
+	waitForLockSql = "SELECT true FROM pg_advisory_lock($1)"
+	tryLockSql     = "SELECT pg_try_advisory_lock($1)"
+)
+
+type advisoryLock struct {
+	key  int64
+	lock sync.Mutex
+	conn *pgxpool.Conn
+}
+
+// LockKey converts a name to a key usable with AcquireLock.
+func LockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// AcquireLock waits until the lock with the provided key is acquired. The
+// lock is held by a connection of the pool, which is dedicated to it until
+// the lock is closed: this allows for example to elect a leader among the
+// replicas of a service or to run a background job only once.
+// When the context is cancelled the acquisition is aborted and the error
+// of the context is returned.
+// https://www.postgresql.org/docs/current/explicit-locking.html#ADVISORY-LOCKS
+func AcquireLock(ctx context.Context, conn Connection, key int64) (Lock, error) {
+	l, ok := conn.(Locker)
+	if !ok {
+		return nil, ErrUnsupportedOperation
+	}
+	return l.AcquireLock(ctx, key)
+}
+
+// TryAcquireLock is similar to AcquireLock but returns immediately. The
+// boolean is false when the lock is held by someone else.
+func TryAcquireLock(ctx context.Context, conn Connection, key int64) (Lock, bool, error) {
+	l, ok := conn.(Locker)
+	if !ok {
+		return nil, false, ErrUnsupportedOperation
+	}
+	return l.TryAcquireLock(ctx, key)
+}
+
+// acquireLock runs the query acquiring the lock on a connection dedicated
+// to it.
+func acquireLock(
+	ctx context.Context, acquire func(context.Context) (*pgxpool.Conn, error), key int64, sql string,
+) (Lock, bool, error) {
+	c, err := acquire(ctx)
+	if err != nil {
+		return nil, false, analyzeAndWrapDatabaseError(err)
+	}
+
+	var acquired bool
+	err = c.QueryRow(ctx, sql, key).Scan(&acquired)
+	if err != nil {
+		// The state of the connection is unknown: it might still acquire
+		// the lock after the cancellation. Closing it guarantees that the
+		// lock is released.
+		// nolint: errcheck
+		c.Conn().Close(context.Background())
+		c.Release()
+
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		return nil, false, analyzeAndWrapDatabaseError(err)
+	}
+
+	if !acquired {
+		c.Release()
+		return nil, false, nil
+	}
+
+	return &advisoryLock{key: key, conn: c}, true, nil
+}
+
+func (l *advisoryLock) Key() int64 {
+	return l.key
+}
+
+func (l *advisoryLock) Close(ctx context.Context) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	_, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	if err != nil {
+		// Closing the connection releases the locks it holds.
+		// nolint: errcheck
+		l.conn.Conn().Close(context.Background())
+	}
+
+	l.conn.Release()
+	l.conn = nil
+
+	return analyzeAndWrapDatabaseError(err)
+}
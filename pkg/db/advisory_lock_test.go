@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unsupportedConnection struct {
+	Connection
+}
+
+func TestUnit_LockKey(t *testing.T) {
+	assert.Equal(t, LockKey("my-job"), LockKey("my-job"))
+	assert.NotEqual(t, LockKey("my-job"), LockKey("other-job"))
+}
+
+func TestUnit_AcquireLock_WhenConnectionIsNotSupported_ExpectError(t *testing.T) {
+	_, err := AcquireLock(t.Context(), unsupportedConnection{}, 1)
+	assert.Equal(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+
+	_, _, err = TryAcquireLock(t.Context(), unsupportedConnection{}, 1)
+	assert.Equal(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+}
+
+func TestUnit_AcquireLock_WhenNotConnected_ExpectError(t *testing.T) {
+	_, err := AcquireLock(t.Context(), &connectionImpl{}, 1)
+	assert.Equal(t, ErrNotConnected, err, "Actual err: %v", err)
+}
+
+func TestIT_AcquireLock(t *testing.T) {
+	conn := newTestConnection(t)
+	key := LockKey(t.Name() + time.Now().String())
+
+	lock, err := AcquireLock(t.Context(), conn, key)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, key, lock.Key())
+
+	_, acquired, err := TryAcquireLock(t.Context(), conn, key)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.False(t, acquired)
+
+	err = lock.Close(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+	err = lock.Close(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	other, acquired, err := TryAcquireLock(t.Context(), conn, key)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, acquired)
+	require.NoError(t, other.Close(t.Context()))
+}
+
+func TestIT_AcquireLock_WhenContextIsCancelled_ExpectError(t *testing.T) {
+	conn := newTestConnection(t)
+	key := LockKey(t.Name() + time.Now().String())
+
+	lock, err := AcquireLock(t.Context(), conn, key)
+	require.NoError(t, err, "Actual err: %v", err)
+	defer lock.Close(t.Context())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = AcquireLock(ctx, conn, key)
+	assert.Equal(t, context.DeadlineExceeded, err, "Actual err: %v", err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -15,22 +16,48 @@ type Connection interface {
 	BeginTx(ctx context.Context) (Transaction, error)
 
 	Exec(ctx context.Context, sql string, arguments ...any) (int64, error)
+
+	// Stats returns a snapshot of the state of the connection pool. It
+	// returns zero values when the connection is closed.
+	Stats() PoolStats
+}
+
+// Querier is implemented by the connections and transactions on which the
+// query helpers (QueryOne, QueryAll, ...) operate. The implementations of
+// this package all satisfy it, and so do the fakes of the dbtest package
+// which allow to use the helpers without a database.
+type Querier interface {
+	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
+}
+
+// Copier is implemented by the connections and transactions supporting the
+// COPY protocol, on which CopyFrom and CopyFromTx operate. The fakes of the
+// dbtest package also satisfy it.
+type Copier interface {
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource) (int64, error)
 }
 
 type connectionImpl struct {
-	pool *pgxpool.Pool
+	pool         *pgxpool.Pool
+	queryTimeout time.Duration
 }
 
-func New(ctx context.Context, config Config) (Connection, error) {
+func New(ctx context.Context, config Config, opts ...Option) (Connection, error) {
 	connStr := config.ToConnectionString()
 
-	pool, err := newPool(ctx, connStr)
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	pool, err := newPool(ctx, connStr, o)
 	if err != nil {
 		return nil, err
 	}
 
 	conn := &connectionImpl{
-		pool: pool,
+		pool:         pool,
+		queryTimeout: queryTimeout(pool.Config()),
 	}
 
 	err = conn.Ping(ctx)
@@ -75,24 +102,70 @@ func (ci *connectionImpl) Exec(ctx context.Context, sql string, arguments ...any
 		return 0, ErrNotConnected
 	}
 
-	tag, err := ci.pool.Exec(ctx, sql, arguments...)
+	defer timing.Track(ctx, timing.SegmentDb)()
+
+	queryCtx, cancel := withDefaultTimeout(ctx, ci.queryTimeout)
+	defer cancel()
+
+	tag, err := ci.pool.Exec(queryCtx, sql, arguments...)
 	if err != nil {
-		return tag.RowsAffected(), analyzeAndWrapDatabaseError(err)
+		return tag.RowsAffected(), wrapTimeoutError(queryCtx, ctx, analyzeAndWrapDatabaseError(err))
 	}
 
 	return tag.RowsAffected(), err
 }
 
-func (ci *connectionImpl) query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+func (ci *connectionImpl) Stats() PoolStats {
+	if ci.pool == nil {
+		return PoolStats{}
+	}
+
+	return newPoolStats(ci.pool.Stat())
+}
+
+func (ci *connectionImpl) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
 	if ci.pool == nil {
 		return nil, ErrNotConnected
 	}
-	rows, err := ci.pool.Query(ctx, sql, arguments...)
+	queryCtx, cancel := withDefaultTimeout(ctx, ci.queryTimeout)
+	rows, err := ci.pool.Query(queryCtx, sql, arguments...)
 
 	err = analyzeAndWrapDatabaseError(err)
 	if err != nil {
-		return nil, err
+		cancel()
+		return nil, wrapTimeoutError(queryCtx, ctx, err)
+	}
+
+	return &timeoutRows{Rows: rows, ctx: queryCtx, parent: ctx, cancel: cancel}, nil
+}
+
+func (ci *connectionImpl) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	if ci.pool == nil {
+		return 0, ErrNotConnected
 	}
 
-	return rows, nil
+	queryCtx, cancel := withDefaultTimeout(ctx, ci.queryTimeout)
+	defer cancel()
+
+	count, err := ci.pool.CopyFrom(queryCtx, table, columns, source)
+	err = analyzeAndWrapDatabaseError(err)
+	return count, wrapTimeoutError(queryCtx, ctx, err)
+}
+
+func (ci *connectionImpl) AcquireLock(ctx context.Context, key int64) (Lock, error) {
+	lock, _, err := acquireLock(ctx, ci.acquire, key, waitForLockSql)
+	return lock, err
+}
+
+func (ci *connectionImpl) TryAcquireLock(ctx context.Context, key int64) (Lock, bool, error) {
+	return acquireLock(ctx, ci.acquire, key, tryLockSql)
+}
+
+func (ci *connectionImpl) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	if ci.pool == nil {
+		return nil, ErrNotConnected
+	}
+	return ci.pool.Acquire(ctx)
 }
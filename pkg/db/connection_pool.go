@@ -9,12 +9,16 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func newPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+func newPool(ctx context.Context, connString string, opts options) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.tracer != nil {
+		config.ConnConfig.Tracer = opts.tracer
+	}
+
 	// https://github.com/jackc/pgx/issues/1195#issuecomment-2002079265
 	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
 		conn.TypeMap().RegisterType(&pgtype.Type{
@@ -23,8 +27,43 @@ func newPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
 			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
 		})
 
+		if len(opts.types) == 0 {
+			return nil
+		}
+
+		types, err := conn.LoadTypes(ctx, typesToLoad(opts.types))
+		if err != nil {
+			return err
+		}
+		conn.TypeMap().RegisterTypes(types)
+
 		return nil
 	}
 
 	return pgxpool.NewWithConfig(ctx, config)
 }
+
+type PoolStats struct {
+	AcquiredConns   int32         `json:"acquiredConns"`
+	IdleConns       int32         `json:"idleConns"`
+	TotalConns      int32         `json:"totalConns"`
+	MaxConns        int32         `json:"maxConns"`
+	AcquireCount    int64         `json:"acquireCount"`
+	AcquireDuration time.Duration `json:"acquireDuration"`
+	// EmptyAcquireCount is the number of acquisitions which had to wait
+	// for a connection to be available: a high value indicates that the
+	// pool is too small.
+	EmptyAcquireCount int64 `json:"emptyAcquireCount"`
+}
+
+func newPoolStats(stat *pgxpool.Stat) PoolStats {
+	return PoolStats{
+		AcquiredConns:     stat.AcquiredConns(),
+		IdleConns:         stat.IdleConns(),
+		TotalConns:        stat.TotalConns(),
+		MaxConns:          stat.MaxConns(),
+		AcquireCount:      stat.AcquireCount(),
+		AcquireDuration:   stat.AcquireDuration(),
+		EmptyAcquireCount: stat.EmptyAcquireCount(),
+	}
+}
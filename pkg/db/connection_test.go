@@ -58,6 +58,18 @@ func TestIT_Connection_Close(t *testing.T) {
 	assert.Equal(t, ErrNotConnected, err, "Actual err: %v", err)
 }
 
+func TestIT_Connection_Stats(t *testing.T) {
+	conn := newTestConnection(t)
+
+	actual := conn.Stats()
+
+	assert.Positive(t, actual.MaxConns)
+	assert.Positive(t, actual.TotalConns)
+
+	conn.Close(t.Context())
+	assert.Equal(t, PoolStats{}, conn.Stats())
+}
+
 func TestIT_Connection_BeginTx_TimeStampIsValid(t *testing.T) {
 	conn := newTestConnection(t)
 
@@ -124,7 +136,7 @@ func TestIT_Connection_Exec(t *testing.T) {
 			SqlCode:    "23505",
 			Schema:     "test_db_schema",
 			Table:      "my_table",
-			Column:     "",
+			Column:     "name",
 			Constraint: "my_table_name_key",
 			Cause:      actual.Cause,
 		}
@@ -146,7 +158,7 @@ func TestIT_Connection_Exec(t *testing.T) {
 			SqlCode:    "23503",
 			Schema:     "test_db_schema",
 			Table:      "dependent_table",
-			Column:     "",
+			Column:     "id",
 			Constraint: "dependent_table_id_fkey",
 			Cause:      actual.Cause,
 		}
@@ -181,7 +193,7 @@ func TestIT_Connection_Exec(t *testing.T) {
 			SqlCode:    "23505",
 			Schema:     "test_db_schema",
 			Table:      "my_table",
-			Column:     "",
+			Column:     "name",
 			Constraint: "my_table_name_key",
 			Cause:      actual.Cause,
 		}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
+	"github.com/jackc/pgx/v5"
+)
+
+// CopyFrom inserts the rows in the table using the COPY protocol, which is
+// much faster than individual inserts for large amounts of data. Struct
+// fields are mapped to the columns the same way as in QueryOne: using the
+// `db` tag or the name of the field. Other types are inserted in a single
+// column. It returns the number of rows inserted.
+func CopyFrom[T any](ctx context.Context, conn Connection, table string, columns []string, rows []T) (int64, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
+	c, ok := conn.(Copier)
+	if !ok {
+		return 0, ErrUnsupportedOperation
+	}
+
+	source, err := newCopySource(columns, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.CopyFrom(ctx, tableIdentifier(table), columns, source)
+}
+
+func CopyFromTx[T any](ctx context.Context, tx Transaction, table string, columns []string, rows []T) (int64, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
+	c, ok := tx.(Copier)
+	if !ok {
+		return 0, ErrUnsupportedOperation
+	}
+
+	source, err := newCopySource(columns, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.CopyFrom(ctx, tableIdentifier(table), columns, source)
+}
+
+// tableIdentifier supports tables qualified with their schema.
+func tableIdentifier(table string) pgx.Identifier {
+	return pgx.Identifier(strings.Split(table, "."))
+}
+
+func newCopySource[T any](columns []string, rows []T) (pgx.CopyFromSource, error) {
+	if !isRowStruct[T]() {
+		return pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+			return []any{rows[i]}, nil
+		}), nil
+	}
+
+	structType := reflect.TypeFor[T]()
+	fields, err := fieldsForColumns(structType, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+		row := reflect.ValueOf(rows[i])
+
+		values := make([]any, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, columnValue(row, structType.FieldByIndex(field)))
+		}
+
+		return values, nil
+	}), nil
+}
+
+// fieldsForColumns returns the index of the field of the struct for each
+// column.
+func fieldsForColumns(structType reflect.Type, columns []string) ([][]int, error) {
+	byName := fieldsByColumnName(structType)
+
+	out := make([][]int, 0, len(columns))
+	for _, column := range columns {
+		index, ok := lookupField(byName, column)
+		if !ok {
+			return nil, ErrUnknownCopyColumn
+		}
+
+		out = append(out, index)
+	}
+
+	return out, nil
+}
+
+// fieldsByColumnName indexes the fields of the struct by the name of the
+// column they map to. The rules follow pgx.RowToStructByName: the `db` tag
+// is used if set, otherwise the name of the field is compared ignoring the
+// case and underscores (see lookupField).
+func fieldsByColumnName(structType reflect.Type) map[string][]int {
+	byName := make(map[string][]int)
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name, tagged := field.Tag.Lookup("db")
+		name, _, _ = strings.Cut(name, ",")
+		if name == "-" {
+			continue
+		}
+		if !tagged || name == "" {
+			name = normalizeColumnName(field.Name)
+		}
+
+		if _, exists := byName[name]; !exists {
+			byName[name] = field.Index
+		}
+	}
+
+	return byName
+}
+
+func lookupField(byName map[string][]int, column string) ([]int, bool) {
+	index, ok := byName[column]
+	if !ok {
+		index, ok = byName[normalizeColumnName(column)]
+	}
+	return index, ok
+}
+
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
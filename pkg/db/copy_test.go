@@ -0,0 +1,122 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type copyBase struct {
+	CreatedBy string
+}
+
+type copySample struct {
+	copyBase
+	Id        uuid.UUID
+	FirstName string
+	Label     string `db:"display_name"`
+	LastName  string `db:",omitempty"`
+	Ignored   string `db:"-"`
+	internal  string
+}
+
+func TestUnit_FieldsForColumns(t *testing.T) {
+	columns := []string{"id", "first_name", "display_name", "last_name", "created_by"}
+
+	actual, err := fieldsForColumns(reflect.TypeFor[copySample](), columns)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	expected := [][]int{{1}, {2}, {3}, {4}, {0, 0}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_FieldsForColumns_WhenColumnIsUnknown_ExpectError(t *testing.T) {
+	for _, column := range []string{"ignored", "internal", "label", "unknown"} {
+		t.Run(column, func(t *testing.T) {
+			_, err := fieldsForColumns(reflect.TypeFor[copySample](), []string{column})
+
+			assert.ErrorIs(t, err, ErrUnknownCopyColumn, "Actual err: %v", err)
+		})
+	}
+}
+
+func TestUnit_NewCopySource_ForStruct(t *testing.T) {
+	id := uuid.New()
+	rows := []copySample{{Id: id, FirstName: "first", Label: "label"}}
+
+	source, err := newCopySource([]string{"display_name", "id"}, rows)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	require.True(t, source.Next())
+	actual, err := source.Values()
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []any{"label", id}, actual)
+	assert.False(t, source.Next())
+}
+
+func TestUnit_NewCopySource_ForSingleColumn(t *testing.T) {
+	source, err := newCopySource([]string{"name"}, []string{"first", "second"})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	var actual []any
+	for source.Next() {
+		values, err := source.Values()
+		require.NoError(t, err, "Actual err: %v", err)
+		actual = append(actual, values...)
+	}
+
+	assert.Equal(t, []any{"first", "second"}, actual)
+}
+
+func TestIT_CopyFrom(t *testing.T) {
+	t.Run("inserts all rows", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		rows := []element{
+			{Id: uuid.New(), Name: uuid.NewString()},
+			{Id: uuid.New(), Name: uuid.NewString()},
+		}
+
+		count, err := CopyFrom(t.Context(), conn, "my_table", []string{"id", "name"}, rows)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.Equal(t, int64(2), count)
+		assertNameForId(t, conn, rows[0].Id, rows[0].Name)
+		assertNameForId(t, conn, rows[1].Id, rows[1].Name)
+	})
+
+	t.Run("returns error when column is unknown", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		_, err := CopyFrom(t.Context(), conn, "my_table", []string{"id", "unknown"}, []element{{}})
+
+		assert.ErrorIs(t, err, ErrUnknownCopyColumn, "Actual err: %v", err)
+	})
+}
+
+func TestIT_CopyFromTx(t *testing.T) {
+	t.Run("inserts all rows when transaction is committed", func(t *testing.T) {
+		conn, tx := newTestTransaction(t)
+
+		rows := []element{{Id: uuid.New(), Name: uuid.NewString()}}
+
+		count, err := CopyFromTx(t.Context(), tx, "my_table", []string{"id", "name"}, rows)
+		require.NoError(t, err, "Actual err: %v", err)
+		tx.Close(t.Context())
+
+		assert.Equal(t, int64(1), count)
+		assertNameForId(t, conn, rows[0].Id, rows[0].Name)
+	})
+
+	t.Run("returns error when already committed", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+		tx.Close(t.Context())
+
+		_, err := CopyFromTx(t.Context(), tx, "my_table", []string{"id", "name"}, []element{{}})
+
+		assert.ErrorIs(t, err, ErrAlreadyCommitted, "Actual err: %v", err)
+	})
+}
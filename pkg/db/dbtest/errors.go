@@ -0,0 +1,22 @@
+package dbtest
+
+import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+
+const (
+	errUnexpectedQuery errors.ErrorCode = 130
+	errUnsupportedScan errors.ErrorCode = 131
+)
+
+var (
+	ErrUnexpectedQuery = errors.FromCode(errUnexpectedQuery)
+	ErrUnsupportedScan = errors.FromCode(errUnsupportedScan)
+)
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errUnexpectedQuery, Name: "dbtest.unexpected_query"},
+		{Code: errUnsupportedScan, Name: "dbtest.unsupported_scan"},
+	} {
+		errors.Register(info)
+	}
+}
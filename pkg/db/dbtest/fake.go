@@ -0,0 +1,459 @@
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/jackc/pgx/v5"
+)
+
+// Call records a query or a statement executed on a fake.
+type Call struct {
+	Sql       string
+	Arguments []any
+}
+
+// Expectation defines the result of the queries matching a pattern. By
+// default it is used once: it can be made to apply to all the matching
+// queries with Repeatedly.
+type Expectation struct {
+	pattern      string
+	columns      []string
+	rows         [][]any
+	rowsAffected int64
+	err          error
+	repeated     bool
+	used         bool
+}
+
+// ReturnRows defines the rows returned by the query. The values of each
+// row are in the order of the columns. The number of rows affected is set
+// accordingly.
+func (e *Expectation) ReturnRows(columns []string, rows ...[]any) *Expectation {
+	e.columns = columns
+	e.rows = rows
+	e.rowsAffected = int64(len(rows))
+	return e
+}
+
+// ReturnRowsAffected defines the count returned by Exec.
+func (e *Expectation) ReturnRowsAffected(count int64) *Expectation {
+	e.rowsAffected = count
+	return e
+}
+
+func (e *Expectation) ReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+func (e *Expectation) Repeatedly() *Expectation {
+	e.repeated = true
+	return e
+}
+
+// fake holds the state shared by a fake connection and the transactions
+// started from it.
+type fake struct {
+	lock         sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+	commitErr    error
+	// locks holds the advisory locks currently acquired: the channel is
+	// closed when the lock is released.
+	locks map[int64]chan struct{}
+}
+
+// Expect registers the result of the next query containing the pattern.
+// The whitespaces are normalized before comparing the pattern and the
+// query. Expectations are matched in the order they were registered.
+func (f *fake) Expect(pattern string) *Expectation {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	e := &Expectation{pattern: normalizeSql(pattern)}
+	f.expectations = append(f.expectations, e)
+	return e
+}
+
+// Calls returns the queries and statements executed so far.
+func (f *fake) Calls() []Call {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	out := make([]Call, len(f.calls))
+	copy(out, f.calls)
+	return out
+}
+
+// FailCommit makes the commit of the transactions return the error, as
+// a serialization failure raised by the database at COMMIT would.
+func (f *fake) FailCommit(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.commitErr = err
+}
+
+// Unmet returns the patterns of the expectations which were not used.
+func (f *fake) Unmet() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var out []string
+	for _, e := range f.expectations {
+		if !e.used {
+			out = append(out, e.pattern)
+		}
+	}
+	return out
+}
+
+func (f *fake) match(sql string, arguments []any) (*Expectation, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.calls = append(f.calls, Call{Sql: sql, Arguments: arguments})
+
+	normalized := normalizeSql(sql)
+	for _, e := range f.expectations {
+		if e.used && !e.repeated {
+			continue
+		}
+		if strings.Contains(normalized, e.pattern) {
+			e.used = true
+			return e, nil
+		}
+	}
+
+	return nil, ErrUnexpectedQuery
+}
+
+func (f *fake) exec(sql string, arguments []any) (int64, error) {
+	e, err := f.match(sql, arguments)
+	if err != nil {
+		return 0, err
+	}
+	return e.rowsAffected, e.err
+}
+
+func (f *fake) query(sql string, arguments []any) (pgx.Rows, error) {
+	e, err := f.match(sql, arguments)
+	if err != nil {
+		return nil, err
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return newRows(e.columns, e.rows), nil
+}
+
+// copyFrom consumes the source and matches the expectations against a
+// statement of the form `COPY my_table (id, name) FROM STDIN`. The call
+// records each row as an argument.
+func (f *fake) copyFrom(table pgx.Identifier, columns []string, source pgx.CopyFromSource) (int64, error) {
+	var rows []any
+	for source.Next() {
+		values, err := source.Values()
+		if err != nil {
+			return 0, err
+		}
+		rows = append(rows, values)
+	}
+	if err := source.Err(); err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf("COPY %s (%s) FROM STDIN", strings.Join(table, "."), strings.Join(columns, ", "))
+	e, err := f.match(sql, rows)
+	if err != nil {
+		return 0, err
+	}
+	if e.err != nil {
+		return 0, e.err
+	}
+	return int64(len(rows)), nil
+}
+
+// Locked returns true when the advisory lock with the key is held.
+func (f *fake) Locked(key int64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	_, ok := f.locks[key]
+	return ok
+}
+
+func (f *fake) acquireLock(ctx context.Context, key int64, wait bool) (db.Lock, bool, error) {
+	for {
+		f.lock.Lock()
+		released, held := f.locks[key]
+		if !held {
+			if f.locks == nil {
+				f.locks = make(map[int64]chan struct{})
+			}
+			f.locks[key] = make(chan struct{})
+			f.lock.Unlock()
+			return &fakeLock{fake: f, key: key}, true, nil
+		}
+		f.lock.Unlock()
+
+		if !wait {
+			return nil, false, nil
+		}
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+func (f *fake) releaseLock(key int64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if released, ok := f.locks[key]; ok {
+		close(released)
+		delete(f.locks, key)
+	}
+}
+
+type fakeLock struct {
+	fake     *fake
+	key      int64
+	released sync.Once
+}
+
+func (l *fakeLock) Key() int64 {
+	return l.key
+}
+
+func (l *fakeLock) Close(ctx context.Context) error {
+	l.released.Do(func() {
+		l.fake.releaseLock(l.key)
+	})
+	return nil
+}
+
+func normalizeSql(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// FakeConnection is an in-memory db.Connection whose results are defined
+// with Expect. It can be used with the query helpers of the db package.
+type FakeConnection struct {
+	*fake
+
+	closed       bool
+	transactions []*FakeTransaction
+}
+
+var (
+	_ db.Connection = (*FakeConnection)(nil)
+	_ db.Querier    = (*FakeConnection)(nil)
+	_ db.Copier     = (*FakeConnection)(nil)
+	_ db.Locker     = (*FakeConnection)(nil)
+)
+
+func NewFakeConnection() *FakeConnection {
+	return &FakeConnection{
+		fake: &fake{},
+	}
+}
+
+func (fc *FakeConnection) Close(ctx context.Context) {
+	fc.closed = true
+}
+
+func (fc *FakeConnection) Ping(ctx context.Context) error {
+	if fc.closed {
+		return db.ErrNotConnected
+	}
+	return nil
+}
+
+func (fc *FakeConnection) BeginTx(ctx context.Context) (db.Transaction, error) {
+	if fc.closed {
+		return nil, db.ErrNotConnected
+	}
+
+	tx := newFakeTransaction(fc.fake, time.Now())
+	fc.transactions = append(fc.transactions, tx)
+	return tx, nil
+}
+
+func (fc *FakeConnection) Exec(ctx context.Context, sql string, arguments ...any) (int64, error) {
+	if fc.closed {
+		return 0, db.ErrNotConnected
+	}
+	return fc.exec(sql, arguments)
+}
+
+func (fc *FakeConnection) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+	if fc.closed {
+		return nil, db.ErrNotConnected
+	}
+	return fc.query(sql, arguments)
+}
+
+func (fc *FakeConnection) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	if fc.closed {
+		return 0, db.ErrNotConnected
+	}
+	return fc.copyFrom(table, columns, source)
+}
+
+// AcquireLock waits until no other lock with the key is held, like an
+// advisory lock taken on a dedicated connection of the pool.
+func (fc *FakeConnection) AcquireLock(ctx context.Context, key int64) (db.Lock, error) {
+	if fc.closed {
+		return nil, db.ErrNotConnected
+	}
+	lock, _, err := fc.acquireLock(ctx, key, true)
+	return lock, err
+}
+
+func (fc *FakeConnection) TryAcquireLock(ctx context.Context, key int64) (db.Lock, bool, error) {
+	if fc.closed {
+		return nil, false, db.ErrNotConnected
+	}
+	return fc.acquireLock(ctx, key, false)
+}
+
+func (fc *FakeConnection) Stats() db.PoolStats {
+	return db.PoolStats{}
+}
+
+// Transactions returns the transactions started from the connection.
+func (fc *FakeConnection) Transactions() []*FakeTransaction {
+	return fc.transactions
+}
+
+// FakeTransaction is the db.Transaction created by the fakes. It shares
+// the expectations and calls of the connection it was started from.
+type FakeTransaction struct {
+	*fake
+
+	timeStamp  time.Time
+	closed     bool
+	rolledBack bool
+	failed     bool
+	nested     []*FakeTransaction
+}
+
+var (
+	_ db.Transaction = (*FakeTransaction)(nil)
+	_ db.Querier     = (*FakeTransaction)(nil)
+	_ db.Copier      = (*FakeTransaction)(nil)
+)
+
+func newFakeTransaction(f *fake, timeStamp time.Time) *FakeTransaction {
+	return &FakeTransaction{
+		fake:      f,
+		timeStamp: timeStamp,
+	}
+}
+
+func (ft *FakeTransaction) Close(ctx context.Context) {
+	// nolint: errcheck
+	ft.Commit(ctx)
+}
+
+func (ft *FakeTransaction) Commit(ctx context.Context) error {
+	if ft.closed {
+		return db.ErrAlreadyCommitted
+	}
+	ft.closed = true
+
+	if ft.rolledBack || ft.failed {
+		return nil
+	}
+
+	ft.lock.Lock()
+	defer ft.lock.Unlock()
+
+	if ft.commitErr != nil {
+		ft.failed = true
+	}
+	return ft.commitErr
+}
+
+func (ft *FakeTransaction) TimeStamp() time.Time {
+	return ft.timeStamp
+}
+
+func (ft *FakeTransaction) Exec(ctx context.Context, sql string, arguments ...any) (int64, error) {
+	if ft.closed {
+		return 0, db.ErrAlreadyCommitted
+	}
+
+	count, err := ft.exec(sql, arguments)
+	ft.failed = ft.failed || err != nil
+	return count, err
+}
+
+func (ft *FakeTransaction) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+	if ft.closed {
+		return nil, db.ErrAlreadyCommitted
+	}
+
+	rows, err := ft.query(sql, arguments)
+	ft.failed = ft.failed || err != nil
+	return rows, err
+}
+
+func (ft *FakeTransaction) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	if ft.closed {
+		return 0, db.ErrAlreadyCommitted
+	}
+
+	count, err := ft.copyFrom(table, columns, source)
+	ft.failed = ft.failed || err != nil
+	return count, err
+}
+
+func (ft *FakeTransaction) BeginTx(ctx context.Context) (db.Transaction, error) {
+	if ft.closed {
+		return nil, db.ErrAlreadyCommitted
+	}
+
+	tx := newFakeTransaction(ft.fake, ft.timeStamp)
+	ft.nested = append(ft.nested, tx)
+	return tx, nil
+}
+
+func (ft *FakeTransaction) Rollback() error {
+	if ft.closed {
+		return db.ErrAlreadyCommitted
+	}
+
+	ft.rolledBack = true
+	return nil
+}
+
+// Committed returns true when the transaction was closed without errors
+// nor a call to Rollback, like a real transaction would be committed.
+func (ft *FakeTransaction) Committed() bool {
+	return ft.closed && !ft.rolledBack && !ft.failed
+}
+
+// RolledBack returns true when the transaction was closed after a failure
+// or a call to Rollback.
+func (ft *FakeTransaction) RolledBack() bool {
+	return ft.closed && (ft.rolledBack || ft.failed)
+}
+
+// Nested returns the transactions started from this one.
+func (ft *FakeTransaction) Nested() []*FakeTransaction {
+	return ft.nested
+}
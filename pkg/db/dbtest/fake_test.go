@@ -0,0 +1,282 @@
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type element struct {
+	Id   uuid.UUID `db:"id"`
+	Name string    `db:"name"`
+}
+
+var errSomeError = fmt.Errorf("some error")
+
+func TestUnit_FakeConnection_QueryOne(t *testing.T) {
+	conn := NewFakeConnection()
+	id := uuid.New()
+	conn.Expect("FROM my_table WHERE id").ReturnRows([]string{"id", "name"}, []any{id, "my-name"})
+
+	actual, err := db.QueryOne[element](t.Context(), conn, "SELECT id, name FROM my_table WHERE id = $1", id)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, element{Id: id, Name: "my-name"}, actual)
+	expectedCalls := []Call{
+		{Sql: "SELECT id, name FROM my_table WHERE id = $1", Arguments: []any{id}},
+	}
+	assert.Equal(t, expectedCalls, conn.Calls())
+	assert.Empty(t, conn.Unmet())
+}
+
+func TestUnit_FakeConnection_QueryOne_WhenNoRows_ExpectNoMatchingRows(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("SELECT").ReturnRows([]string{"id", "name"})
+
+	_, err := db.QueryOne[element](t.Context(), conn, "SELECT id, name FROM my_table")
+
+	assert.Equal(t, db.ErrNoMatchingRows, err, "Actual err: %v", err)
+}
+
+func TestUnit_FakeConnection_QueryAll(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("SELECT name").ReturnRows([]string{"name"}, []any{"a"}, []any{"b"})
+
+	actual, err := db.QueryAll[string](t.Context(), conn, "SELECT name FROM my_table")
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []string{"a", "b"}, actual)
+}
+
+func TestUnit_FakeConnection_ReturnError(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("INSERT").ReturnError(errSomeError)
+
+	_, err := conn.Exec(t.Context(), "INSERT INTO my_table VALUES ($1)", 1)
+
+	assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+}
+
+func TestUnit_FakeConnection_Exec(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("DELETE   FROM\nmy_table").ReturnRowsAffected(3)
+
+	actual, err := conn.Exec(t.Context(), "DELETE FROM my_table WHERE name = $1", "a")
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int64(3), actual)
+}
+
+func TestUnit_FakeConnection_ExpectationsAreUsedOnce(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("DELETE").ReturnRowsAffected(1)
+	conn.Expect("UPDATE").ReturnRowsAffected(2).Repeatedly()
+
+	_, err := conn.Exec(t.Context(), "DELETE FROM my_table")
+	require.NoError(t, err, "Actual err: %v", err)
+	_, err = conn.Exec(t.Context(), "DELETE FROM my_table")
+	assert.Equal(t, ErrUnexpectedQuery, err, "Actual err: %v", err)
+
+	for range 3 {
+		count, err := conn.Exec(t.Context(), "UPDATE my_table SET name = 'a'")
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, int64(2), count)
+	}
+}
+
+func TestUnit_FakeConnection_Unmet(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("DELETE")
+
+	assert.Equal(t, []string{"DELETE"}, conn.Unmet())
+}
+
+func TestUnit_FakeConnection_Close(t *testing.T) {
+	conn := NewFakeConnection()
+	require.NoError(t, conn.Ping(t.Context()))
+
+	conn.Close(t.Context())
+
+	assert.Equal(t, db.ErrNotConnected, conn.Ping(t.Context()))
+	_, err := conn.Exec(t.Context(), "DELETE FROM my_table")
+	assert.Equal(t, db.ErrNotConnected, err, "Actual err: %v", err)
+}
+
+func TestUnit_FakeConnection_CopyFrom(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("COPY my_table (id, name)")
+	id := uuid.New()
+
+	rows := []element{{Id: id, Name: "my-name"}}
+	actual, err := db.CopyFrom(t.Context(), conn, "my_table", []string{"id", "name"}, rows)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int64(1), actual)
+	expectedCalls := []Call{
+		{Sql: "COPY my_table (id, name) FROM STDIN", Arguments: []any{[]any{id, "my-name"}}},
+	}
+	assert.Equal(t, expectedCalls, conn.Calls())
+}
+
+func TestUnit_FakeConnection_CopyFrom_ReturnError(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("COPY my_table").ReturnError(errSomeError)
+
+	_, err := db.CopyFrom(t.Context(), conn, "my_table", []string{"name"}, []string{"my-name"})
+
+	assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+}
+
+func TestUnit_FakeTransaction_CopyFrom(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("COPY my_table").ReturnError(errSomeError)
+
+	err := db.WithTransaction(t.Context(), conn, func(tx db.Transaction) error {
+		_, err := db.CopyFromTx(t.Context(), tx, "my_table", []string{"name"}, []string{"my-name"})
+		return err
+	})
+
+	assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+	assert.True(t, conn.Transactions()[0].RolledBack())
+}
+
+func TestUnit_FakeConnection_TryAcquireLock(t *testing.T) {
+	conn := NewFakeConnection()
+
+	lock, acquired, err := db.TryAcquireLock(t.Context(), conn, 1)
+	require.NoError(t, err, "Actual err: %v", err)
+	require.True(t, acquired)
+	assert.Equal(t, int64(1), lock.Key())
+	assert.True(t, conn.Locked(1))
+
+	_, acquired, err = db.TryAcquireLock(t.Context(), conn, 1)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.False(t, acquired)
+
+	require.NoError(t, lock.Close(t.Context()))
+	require.NoError(t, lock.Close(t.Context()))
+	assert.False(t, conn.Locked(1))
+
+	other, acquired, err := db.TryAcquireLock(t.Context(), conn, 1)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, acquired)
+	require.NoError(t, other.Close(t.Context()))
+}
+
+func TestUnit_FakeConnection_AcquireLock(t *testing.T) {
+	t.Run("waits until lock is released", func(t *testing.T) {
+		conn := NewFakeConnection()
+		lock, err := db.AcquireLock(t.Context(), conn, 1)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		acquired := make(chan db.Lock)
+		go func() {
+			other, err := db.AcquireLock(t.Context(), conn, 1)
+			assert.NoError(t, err, "Actual err: %v", err)
+			acquired <- other
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		require.NoError(t, lock.Close(t.Context()))
+		other := <-acquired
+		assert.Equal(t, int64(1), other.Key())
+	})
+
+	t.Run("returns error when context is cancelled", func(t *testing.T) {
+		conn := NewFakeConnection()
+		_, err := db.AcquireLock(t.Context(), conn, 1)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		_, err = db.AcquireLock(ctx, conn, 1)
+
+		assert.Equal(t, context.DeadlineExceeded, err, "Actual err: %v", err)
+	})
+}
+
+func TestUnit_FakeTransaction_WithTransaction(t *testing.T) {
+	t.Run("commits when function succeeds", func(t *testing.T) {
+		conn := NewFakeConnection()
+		conn.Expect("INSERT").ReturnRowsAffected(1)
+
+		err := db.WithTransaction(t.Context(), conn, func(tx db.Transaction) error {
+			_, err := tx.Exec(t.Context(), "INSERT INTO my_table VALUES ($1)", 1)
+			return err
+		})
+
+		require.NoError(t, err, "Actual err: %v", err)
+		require.Len(t, conn.Transactions(), 1)
+		assert.True(t, conn.Transactions()[0].Committed())
+		assert.False(t, conn.Transactions()[0].RolledBack())
+	})
+
+	t.Run("rolls back when function fails", func(t *testing.T) {
+		conn := NewFakeConnection()
+
+		err := db.WithTransaction(t.Context(), conn, func(tx db.Transaction) error {
+			return errSomeError
+		})
+
+		assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+		require.Len(t, conn.Transactions(), 1)
+		assert.True(t, conn.Transactions()[0].RolledBack())
+	})
+
+	t.Run("returns error when commit fails", func(t *testing.T) {
+		conn := NewFakeConnection()
+		conn.FailCommit(errSomeError)
+
+		err := db.WithTransaction(t.Context(), conn, func(tx db.Transaction) error {
+			return nil
+		})
+
+		assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+		require.Len(t, conn.Transactions(), 1)
+		assert.False(t, conn.Transactions()[0].Committed())
+	})
+
+	t.Run("rolls back when query fails", func(t *testing.T) {
+		conn := NewFakeConnection()
+
+		tx, err := conn.BeginTx(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+		_, err = db.QueryOneTx[int](t.Context(), tx, "SELECT 1")
+		assert.Equal(t, ErrUnexpectedQuery, err, "Actual err: %v", err)
+		tx.Close(t.Context())
+
+		assert.True(t, conn.Transactions()[0].RolledBack())
+	})
+}
+
+func TestUnit_FakeTransaction_Nested(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("SELECT").ReturnRows([]string{"name"}, []any{"a"})
+
+	tx, err := conn.BeginTx(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+	nested, err := tx.BeginTx(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := db.QueryOneTx[string](t.Context(), nested, "SELECT name FROM my_table")
+	require.NoError(t, err, "Actual err: %v", err)
+	nested.Close(t.Context())
+	tx.Close(t.Context())
+
+	assert.Equal(t, "a", actual)
+	assert.Equal(t, tx.TimeStamp(), nested.TimeStamp())
+	fakeTx := conn.Transactions()[0]
+	require.Len(t, fakeTx.Nested(), 1)
+	assert.True(t, fakeTx.Nested()[0].Committed())
+}
@@ -0,0 +1,142 @@
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// rows implements pgx.Rows on top of in-memory values so that the results
+// of the fakes go through the same collectors as the real queries.
+type rows struct {
+	columns []string
+	values  [][]any
+	current int
+	err     error
+	closed  bool
+}
+
+var _ pgx.Rows = (*rows)(nil)
+
+func newRows(columns []string, values [][]any) *rows {
+	return &rows{
+		columns: columns,
+		values:  values,
+		current: -1,
+	}
+}
+
+func (r *rows) Close() {
+	r.closed = true
+}
+
+func (r *rows) Err() error {
+	return r.err
+}
+
+func (r *rows) CommandTag() pgconn.CommandTag {
+	return pgconn.NewCommandTag(fmt.Sprintf("SELECT %d", len(r.values)))
+}
+
+func (r *rows) FieldDescriptions() []pgconn.FieldDescription {
+	out := make([]pgconn.FieldDescription, 0, len(r.columns))
+	for _, column := range r.columns {
+		out = append(out, pgconn.FieldDescription{Name: column})
+	}
+	return out
+}
+
+func (r *rows) Next() bool {
+	if r.closed || r.err != nil {
+		return false
+	}
+
+	r.current++
+	if r.current >= len(r.values) {
+		r.Close()
+		return false
+	}
+	return true
+}
+
+func (r *rows) Scan(dest ...any) error {
+	row := r.values[r.current]
+	if len(dest) != len(row) {
+		r.err = ErrUnsupportedScan
+		return r.err
+	}
+
+	for i, d := range dest {
+		if err := assign(d, row[i]); err != nil {
+			r.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rows) Values() ([]any, error) {
+	return r.values[r.current], nil
+}
+
+func (r *rows) RawValues() [][]byte {
+	return nil
+}
+
+func (r *rows) Conn() *pgx.Conn {
+	return nil
+}
+
+// assign stores the value in the destination, which is a pointer. The value
+// should be assignable or convertible to the type of the destination.
+func assign(dest any, value any) error {
+	d := reflect.ValueOf(dest)
+	if d.Kind() != reflect.Pointer || d.IsNil() {
+		return ErrUnsupportedScan
+	}
+	target := d.Elem()
+
+	if value != nil && reflect.TypeOf(value).AssignableTo(target.Type()) {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case target.Kind() == reflect.Pointer:
+		ptr := reflect.New(target.Type().Elem())
+		if err := assign(ptr.Interface(), value); err != nil {
+			return err
+		}
+		target.Set(ptr)
+	case isConvertible(v.Type(), target.Type()):
+		target.Set(v.Convert(target.Type()))
+	default:
+		return ErrUnsupportedScan
+	}
+
+	return nil
+}
+
+// isConvertible prevents the conversions which are valid in go but would
+// not be done by the database, such as an integer into a string.
+func isConvertible(from reflect.Type, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	if to.Kind() == reflect.String {
+		return from.Kind() == reflect.String
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status string
+
+func TestUnit_Assign(t *testing.T) {
+	t.Run("assigns value", func(t *testing.T) {
+		var actual string
+		require.NoError(t, assign(&actual, "a"))
+		assert.Equal(t, "a", actual)
+	})
+
+	t.Run("converts value", func(t *testing.T) {
+		var actual status
+		require.NoError(t, assign(&actual, "active"))
+		assert.Equal(t, status("active"), actual)
+
+		var count int64
+		require.NoError(t, assign(&count, 2))
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("sets zero value for nil", func(t *testing.T) {
+		actual := "a"
+		require.NoError(t, assign(&actual, nil))
+		assert.Equal(t, "", actual)
+	})
+
+	t.Run("allocates pointer", func(t *testing.T) {
+		var actual *string
+		require.NoError(t, assign(&actual, "a"))
+		require.NotNil(t, actual)
+		assert.Equal(t, "a", *actual)
+	})
+
+	t.Run("uses scanner", func(t *testing.T) {
+		var actual db.JSON[[]string]
+		require.NoError(t, assign(&actual, []byte(`["a"]`)))
+		assert.Equal(t, []string{"a"}, actual.V)
+	})
+
+	t.Run("returns error when value is not convertible", func(t *testing.T) {
+		var actual string
+		assert.Equal(t, ErrUnsupportedScan, assign(&actual, 2))
+	})
+}
+
+func TestUnit_Rows_Scan_WhenColumnCountDiffers_ExpectError(t *testing.T) {
+	r := newRows([]string{"a"}, [][]any{{1, 2}})
+	require.True(t, r.Next())
+
+	var a int
+	err := r.Scan(&a)
+
+	assert.Equal(t, ErrUnsupportedScan, err, "Actual err: %v", err)
+	assert.Equal(t, ErrUnsupportedScan, r.Err())
+	assert.False(t, r.Next())
+}
@@ -0,0 +1,151 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// NewTransaction starts a transaction which is rolled back when the test
+// completes: the changes made by the test are never visible to the other
+// ones, without having to clean the tables.
+func NewTransaction(t testing.TB, conn db.Connection) db.Transaction {
+	t.Helper()
+
+	tx, err := conn.BeginTx(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	t.Cleanup(func() {
+		// The context of the test is already cancelled when the cleanup
+		// functions run.
+		ctx := context.Background()
+
+		// The transaction can't be committed yet.
+		// nolint: errcheck
+		tx.Rollback()
+		tx.Close(ctx)
+	})
+
+	return tx
+}
+
+// NewConnection returns a connection running everything in a transaction
+// rolled back when the test completes (see NewTransaction). It allows to
+// isolate the tests of code expecting a connection.
+//
+// Each statement runs in its own nested transaction so that, like with a
+// real connection, a failed statement does not prevent the next ones from
+// running.
+func NewConnection(t testing.TB, conn db.Connection) db.Connection {
+	t.Helper()
+
+	return &txConnection{
+		conn: conn,
+		tx:   NewTransaction(t, conn),
+	}
+}
+
+type txConnection struct {
+	conn db.Connection
+	tx   db.Transaction
+}
+
+var (
+	_ db.Connection = (*txConnection)(nil)
+	_ db.Querier    = (*txConnection)(nil)
+	_ db.Copier     = (*txConnection)(nil)
+	_ db.Locker     = (*txConnection)(nil)
+)
+
+// Close does nothing: the transaction is rolled back at the end of the test.
+func (c *txConnection) Close(ctx context.Context) {}
+
+func (c *txConnection) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+func (c *txConnection) BeginTx(ctx context.Context) (db.Transaction, error) {
+	return c.tx.BeginTx(ctx)
+}
+
+func (c *txConnection) Exec(ctx context.Context, sql string, arguments ...any) (int64, error) {
+	nested, err := c.tx.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer nested.Close(ctx)
+
+	return nested.Exec(ctx, sql, arguments...)
+}
+
+func (c *txConnection) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+	nested, err := c.tx.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	querier, ok := nested.(db.Querier)
+	if !ok {
+		nested.Close(ctx)
+		return nil, db.ErrUnsupportedOperation
+	}
+
+	rows, err := querier.Query(ctx, sql, arguments...)
+	if err != nil {
+		nested.Close(ctx)
+		return nil, err
+	}
+
+	return &nestedRows{Rows: rows, ctx: ctx, tx: nested}, nil
+}
+
+func (c *txConnection) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	nested, err := c.tx.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer nested.Close(ctx)
+
+	copier, ok := nested.(db.Copier)
+	if !ok {
+		return 0, db.ErrUnsupportedOperation
+	}
+	return copier.CopyFrom(ctx, table, columns, source)
+}
+
+// AcquireLock uses the underlying connection: advisory locks are not
+// bound to the transaction and are visible to the other connections.
+func (c *txConnection) AcquireLock(ctx context.Context, key int64) (db.Lock, error) {
+	return db.AcquireLock(ctx, c.conn, key)
+}
+
+func (c *txConnection) TryAcquireLock(ctx context.Context, key int64) (db.Lock, bool, error) {
+	return db.TryAcquireLock(ctx, c.conn, key)
+}
+
+func (c *txConnection) Stats() db.PoolStats {
+	return c.conn.Stats()
+}
+
+// nestedRows closes the nested transaction of the query once the rows are
+// consumed, rolling it back if reading them failed.
+type nestedRows struct {
+	pgx.Rows
+	ctx context.Context
+	tx  db.Transaction
+}
+
+func (r *nestedRows) Close() {
+	r.Rows.Close()
+	if r.Rows.Err() != nil {
+		// The transaction is not committed yet.
+		// nolint: errcheck
+		r.tx.Rollback()
+	}
+	r.tx.Close(r.ctx)
+}
@@ -0,0 +1,78 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db/postgresql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dbTestConfig = postgresql.NewConfigForLocalhost("test_db", "test_user", "test_password")
+
+func TestUnit_NewTransaction_ExpectRollbackOnCleanup(t *testing.T) {
+	conn := NewFakeConnection()
+
+	t.Run("test", func(t *testing.T) {
+		tx := NewTransaction(t, conn)
+		assert.NotNil(t, tx)
+	})
+
+	require.Len(t, conn.Transactions(), 1)
+	assert.True(t, conn.Transactions()[0].RolledBack())
+}
+
+func TestUnit_NewConnection(t *testing.T) {
+	conn := NewFakeConnection()
+	conn.Expect("INSERT").ReturnError(errSomeError)
+	conn.Expect("SELECT").ReturnRows([]string{"name"}, []any{"a"})
+
+	t.Run("test", func(t *testing.T) {
+		txConn := NewConnection(t, conn)
+
+		_, err := txConn.Exec(t.Context(), "INSERT INTO my_table VALUES ($1)", 1)
+		assert.Equal(t, errSomeError, err, "Actual err: %v", err)
+
+		actual, err := db.QueryOne[string](t.Context(), txConn, "SELECT name FROM my_table")
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, "a", actual)
+
+		txConn.Close(t.Context())
+	})
+
+	require.Len(t, conn.Transactions(), 1)
+	tx := conn.Transactions()[0]
+	assert.True(t, tx.RolledBack())
+	require.Len(t, tx.Nested(), 2)
+	assert.True(t, tx.Nested()[0].RolledBack())
+	assert.True(t, tx.Nested()[1].Committed())
+}
+
+func TestIT_NewConnection_ExpectChangesToBeRolledBack(t *testing.T) {
+	conn, err := db.New(t.Context(), dbTestConfig)
+	require.NoError(t, err, "Actual err: %v", err)
+	defer conn.Close(t.Context())
+
+	id := uuid.New()
+
+	t.Run("test", func(t *testing.T) {
+		txConn := NewConnection(t, conn)
+
+		_, err := txConn.Exec(t.Context(), "INSERT INTO my_table (id, name) VALUES ($1, $2)", id, uuid.NewString())
+		require.NoError(t, err, "Actual err: %v", err)
+
+		// A failed statement does not abort the transaction of the test.
+		_, err = txConn.Exec(t.Context(), "INSERT INTO my_table (id, name) VALUES ($1, $2)", id, uuid.NewString())
+		assert.Error(t, err)
+
+		count, err := db.QueryOne[int](t.Context(), txConn, "SELECT COUNT(*) FROM my_table WHERE id = $1", id)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, 1, count)
+	})
+
+	count, err := db.QueryOne[int](t.Context(), conn, "SELECT COUNT(*) FROM my_table WHERE id = $1", id)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, 0, count)
+}
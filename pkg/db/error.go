@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	berrors "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
 )
@@ -28,6 +29,46 @@ func AsDatabaseError(err error) (*DatabaseError, bool) {
 	return nil, false
 }
 
+// ConstraintViolation describes the constraint which caused an error.
+type ConstraintViolation struct {
+	Constraint string
+	Table      string
+	// Columns are the columns covered by the constraint. They might be
+	// empty, e.g. for check constraints.
+	Columns []string
+}
+
+// AsConstraintViolation returns the details of the violated constraint when
+// the error is caused by a unique, foreign key, not null, check or exclusion
+// constraint. This allows to produce meaningful messages such as "email
+// already in use".
+func AsConstraintViolation(err error) (ConstraintViolation, bool) {
+	dbErr, ok := AsDatabaseError(err)
+	if !ok {
+		return ConstraintViolation{}, false
+	}
+
+	switch dbErr.Code {
+	case ErrUniqueConstraintViolation,
+		ErrForeignKeyValidation,
+		ErrNotNullViolation,
+		ErrCheckViolation,
+		ErrExclusionViolation:
+	default:
+		return ConstraintViolation{}, false
+	}
+
+	out := ConstraintViolation{
+		Constraint: dbErr.Constraint,
+		Table:      dbErr.Table,
+	}
+	if dbErr.Column != "" {
+		out.Columns = strings.Split(dbErr.Column, ",")
+	}
+
+	return out, true
+}
+
 func (e *DatabaseError) Error() string {
 	out := fmt.Sprintf("%s, code: %d, sql code: %s", e.Message, e.Code, e.SqlCode)
 
@@ -38,6 +79,15 @@ func (e *DatabaseError) Error() string {
 	return out
 }
 
+func (e *DatabaseError) ErrorCode() berrors.ErrorCode {
+	return e.Code
+}
+
+// Unwrap gives access to the error of the driver, e.g. *pgconn.PgError.
+func (e *DatabaseError) Unwrap() error {
+	return e.Cause
+}
+
 func (e *DatabaseError) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Code       berrors.ErrorCode `json:"code"`
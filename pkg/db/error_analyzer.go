@@ -1,7 +1,10 @@
 package db
 
 import (
+	stderrors "errors"
+	"regexp"
 	"strings"
+	"syscall"
 
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -9,9 +12,18 @@ import (
 
 // https://www.postgresql.org/docs/current/errcodes-appendix.html
 const (
+	notNullViolation             = "23502"
 	foreignKeyViolation          = "23503"
 	uniqueValidation             = "23505"
+	checkViolation               = "23514"
+	exclusionViolation           = "23P01"
 	passwordAuthenticationFailed = "28P01"
+	serializationFailure         = "40001"
+	deadlockDetected             = "40P01"
+	queryCanceled                = "57014"
+
+	unableToEstablishConnection = "08001"
+	connectionRejected          = "08004"
 )
 
 func analyzeAndWrapDatabaseError(err error) error {
@@ -35,34 +47,73 @@ func analyzeAndWrapDatabaseError(err error) error {
 }
 
 func analyzePgError(err *pgconn.PgError) error {
+	column := err.ColumnName
+	if column == "" {
+		column = columnFromDetail(err.Detail)
+	}
+
 	return &DatabaseError{
 		Code:       mapPostgreCodeToErrorCode(err.Code),
 		Message:    err.Message,
 		SqlCode:    err.Code,
 		Schema:     err.SchemaName,
 		Table:      err.TableName,
-		Column:     err.ColumnName,
+		Column:     column,
 		Constraint: err.ConstraintName,
 		Cause:      err,
 	}
 }
 
+// Postgres does not set the column for unique and foreign key violations
+// but mentions it in the detail, e.g.:
+// Key (email)=(john@example.com) already exists.
+var keyDetailRegex = regexp.MustCompile(`^Key \((.+?)\)=`)
+
+// columnFromDetail extracts the column(s) of the key from the detail of the
+// error. Multiple columns are separated by a comma.
+func columnFromDetail(detail string) string {
+	match := keyDetailRegex.FindStringSubmatch(detail)
+	if match == nil {
+		return ""
+	}
+	return strings.ReplaceAll(match[1], ", ", ",")
+}
+
 func analyzeConnError(err *pgconn.ConnectError) error {
 	msg := err.Unwrap().Error()
 
 	if strings.Contains(msg, passwordAuthenticationFailed) {
 		return ErrAuthenticationFailed
 	}
+	if stderrors.Is(err, syscall.ECONNREFUSED) {
+		return errors.FromCode(ErrConnectionRefused)
+	}
 
 	return errors.FromCode(ErrGenericSqlError)
 }
 
 func mapPostgreCodeToErrorCode(postgreCode string) errors.ErrorCode {
 	switch postgreCode {
+	case notNullViolation:
+		return ErrNotNullViolation
 	case foreignKeyViolation:
 		return ErrForeignKeyValidation
 	case uniqueValidation:
 		return ErrUniqueConstraintViolation
+	case checkViolation:
+		return ErrCheckViolation
+	case exclusionViolation:
+		return ErrExclusionViolation
+	case serializationFailure:
+		return ErrSerializationFailure
+	case deadlockDetected:
+		return ErrDeadlockDetected
+	// Also raised when a query is cancelled, which in practice mostly
+	// happens because of the statement timeout.
+	case queryCanceled:
+		return ErrStatementTimeout
+	case unableToEstablishConnection, connectionRejected:
+		return ErrConnectionRefused
 	}
 
 	return ErrGenericSqlError
@@ -200,3 +200,56 @@ func TestUnit_Error_As(t *testing.T) {
 		assert.Equal(t, testErr, err)
 	})
 }
+
+func TestUnit_Error_Unwrap(t *testing.T) {
+	t.Run("gives access to the driver error", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", &DatabaseError{
+			Code:  ErrGenericSqlError,
+			Cause: errSomeError,
+		})
+
+		assert.True(t, errors.Is(err, errSomeError))
+	})
+
+	t.Run("detects code of the database error", func(t *testing.T) {
+		err := berrors.Wrap(&DatabaseError{Code: ErrUniqueConstraintViolation}, "wrapper")
+
+		assert.True(t, berrors.IsErrorWithCode(err, ErrUniqueConstraintViolation))
+		assert.False(t, berrors.IsErrorWithCode(err, ErrForeignKeyValidation))
+	})
+}
+
+func TestUnit_Error_AsConstraintViolation(t *testing.T) {
+	t.Run("detects constraint violation", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", &DatabaseError{
+			Code:       ErrUniqueConstraintViolation,
+			Table:      "users",
+			Column:     "tenant_id,email",
+			Constraint: "users_tenant_id_email_key",
+		})
+
+		actual, ok := AsConstraintViolation(err)
+
+		require.True(t, ok)
+		expected := ConstraintViolation{
+			Constraint: "users_tenant_id_email_key",
+			Table:      "users",
+			Columns:    []string{"tenant_id", "email"},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("does not detect other database errors", func(t *testing.T) {
+		err := &DatabaseError{Code: ErrGenericSqlError}
+
+		_, ok := AsConstraintViolation(err)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("does not detect random error", func(t *testing.T) {
+		_, ok := AsConstraintViolation(errSomeError)
+
+		assert.False(t, ok)
+	})
+}
@@ -7,6 +7,11 @@ const (
 	errUnsupportedOperation errors.ErrorCode = 101
 	errAlreadyCommitted     errors.ErrorCode = 102
 	errForcedRollback       errors.ErrorCode = 103
+	errUnknownCopyColumn    errors.ErrorCode = 104
+	errMissingNamedParam    errors.ErrorCode = 105
+	errInvalidNamedParams   errors.ErrorCode = 106
+	errInvalidRepository    errors.ErrorCode = 107
+	errInvalidUpsert        errors.ErrorCode = 108
 
 	errNoMatchingRows      errors.ErrorCode = 110
 	errTooManyMatchingRows errors.ErrorCode = 111
@@ -15,6 +20,13 @@ const (
 	ErrForeignKeyValidation      errors.ErrorCode = 151
 	ErrUniqueConstraintViolation errors.ErrorCode = 152
 	errAuthenticationFailed      errors.ErrorCode = 153
+	ErrNotNullViolation          errors.ErrorCode = 154
+	ErrCheckViolation            errors.ErrorCode = 155
+	ErrExclusionViolation        errors.ErrorCode = 156
+	ErrSerializationFailure      errors.ErrorCode = 157
+	ErrDeadlockDetected          errors.ErrorCode = 158
+	ErrStatementTimeout          errors.ErrorCode = 159
+	ErrConnectionRefused         errors.ErrorCode = 160
 )
 
 var ()
@@ -23,9 +35,43 @@ var (
 	ErrNotConnected         = errors.FromCode(errNotConnected)
 	ErrUnsupportedOperation = errors.FromCode(errUnsupportedOperation)
 	ErrAlreadyCommitted     = errors.FromCode(errAlreadyCommitted)
+	ErrUnknownCopyColumn    = errors.FromCode(errUnknownCopyColumn)
+	ErrMissingNamedParam    = errors.FromCode(errMissingNamedParam)
+	ErrInvalidNamedParams   = errors.FromCode(errInvalidNamedParams)
+	ErrInvalidRepository    = errors.FromCode(errInvalidRepository)
+	ErrInvalidUpsert        = errors.FromCode(errInvalidUpsert)
 
 	ErrNoMatchingRows      = errors.FromCode(errNoMatchingRows)
 	ErrTooManyMatchingRows = errors.FromCode(errTooManyMatchingRows)
 
 	ErrAuthenticationFailed = errors.FromCode(errAuthenticationFailed)
 )
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errNotConnected, Name: "db.not_connected", GrpcCode: errors.GrpcUnavailable},
+		{Code: errUnsupportedOperation, Name: "db.unsupported_operation"},
+		{Code: errAlreadyCommitted, Name: "db.already_committed"},
+		{Code: errForcedRollback, Name: "db.forced_rollback"},
+		{Code: errUnknownCopyColumn, Name: "db.unknown_copy_column"},
+		{Code: errMissingNamedParam, Name: "db.missing_named_param"},
+		{Code: errInvalidNamedParams, Name: "db.invalid_named_params"},
+		{Code: errInvalidRepository, Name: "db.invalid_repository"},
+		{Code: errInvalidUpsert, Name: "db.invalid_upsert"},
+		{Code: errNoMatchingRows, Name: "db.no_matching_rows", GrpcCode: errors.GrpcNotFound},
+		{Code: errTooManyMatchingRows, Name: "db.too_many_matching_rows"},
+		{Code: ErrGenericSqlError, Name: "db.generic_sql_error"},
+		{Code: ErrForeignKeyValidation, Name: "db.foreign_key_validation", GrpcCode: errors.GrpcFailedPrecondition},
+		{Code: ErrUniqueConstraintViolation, Name: "db.unique_constraint_violation", GrpcCode: errors.GrpcAlreadyExists},
+		{Code: errAuthenticationFailed, Name: "db.authentication_failed", GrpcCode: errors.GrpcUnavailable},
+		{Code: ErrNotNullViolation, Name: "db.not_null_violation", GrpcCode: errors.GrpcInvalidArgument},
+		{Code: ErrCheckViolation, Name: "db.check_violation", GrpcCode: errors.GrpcInvalidArgument},
+		{Code: ErrExclusionViolation, Name: "db.exclusion_violation", GrpcCode: errors.GrpcAlreadyExists},
+		{Code: ErrSerializationFailure, Name: "db.serialization_failure", GrpcCode: errors.GrpcAborted},
+		{Code: ErrDeadlockDetected, Name: "db.deadlock_detected", GrpcCode: errors.GrpcAborted},
+		{Code: ErrStatementTimeout, Name: "db.statement_timeout", GrpcCode: errors.GrpcDeadlineExceeded},
+		{Code: ErrConnectionRefused, Name: "db.connection_refused", GrpcCode: errors.GrpcUnavailable},
+	} {
+		errors.Register(info)
+	}
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Health is the result of a health check of a connection.
+type Health struct {
+	// Latency is the duration of the round trip to the database.
+	Latency time.Duration `json:"latency"`
+	Pool    PoolStats     `json:"pool"`
+}
+
+// Healthcheck returns a probe verifying that the database is reachable.
+// Unlike a query, it does not hide the state of the pool: the statistics
+// are reported even when the database can't be reached, which helps to
+// distinguish an exhausted pool from an unavailable database.
+func Healthcheck(conn Connection) func(ctx context.Context) (Health, error) {
+	return func(ctx context.Context) (Health, error) {
+		start := time.Now()
+		err := conn.Ping(ctx)
+
+		health := Health{
+			Latency: time.Since(start),
+			Pool:    conn.Stats(),
+		}
+
+		return health, analyzeAndWrapDatabaseError(err)
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Healthcheck_WhenNotConnected_ExpectError(t *testing.T) {
+	probe := Healthcheck(&connectionImpl{})
+
+	health, err := probe(t.Context())
+
+	assert.Equal(t, ErrNotConnected, err, "Actual err: %v", err)
+	assert.Equal(t, PoolStats{}, health.Pool)
+}
+
+func TestIT_Healthcheck(t *testing.T) {
+	conn := newTestConnection(t)
+	probe := Healthcheck(conn)
+
+	health, err := probe(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Greater(t, health.Latency, time.Duration(0))
+	assert.Greater(t, health.Pool.TotalConns, int32(0))
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryEvent describes a query executed on the database.
+type QueryEvent struct {
+	// Sql is the redacted text of the query: the string literals are
+	// replaced by a placeholder. The arguments are never included.
+	Sql      string
+	Duration time.Duration
+	// Rows is the number of rows returned or affected by the query.
+	Rows int64
+	Err  error
+}
+
+// QueryObserver receives the queries executed on the database. It allows
+// to feed metrics collectors (e.g. Prometheus histograms) without the db
+// package depending on them. It is called synchronously and should be fast.
+type QueryObserver interface {
+	ObserveQuery(ctx context.Context, event QueryEvent)
+}
+
+// QueryObserverFunc allows to use a function as a QueryObserver.
+type QueryObserverFunc func(ctx context.Context, event QueryEvent)
+
+func (f QueryObserverFunc) ObserveQuery(ctx context.Context, event QueryEvent) {
+	f(ctx, event)
+}
+
+type InstrumentationConfig struct {
+	// Logger receives a debug log for each query and a warning for the
+	// slow ones. Defaults to slog.Default().
+	Logger *slog.Logger
+	// SlowQueryThreshold is the duration above which a query is logged as
+	// slow. A zero value disables the slow query logs.
+	SlowQueryThreshold time.Duration
+	Observers          []QueryObserver
+}
+
+// WithInstrumentation records the duration, row count and error of each
+// query executed through the connection.
+func WithInstrumentation(config InstrumentationConfig) Option {
+	return func(o *options) {
+		o.tracer = newQueryTracer(config)
+	}
+}
+
+type queryTracer struct {
+	log                *slog.Logger
+	slowQueryThreshold time.Duration
+	observers          []QueryObserver
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql  string
+	time time.Time
+}
+
+func newQueryTracer(config InstrumentationConfig) *queryTracer {
+	log := config.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+
+	return &queryTracer{
+		log:                log,
+		slowQueryThreshold: config.SlowQueryThreshold,
+		observers:          config.Observers,
+	}
+}
+
+func (qt *queryTracer) TraceQueryStart(
+	ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData,
+) context.Context {
+	start := queryStart{
+		sql:  data.SQL,
+		time: time.Now(),
+	}
+	return context.WithValue(ctx, queryStartKey{}, start)
+}
+
+func (qt *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	event := QueryEvent{
+		Sql:      redactSql(start.sql),
+		Duration: time.Since(start.time),
+		Rows:     data.CommandTag.RowsAffected(),
+		Err:      data.Err,
+	}
+
+	for _, observer := range qt.observers {
+		observer.ObserveQuery(ctx, event)
+	}
+
+	attrs := []any{
+		slog.Duration("duration", event.Duration),
+		slog.Int64("rows", event.Rows),
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.Any("error", event.Err))
+	}
+
+	if qt.slowQueryThreshold > 0 && event.Duration > qt.slowQueryThreshold {
+		attrs = append(attrs, slog.String("sql", event.Sql))
+		qt.log.WarnContext(ctx, "Slow query", attrs...)
+		return
+	}
+
+	qt.log.DebugContext(ctx, "Executed query", attrs...)
+}
+
+var stringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// redactSql removes the string literals from the query as they might hold
+// sensitive values. Queries using arguments are not affected.
+func redactSql(sql string) string {
+	return stringLiteralRegex.ReplaceAllString(sql, "'?'")
+}
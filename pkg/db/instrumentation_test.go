@@ -0,0 +1,112 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_RedactSql(t *testing.T) {
+	type testCase struct {
+		sql      string
+		expected string
+	}
+
+	testCases := map[string]testCase{
+		"no literal": {
+			sql:      "SELECT * FROM my_table WHERE id = $1",
+			expected: "SELECT * FROM my_table WHERE id = $1",
+		},
+		"string literal": {
+			sql:      "SELECT * FROM my_table WHERE name = 'secret'",
+			expected: "SELECT * FROM my_table WHERE name = '?'",
+		},
+		"escaped quote": {
+			sql:      "SELECT 'it''s' || 'other'",
+			expected: "SELECT '?' || '?'",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, redactSql(tc.sql))
+		})
+	}
+}
+
+func TestUnit_QueryTracer_NotifiesObservers(t *testing.T) {
+	var events []QueryEvent
+	observer := QueryObserverFunc(func(ctx context.Context, event QueryEvent) {
+		events = append(events, event)
+	})
+	tracer := newQueryTracer(InstrumentationConfig{Observers: []QueryObserver{observer}})
+
+	traceQuery(t, tracer, "DELETE FROM my_table WHERE name = 'a'", pgconn.NewCommandTag("DELETE 3"), errSomeError)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "DELETE FROM my_table WHERE name = '?'", events[0].Sql)
+	assert.Equal(t, int64(3), events[0].Rows)
+	assert.Equal(t, errSomeError, events[0].Err)
+}
+
+func TestUnit_QueryTracer_LogsSlowQueries(t *testing.T) {
+	var out bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	tracer := newQueryTracer(InstrumentationConfig{
+		Logger:             log,
+		SlowQueryThreshold: time.Nanosecond,
+	})
+
+	traceQuery(t, tracer, "SELECT 'secret'", pgconn.NewCommandTag("SELECT 1"), nil)
+
+	assert.Contains(t, out.String(), `msg="Slow query"`)
+	assert.Contains(t, out.String(), `sql="SELECT '?'"`)
+	assert.NotContains(t, out.String(), "secret")
+}
+
+func TestUnit_QueryTracer_WhenQueryIsFast_ExpectNoWarning(t *testing.T) {
+	var out bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	tracer := newQueryTracer(InstrumentationConfig{
+		Logger:             log,
+		SlowQueryThreshold: time.Hour,
+	})
+
+	traceQuery(t, tracer, "SELECT 1", pgconn.NewCommandTag("SELECT 1"), nil)
+
+	assert.Empty(t, out.String())
+}
+
+func TestIT_WithInstrumentation(t *testing.T) {
+	var events []QueryEvent
+	observer := QueryObserverFunc(func(ctx context.Context, event QueryEvent) {
+		events = append(events, event)
+	})
+
+	conn, err := New(t.Context(), dbTestConfig, WithInstrumentation(InstrumentationConfig{
+		Observers: []QueryObserver{observer},
+	}))
+	require.NoError(t, err, "Actual err: %v", err)
+	defer conn.Close(t.Context())
+
+	_, err = QueryAll[int](t.Context(), conn, "SELECT generate_series(1, 3)")
+	require.NoError(t, err, "Actual err: %v", err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), events[0].Rows)
+	assert.Nil(t, events[0].Err)
+}
+
+func traceQuery(t *testing.T, tracer pgx.QueryTracer, sql string, tag pgconn.CommandTag, err error) {
+	t.Helper()
+
+	ctx := tracer.TraceQueryStart(t.Context(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: tag, Err: err})
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSON wraps a value stored in a json or jsonb column. The value is
+// serialized when written and deserialized when read, whatever the type
+// of the column. A SQL NULL is read as the zero value of T and a nil
+// value is written as NULL.
+//
+// Struct fields with the `jsonb` option in their `db` tag are serialized
+// the same way by the insertion helpers (CopyFrom, Upsert, Repository).
+type JSON[T any] struct {
+	V T
+}
+
+func (j *JSON[T]) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		var zero T
+		j.V = zero
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", src)
+	}
+
+	return json.Unmarshal(data, &j.V)
+}
+
+func (j JSON[T]) Value() (driver.Value, error) {
+	if isNil(j.V) {
+		return nil, nil
+	}
+	return json.Marshal(j.V)
+}
+
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.V)
+}
+
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &j.V)
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func isJsonbField(field reflect.StructField) bool {
+	_, options, _ := strings.Cut(field.Tag.Get("db"), ",")
+	return slices.Contains(strings.Split(options, ","), "jsonb")
+}
+
+// columnValue returns the value to send to the database for the field.
+func columnValue(value reflect.Value, field reflect.StructField) any {
+	v := value.FieldByIndex(field.Index).Interface()
+	if isJsonbField(field) {
+		return JSON[any]{V: v}
+	}
+	return v
+}
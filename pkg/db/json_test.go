@@ -0,0 +1,131 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonPayload struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestUnit_JSON_Value(t *testing.T) {
+	j := JSON[jsonPayload]{V: jsonPayload{Name: "a", Count: 2}}
+
+	actual, err := j.Value()
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []byte(`{"name":"a","count":2}`), actual)
+}
+
+func TestUnit_JSON_Value_WhenNil_ExpectNull(t *testing.T) {
+	j := JSON[*jsonPayload]{}
+
+	actual, err := j.Value()
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Nil(t, actual)
+}
+
+func TestUnit_JSON_Scan(t *testing.T) {
+	type testCase struct {
+		src      any
+		expected jsonPayload
+	}
+
+	testCases := map[string]testCase{
+		"bytes": {
+			src:      []byte(`{"name":"a","count":2}`),
+			expected: jsonPayload{Name: "a", Count: 2},
+		},
+		"string": {
+			src:      `{"name":"b"}`,
+			expected: jsonPayload{Name: "b"},
+		},
+		"null": {
+			src:      nil,
+			expected: jsonPayload{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			j := JSON[jsonPayload]{V: jsonPayload{Name: "previous"}}
+
+			err := j.Scan(tc.src)
+
+			require.NoError(t, err, "Actual err: %v", err)
+			assert.Equal(t, tc.expected, j.V)
+		})
+	}
+}
+
+func TestUnit_JSON_Scan_WhenSourceIsInvalid_ExpectError(t *testing.T) {
+	var j JSON[jsonPayload]
+
+	assert.Error(t, j.Scan(2))
+	assert.Error(t, j.Scan([]byte("not-json")))
+}
+
+func TestUnit_JSON_RoundTripThroughJsonbCodec(t *testing.T) {
+	m := pgtype.NewMap()
+	in := JSON[jsonPayload]{V: jsonPayload{Name: "a", Count: 2}}
+
+	encoded, err := m.Encode(pgtype.JSONBOID, pgx.BinaryFormatCode, in, nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	var out JSON[jsonPayload]
+	err = m.Scan(pgtype.JSONBOID, pgx.BinaryFormatCode, encoded, &out)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, in, out)
+}
+
+func TestUnit_ColumnValue_WhenFieldIsJsonb_ExpectWrapped(t *testing.T) {
+	type row struct {
+		Raw     []string
+		Payload []string `db:"payload,jsonb"`
+	}
+
+	r := row{Raw: []string{"a"}, Payload: []string{"b"}}
+	mapping := newColumnMapping(reflect.TypeFor[row]())
+
+	actual := mapping.values(r, mapping.columns)
+
+	assert.Equal(t, []any{[]string{"a"}, JSON[any]{V: []string{"b"}}}, actual)
+}
+
+type jsonElement struct {
+	Id      uuid.UUID   `db:"id"`
+	Payload jsonPayload `db:"payload,jsonb"`
+	Tags    JSON[[]string]
+}
+
+func TestIT_Json(t *testing.T) {
+	_, tx := newTestTransaction(t)
+	defer tx.Close(t.Context())
+
+	sql := "CREATE TEMPORARY TABLE json_table (id uuid PRIMARY KEY, payload jsonb, tags jsonb) ON COMMIT DROP"
+	_, err := tx.Exec(t.Context(), sql)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	v := jsonElement{
+		Id:      uuid.New(),
+		Payload: jsonPayload{Name: "a", Count: 2},
+		Tags:    JSON[[]string]{V: []string{"b", "c"}},
+	}
+
+	_, err = Upsert(t.Context(), tx, "json_table", v, "id")
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual, err := QueryOneTx[jsonElement](t.Context(), tx, "SELECT id, payload, tags FROM json_table WHERE id = $1", v.Id)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, v, actual)
+}
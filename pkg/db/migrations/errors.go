@@ -0,0 +1,34 @@
+package migrations
+
+import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+
+const (
+	errInvalidMigrationFile   errors.ErrorCode = 600
+	errDuplicatedMigration    errors.ErrorCode = 601
+	errMissingUpMigration     errors.ErrorCode = 602
+	errMissingDownMigration   errors.ErrorCode = 603
+	errDirtyDatabase          errors.ErrorCode = 604
+	errUnknownDatabaseVersion errors.ErrorCode = 605
+)
+
+var (
+	ErrInvalidMigrationFile   = errors.FromCode(errInvalidMigrationFile)
+	ErrDuplicatedMigration    = errors.FromCode(errDuplicatedMigration)
+	ErrMissingUpMigration     = errors.FromCode(errMissingUpMigration)
+	ErrMissingDownMigration   = errors.FromCode(errMissingDownMigration)
+	ErrDirtyDatabase          = errors.FromCode(errDirtyDatabase)
+	ErrUnknownDatabaseVersion = errors.FromCode(errUnknownDatabaseVersion)
+)
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errInvalidMigrationFile, Name: "migrations.invalid_migration_file"},
+		{Code: errDuplicatedMigration, Name: "migrations.duplicated_migration"},
+		{Code: errMissingUpMigration, Name: "migrations.missing_up_migration"},
+		{Code: errMissingDownMigration, Name: "migrations.missing_down_migration"},
+		{Code: errDirtyDatabase, Name: "migrations.dirty_database"},
+		{Code: errUnknownDatabaseVersion, Name: "migrations.unknown_database_version"},
+	} {
+		errors.Register(info)
+	}
+}
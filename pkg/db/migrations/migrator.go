@@ -0,0 +1,227 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/jackc/pgx/v5"
+)
+
+const defaultTable = "schema_migrations"
+
+type Config struct {
+	// Table stores the current version of the schema. It defaults to
+	// schema_migrations which is compatible with golang-migrate.
+	Table string
+	// LockId is the key of the advisory lock preventing several migrators
+	// to run concurrently. It defaults to a hash of the table name.
+	LockId int64
+}
+
+type Migrator struct {
+	conn       db.Connection
+	migrations []Migration
+	table      string
+	lockId     int64
+	log        *slog.Logger
+}
+
+type schemaVersion struct {
+	Version int64
+	Dirty   bool
+}
+
+func New(conn db.Connection, fsys fs.FS, config Config, log *slog.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+	if config.LockId == 0 {
+		hash := fnv.New64a()
+		hash.Write([]byte(config.Table))
+		config.LockId = int64(hash.Sum64())
+	}
+
+	m := &Migrator{
+		conn:       conn,
+		migrations: migrations,
+		table:      pgx.Identifier{config.Table}.Sanitize(),
+		lockId:     config.LockId,
+		log:        log,
+	}
+
+	return m, nil
+}
+
+// Version returns the version of the schema. A dirty version means that a
+// migration failed: the schema needs to be fixed manually before running
+// the migrations again. A version of 0 means no migration was applied.
+func (m *Migrator) Version(ctx context.Context) (int64, bool, error) {
+	if err := m.createVersionTable(ctx); err != nil {
+		return 0, false, err
+	}
+
+	return m.version(ctx)
+}
+
+// Up applies all the migrations which are not yet applied.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		current, err := m.checkedVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= current {
+				continue
+			}
+
+			m.log.Info("Applying migration", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			if err := m.apply(ctx, migration.Up, migration.Version); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down reverts the provided number of migrations. A value of 0 or less
+// reverts all of them.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func() error {
+		current, err := m.checkedVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && current > 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version > current {
+				continue
+			}
+			if migration.Down == "" {
+				return ErrMissingDownMigration
+			}
+
+			var previous int64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			m.log.Info("Reverting migration", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			if err := m.apply(ctx, migration.Down, previous); err != nil {
+				return err
+			}
+			current = previous
+
+			steps--
+			if steps == 0 {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+// withLock runs the function while holding an advisory lock. The lock is
+// bound to a transaction which is kept open in the meantime: the pool can
+// use any connection to run the migrations.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	return db.WithTransaction(ctx, m.conn, func(tx db.Transaction) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", m.lockId); err != nil {
+			return err
+		}
+
+		if err := m.createVersionTable(ctx); err != nil {
+			return err
+		}
+
+		return fn()
+	})
+}
+
+func (m *Migrator) createVersionTable(ctx context.Context) error {
+	sql := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)",
+		m.table,
+	)
+	_, err := m.conn.Exec(ctx, sql)
+	return err
+}
+
+func (m *Migrator) version(ctx context.Context) (int64, bool, error) {
+	sql := fmt.Sprintf("SELECT version, dirty FROM %s LIMIT 1", m.table)
+	versions, err := db.QueryAll[schemaVersion](ctx, m.conn, sql)
+	if err != nil || len(versions) == 0 {
+		return 0, false, err
+	}
+
+	return versions[0].Version, versions[0].Dirty, nil
+}
+
+// checkedVersion returns the current version when it is safe to migrate
+// from it: it should not be dirty and should be known.
+func (m *Migrator) checkedVersion(ctx context.Context) (int64, error) {
+	current, dirty, err := m.version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, ErrDirtyDatabase
+	}
+
+	if current == 0 {
+		return 0, nil
+	}
+	for _, migration := range m.migrations {
+		if migration.Version == current {
+			return current, nil
+		}
+	}
+
+	return 0, ErrUnknownDatabaseVersion
+}
+
+// apply runs the migration after marking the target version as dirty: it
+// is only marked as clean once the migration succeeded. This allows to
+// detect migrations which failed midway.
+func (m *Migrator) apply(ctx context.Context, sql string, version int64) error {
+	if err := m.setVersion(ctx, version, true); err != nil {
+		return err
+	}
+
+	if _, err := m.conn.Exec(ctx, sql); err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, version, false)
+}
+
+func (m *Migrator) setVersion(ctx context.Context, version int64, dirty bool) error {
+	return db.WithTransaction(ctx, m.conn, func(tx db.Transaction) error {
+		if _, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s", m.table)); err != nil {
+			return err
+		}
+
+		// When reverting the first migration a dirty state still needs to
+		// be recorded in case the migration fails.
+		if version == 0 && !dirty {
+			return nil
+		}
+
+		sql := fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES ($1, $2)", m.table)
+		_, err := tx.Exec(ctx, sql, version, dirty)
+		return err
+	})
+}
@@ -0,0 +1,159 @@
+package migrations
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db/postgresql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dbTestConfig = postgresql.NewConfigForLocalhost("test_db", "test_user", "test_password")
+
+func TestIT_Migrator_Up(t *testing.T) {
+	t.Run("applies all migrations", func(t *testing.T) {
+		conn, m, table := newTestMigrator(t, sampleMigrations(t))
+
+		err := m.Up(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertVersion(t, m, 2, false)
+		assertColumnCount(t, conn, table, 2)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		_, m, _ := newTestMigrator(t, sampleMigrations(t))
+
+		err := m.Up(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+		err = m.Up(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertVersion(t, m, 2, false)
+	})
+
+	t.Run("marks version as dirty when migration fails", func(t *testing.T) {
+		migrations := sampleMigrations(t)
+		migrations["3_invalid.up.sql"] = &fstest.MapFile{Data: []byte("NOT VALID SQL;")}
+		_, m, _ := newTestMigrator(t, migrations)
+
+		err := m.Up(t.Context())
+		assert.Error(t, err)
+
+		assertVersion(t, m, 3, true)
+
+		err = m.Up(t.Context())
+		assert.Equal(t, ErrDirtyDatabase, err, "Actual err: %v", err)
+	})
+}
+
+func TestIT_Migrator_Down(t *testing.T) {
+	t.Run("reverts the requested number of migrations", func(t *testing.T) {
+		conn, m, table := newTestMigrator(t, sampleMigrations(t))
+		err := m.Up(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+
+		err = m.Down(t.Context(), 1)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertVersion(t, m, 1, false)
+		assertColumnCount(t, conn, table, 1)
+	})
+
+	t.Run("reverts all migrations", func(t *testing.T) {
+		conn, m, table := newTestMigrator(t, sampleMigrations(t))
+		err := m.Up(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+
+		err = m.Down(t.Context(), 0)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertVersion(t, m, 0, false)
+		assertColumnCount(t, conn, table, 0)
+	})
+}
+
+func TestIT_Runnable_AppliesMigrations(t *testing.T) {
+	_, m, _ := newTestMigrator(t, sampleMigrations(t))
+
+	err := NewRunnable(m).Start()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assertVersion(t, m, 2, false)
+}
+
+func newTestMigrator(t *testing.T, migrations fstest.MapFS) (db.Connection, *Migrator, string) {
+	t.Helper()
+
+	conn, err := db.New(t.Context(), dbTestConfig)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	suffix := uuid.New().ID()
+	table := templateTable(suffix)
+	versionTable := templateVersionTable(suffix)
+	for name, file := range migrations {
+		migrations[name] = &fstest.MapFile{Data: []byte(replaceTable(string(file.Data), table))}
+	}
+
+	m, err := New(conn, migrations, Config{Table: versionTable}, slog.Default())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	t.Cleanup(func() {
+		_, _ = conn.Exec(t.Context(), "DROP TABLE IF EXISTS "+table)
+		_, _ = conn.Exec(t.Context(), "DROP TABLE IF EXISTS "+versionTable)
+		conn.Close(t.Context())
+	})
+
+	return conn, m, table
+}
+
+func sampleMigrations(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	return fstest.MapFS{
+		"1_create_table.up.sql":   {Data: []byte("CREATE TABLE $table (id UUID NOT NULL);")},
+		"1_create_table.down.sql": {Data: []byte("DROP TABLE $table;")},
+		"2_add_column.up.sql":     {Data: []byte("ALTER TABLE $table ADD COLUMN name TEXT;")},
+		"2_add_column.down.sql":   {Data: []byte("ALTER TABLE $table DROP COLUMN name;")},
+	}
+}
+
+func assertVersion(t *testing.T, m *Migrator, expectedVersion int64, expectedDirty bool) {
+	t.Helper()
+
+	version, dirty, err := m.Version(t.Context())
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, expectedVersion, version)
+	assert.Equal(t, expectedDirty, dirty)
+}
+
+func assertColumnCount(t *testing.T, conn db.Connection, table string, expected int) {
+	t.Helper()
+
+	count, err := db.QueryOne[int](
+		t.Context(),
+		conn,
+		"SELECT COUNT(*) FROM information_schema.columns WHERE table_name = $1",
+		table,
+	)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, expected, count)
+}
+
+func templateTable(suffix uint32) string {
+	return fmt.Sprintf("migrations_test_%d", suffix)
+}
+
+func templateVersionTable(suffix uint32) string {
+	return fmt.Sprintf("migrations_test_versions_%d", suffix)
+}
+
+func replaceTable(sql string, table string) string {
+	return strings.ReplaceAll(sql, "$table", table)
+}
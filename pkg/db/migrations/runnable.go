@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+)
+
+type runnableImpl struct {
+	migrator *Migrator
+	lock     sync.Mutex
+	cancel   context.CancelFunc
+}
+
+// NewRunnable applies the pending migrations when started. Start returns
+// once all the migrations are applied. Stopping it cancels the migration
+// in progress if any.
+func NewRunnable(migrator *Migrator) process.Runnable {
+	return &runnableImpl{
+		migrator: migrator,
+	}
+}
+
+func (r *runnableImpl) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.lock.Lock()
+	r.cancel = cancel
+	r.lock.Unlock()
+
+	return r.migrator.Up(ctx)
+}
+
+func (r *runnableImpl) Stop() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cancel != nil {
+		r.cancel()
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The naming follows the one of golang-migrate so that existing migration
+// folders can be used as is: 1_create_table.up.sql, 1_create_table.down.sql.
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(.*)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads the migrations stored at the root of the file system, which is
+// typically an embed.FS. The migrations are sorted by increasing version.
+// Files not ending with .sql are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !isSqlFile(entry.Name()) {
+			continue
+		}
+
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, ErrInvalidMigrationFile
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, ErrInvalidMigrationFile
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, ErrDuplicatedMigration
+		}
+
+		target := &migration.Up
+		if matches[3] == "down" {
+			target = &migration.Down
+		}
+		if *target != "" {
+			return nil, ErrDuplicatedMigration
+		}
+		*target = string(content)
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, ErrMissingUpMigration
+		}
+		out = append(out, *migration)
+	}
+
+	slices.SortFunc(out, func(lhs Migration, rhs Migration) int {
+		return int(lhs.Version - rhs.Version)
+	})
+
+	return out, nil
+}
+
+func isSqlFile(name string) bool {
+	return strings.HasSuffix(name, ".sql")
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Load(t *testing.T) {
+	fsys := fstest.MapFS{
+		"2_add_column.up.sql":      {Data: []byte("ALTER TABLE t ADD COLUMN c TEXT;")},
+		"1_create_table.up.sql":    {Data: []byte("CREATE TABLE t (id INT);")},
+		"1_create_table.down.sql":  {Data: []byte("DROP TABLE t;")},
+		"10_create_index.up.sql":   {Data: []byte("CREATE INDEX ON t (id);")},
+		"README.md":                {Data: []byte("Not a migration")},
+		"nested/3_ignored.up.sql":  {Data: []byte("SELECT 1;")},
+		"10_create_index.down.sql": {Data: []byte("DROP INDEX t_id_idx;")},
+	}
+
+	actual, err := Load(fsys)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	expected := []Migration{
+		{Version: 1, Name: "create_table", Up: "CREATE TABLE t (id INT);", Down: "DROP TABLE t;"},
+		{Version: 2, Name: "add_column", Up: "ALTER TABLE t ADD COLUMN c TEXT;"},
+		{Version: 10, Name: "create_index", Up: "CREATE INDEX ON t (id);", Down: "DROP INDEX t_id_idx;"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_Load_WhenFilesAreInvalid_ExpectError(t *testing.T) {
+	type testCase struct {
+		fsys     fstest.MapFS
+		expected error
+	}
+
+	testCases := map[string]testCase{
+		"invalidName": {
+			fsys:     fstest.MapFS{"create_table.up.sql": {}},
+			expected: ErrInvalidMigrationFile,
+		},
+		"zeroVersion": {
+			fsys:     fstest.MapFS{"0_create_table.up.sql": {Data: []byte("SELECT 1;")}},
+			expected: ErrInvalidMigrationFile,
+		},
+		"sameVersion": {
+			fsys: fstest.MapFS{
+				"1_create_table.up.sql": {Data: []byte("SELECT 1;")},
+				"1_other_table.up.sql":  {Data: []byte("SELECT 1;")},
+			},
+			expected: ErrDuplicatedMigration,
+		},
+		"missingUp": {
+			fsys:     fstest.MapFS{"1_create_table.down.sql": {Data: []byte("SELECT 1;")}},
+			expected: ErrMissingUpMigration,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(tc.fsys)
+
+			assert.Equal(t, tc.expected, err, "Actual err: %v", err)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindNamed rewrites the named parameters (:name) of the query into
+// positional ones ($1, $2, ...) and returns the matching arguments. The
+// parameters are either a map[string]any or a struct, in which case the
+// fields are mapped like the columns when querying: using the `db` tag or
+// the name of the field. A parameter used several times is bound once.
+// Casts (::type), string literals, quoted identifiers and comments are
+// left untouched.
+func BindNamed(sql string, params any) (string, []any, error) {
+	lookup, err := namedParamsLookup(params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var out strings.Builder
+	var arguments []any
+	positions := make(map[string]int)
+
+	for i := 0; i < len(sql); {
+		switch {
+		case sql[i] == '\'' || sql[i] == '"':
+			end := skipQuoted(sql, i)
+			out.WriteString(sql[i:end])
+			i = end
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			out.WriteString(sql[i : i+end])
+			i += end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				end = len(sql) - i
+			} else {
+				end += 4
+			}
+			out.WriteString(sql[i : i+end])
+			i += end
+		case strings.HasPrefix(sql[i:], "::"):
+			out.WriteString("::")
+			i += 2
+		case sql[i] == ':' && i+1 < len(sql) && isNameStart(sql[i+1]):
+			end := i + 1
+			for end < len(sql) && isNamePart(sql[end]) {
+				end++
+			}
+			name := sql[i+1 : end]
+
+			position, ok := positions[name]
+			if !ok {
+				value, found := lookup(name)
+				if !found {
+					return "", nil, ErrMissingNamedParam
+				}
+
+				arguments = append(arguments, value)
+				position = len(arguments)
+				positions[name] = position
+			}
+
+			out.WriteString("$" + strconv.Itoa(position))
+			i = end
+		default:
+			out.WriteByte(sql[i])
+			i++
+		}
+	}
+
+	return out.String(), arguments, nil
+}
+
+func QueryOneNamed[T any](ctx context.Context, conn Connection, sql string, params any) (T, error) {
+	query, arguments, err := BindNamed(sql, params)
+	if err != nil {
+		var out T
+		return out, err
+	}
+
+	return QueryOne[T](ctx, conn, query, arguments...)
+}
+
+func QueryAllNamed[T any](ctx context.Context, conn Connection, sql string, params any) ([]T, error) {
+	query, arguments, err := BindNamed(sql, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return QueryAll[T](ctx, conn, query, arguments...)
+}
+
+func ExecNamed(ctx context.Context, conn Connection, sql string, params any) (int64, error) {
+	query, arguments, err := BindNamed(sql, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return conn.Exec(ctx, query, arguments...)
+}
+
+func namedParamsLookup(params any) (func(name string) (any, bool), error) {
+	if values, ok := params.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			value, ok := values[name]
+			return value, ok
+		}, nil
+	}
+
+	value := reflect.ValueOf(params)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, ErrInvalidNamedParams
+	}
+
+	byName := fieldsByColumnName(value.Type())
+	return func(name string) (any, bool) {
+		index, ok := lookupField(byName, name)
+		if !ok {
+			return nil, false
+		}
+		return columnValue(value, value.Type().FieldByIndex(index)), true
+	}, nil
+}
+
+// skipQuoted returns the position right after the quoted string starting
+// at the provided position. Quotes are escaped by doubling them.
+func skipQuoted(sql string, start int) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+
+	return len(sql)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namedSample struct {
+	Id        uuid.UUID
+	FirstName string
+	Label     string `db:"display_name"`
+}
+
+func TestUnit_BindNamed(t *testing.T) {
+	type testCase struct {
+		sql               string
+		params            any
+		expectedSql       string
+		expectedArguments []any
+	}
+
+	id := uuid.New()
+	sample := namedSample{Id: id, FirstName: "first", Label: "label"}
+
+	testCases := map[string]testCase{
+		"map": {
+			sql:               "SELECT * FROM t WHERE a = :a AND b = :b",
+			params:            map[string]any{"a": 1, "b": "two"},
+			expectedSql:       "SELECT * FROM t WHERE a = $1 AND b = $2",
+			expectedArguments: []any{1, "two"},
+		},
+		"struct": {
+			sql:               "INSERT INTO t (id, first_name, display_name) VALUES (:id, :first_name, :display_name)",
+			params:            sample,
+			expectedSql:       "INSERT INTO t (id, first_name, display_name) VALUES ($1, $2, $3)",
+			expectedArguments: []any{id, "first", "label"},
+		},
+		"pointerToStruct": {
+			sql:               "SELECT :firstName",
+			params:            &sample,
+			expectedSql:       "SELECT $1",
+			expectedArguments: []any{"first"},
+		},
+		"repeatedName": {
+			sql:               "SELECT :a, :b, :a",
+			params:            map[string]any{"a": 1, "b": 2},
+			expectedSql:       "SELECT $1, $2, $1",
+			expectedArguments: []any{1, 2},
+		},
+		"castIsIgnored": {
+			sql:               "SELECT :a::text",
+			params:            map[string]any{"a": 1},
+			expectedSql:       "SELECT $1::text",
+			expectedArguments: []any{1},
+		},
+		"literalsAreIgnored": {
+			sql:               `SELECT ':a', 'it''s :a', ":a" FROM t WHERE b = :b`,
+			params:            map[string]any{"b": 2},
+			expectedSql:       `SELECT ':a', 'it''s :a', ":a" FROM t WHERE b = $1`,
+			expectedArguments: []any{2},
+		},
+		"commentsAreIgnored": {
+			sql:               "SELECT :b -- :a\n/* :a */",
+			params:            map[string]any{"b": 2},
+			expectedSql:       "SELECT $1 -- :a\n/* :a */",
+			expectedArguments: []any{2},
+		},
+		"noParams": {
+			sql:         "SELECT 1",
+			params:      map[string]any{},
+			expectedSql: "SELECT 1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualSql, actualArguments, err := BindNamed(tc.sql, tc.params)
+
+			require.NoError(t, err, "Actual err: %v", err)
+			assert.Equal(t, tc.expectedSql, actualSql)
+			assert.Equal(t, tc.expectedArguments, actualArguments)
+		})
+	}
+}
+
+func TestUnit_BindNamed_WhenParamIsMissing_ExpectError(t *testing.T) {
+	_, _, err := BindNamed("SELECT :a, :unknown", map[string]any{"a": 1})
+
+	assert.Equal(t, ErrMissingNamedParam, err, "Actual err: %v", err)
+}
+
+func TestUnit_BindNamed_WhenParamsAreInvalid_ExpectError(t *testing.T) {
+	_, _, err := BindNamed("SELECT :a", 12)
+
+	assert.Equal(t, ErrInvalidNamedParams, err, "Actual err: %v", err)
+}
+
+func TestIT_QueryNamed(t *testing.T) {
+	t.Run("successfully inserts and fetches data", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := element{Id: uuid.New(), Name: uuid.NewString()}
+
+		affected, err := ExecNamed(t.Context(), conn, "INSERT INTO my_table VALUES (:id, :name)", v)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, int64(1), affected)
+
+		params := map[string]any{"id": v.Id}
+		actual, err := QueryOneNamed[element](t.Context(), conn, "SELECT id, name FROM my_table WHERE id = :id", params)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, v, actual)
+
+		all, err := QueryAllNamed[string](t.Context(), conn, "SELECT name FROM my_table WHERE id = :id", params)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, []string{v.Name}, all)
+	})
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Option func(*options)
+
+type options struct {
+	tracer pgx.QueryTracer
+	types  []string
+}
+
+// WithTypes registers the user-defined types (enums, composites, domains,
+// ...) with the provided names, along with their array type, on each new
+// connection. Values of these types can then be scanned and passed as
+// arguments like the built-in ones, e.g. an enum array into a []string.
+// The types must exist when the connection is established.
+func WithTypes(names ...string) Option {
+	return func(o *options) {
+		o.types = append(o.types, names...)
+	}
+}
+
+// typesToLoad returns the names of the types to register, including the
+// array types. In postgres the name of the array type is the name of the
+// element type prefixed with an underscore.
+func typesToLoad(names []string) []string {
+	out := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		schema, typeName, qualified := strings.Cut(name, ".")
+		if !qualified {
+			schema, typeName = "", name
+		}
+
+		arrayName := "_" + typeName
+		if qualified {
+			arrayName = schema + "." + arrayName
+		}
+
+		out = append(out, name, arrayName)
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_TypesToLoad(t *testing.T) {
+	actual := typesToLoad([]string{"mood", "my_schema.status"})
+
+	expected := []string{"mood", "_mood", "my_schema.status", "my_schema._status"}
+	assert.Equal(t, expected, actual)
+}
+
+type testMood string
+
+func TestIT_WithTypes(t *testing.T) {
+	conn := newTestConnection(t)
+	typeName := fmt.Sprintf("mood_%s", strings.ReplaceAll(uuid.NewString(), "-", ""))
+
+	_, err := conn.Exec(t.Context(), fmt.Sprintf("CREATE TYPE %s AS ENUM ('happy', 'sad')", typeName))
+	require.NoError(t, err, "Actual err: %v", err)
+	t.Cleanup(func() {
+		// nolint: errcheck
+		conn.Exec(t.Context(), fmt.Sprintf("DROP TYPE %s", typeName))
+	})
+
+	typedConn, err := New(t.Context(), dbTestConfig, WithTypes(typeName))
+	require.NoError(t, err, "Actual err: %v", err)
+	defer typedConn.Close(t.Context())
+
+	sql := fmt.Sprintf("SELECT ARRAY['happy', 'sad']::%s[]", typeName)
+	actual, err := QueryOne[[]testMood](t.Context(), typedConn, sql)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, []testMood{"happy", "sad"}, actual)
+}
+
+func TestIT_Query_BuiltinArrays(t *testing.T) {
+	conn := newTestConnection(t)
+	id := uuid.New()
+
+	names, err := QueryOne[[]string](t.Context(), conn, "SELECT ARRAY['a', 'b']")
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	ids, err := QueryOne[[]uuid.UUID](t.Context(), conn, "SELECT ARRAY[$1::uuid]", id)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []uuid.UUID{id}, ids)
+}
@@ -9,8 +9,47 @@ type Config struct {
 	Port           uint16
 	Database       string
 	User           string
-	Password       string
+	Password       string `secret:"true"`
 	ConnectTimeout time.Duration
+	// DefaultQueryTimeout aborts the statements running for longer than
+	// this duration (using the statement_timeout of postgres). Queries
+	// executed on the connection also use it as deadline unless their
+	// context already has one. Zero disables the timeout.
+	DefaultQueryTimeout time.Duration
+	Pool                PoolConfig
+	TLS                 TLSConfig
+}
+
+// PoolConfig defines the settings of the connection pool. Zero values use
+// the defaults of pgxpool.
+type PoolConfig struct {
+	MinConns int32
+	MaxConns int32
+	// MaxConnLifetime is the duration after which a connection is closed
+	// and replaced by a new one.
+	MaxConnLifetime time.Duration
+	// MaxConnIdleTime is the duration after which an idle connection is
+	// closed.
+	MaxConnIdleTime time.Duration
+	// HealthCheckPeriod is the interval at which idle connections are
+	// checked and closed if they are broken.
+	HealthCheckPeriod time.Duration
+}
+
+// TLSConfig defines how the connection to the database is secured. The
+// fields map to the libpq parameters with the same name.
+// https://www.postgresql.org/docs/current/libpq-ssl.html
+type TLSConfig struct {
+	// Mode is one of disable, allow, prefer, require, verify-ca or
+	// verify-full. When empty the default of pgx (prefer) is used.
+	Mode string
+	// RootCertFile is the path to the certificate authorities used to
+	// verify the server.
+	RootCertFile string
+	// CertFile and KeyFile are the paths to the client certificate and
+	// its key, used when the server requires client authentication.
+	CertFile string
+	KeyFile  string
 }
 
 const defaultConnectTimeout = 5 * time.Second
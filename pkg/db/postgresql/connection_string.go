@@ -12,11 +12,31 @@ const connectionStringPrefix = "postgresql://"
 // https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNECT-CONNECT-TIMEOUT
 const connectTimeOutKey = "connect_timeout"
 
+// https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-STATEMENT-TIMEOUT
+const statementTimeoutKey = "statement_timeout"
+
+// https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNECT-SSLMODE
+const (
+	sslModeKey     = "sslmode"
+	sslRootCertKey = "sslrootcert"
+	sslCertKey     = "sslcert"
+	sslKeyKey      = "sslkey"
+)
+
+// https://pkg.go.dev/github.com/jackc/pgx/v5/pgxpool#ParseConfig
+const (
+	poolMinConnsKey          = "pool_min_conns"
+	poolMaxConnsKey          = "pool_max_conns"
+	poolMaxConnLifetimeKey   = "pool_max_conn_lifetime"
+	poolMaxConnIdleTimeKey   = "pool_max_conn_idle_time"
+	poolHealthCheckPeriodKey = "pool_health_check_period"
+)
+
 func generateConnectionString(config Config) string {
 	// https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING-URIS
 	user := generateUserSpec(config.User, config.Password)
 	host := generateHostSpec(config.Host, config.Port)
-	params := generateParamSpec((config.ConnectTimeout))
+	params := generateParamSpec(config.ConnectTimeout, config.DefaultQueryTimeout, config.Pool, config.TLS)
 
 	out := connectionStringPrefix
 	if user != "" {
@@ -51,9 +71,45 @@ func generateHostSpec(host string, port uint16) string {
 	return strings.TrimSuffix(hostSpec, ":0")
 }
 
-func generateParamSpec(connectionTimeout time.Duration) string {
-	if connectionTimeout == 0 {
-		return ""
+func generateParamSpec(
+	connectionTimeout time.Duration, queryTimeout time.Duration, pool PoolConfig, tls TLSConfig,
+) string {
+	var params []string
+	if connectionTimeout != 0 {
+		params = append(params, fmt.Sprintf("%s=%d", connectTimeOutKey, int(connectionTimeout.Seconds())))
+	}
+	if queryTimeout != 0 {
+		params = append(params, fmt.Sprintf("%s=%d", statementTimeoutKey, queryTimeout.Milliseconds()))
+	}
+
+	if pool.MinConns != 0 {
+		params = append(params, fmt.Sprintf("%s=%d", poolMinConnsKey, pool.MinConns))
+	}
+	if pool.MaxConns != 0 {
+		params = append(params, fmt.Sprintf("%s=%d", poolMaxConnsKey, pool.MaxConns))
+	}
+	if pool.MaxConnLifetime != 0 {
+		params = append(params, fmt.Sprintf("%s=%s", poolMaxConnLifetimeKey, pool.MaxConnLifetime))
+	}
+	if pool.MaxConnIdleTime != 0 {
+		params = append(params, fmt.Sprintf("%s=%s", poolMaxConnIdleTimeKey, pool.MaxConnIdleTime))
+	}
+	if pool.HealthCheckPeriod != 0 {
+		params = append(params, fmt.Sprintf("%s=%s", poolHealthCheckPeriodKey, pool.HealthCheckPeriod))
+	}
+
+	if tls.Mode != "" {
+		params = append(params, fmt.Sprintf("%s=%s", sslModeKey, url.QueryEscape(tls.Mode)))
 	}
-	return fmt.Sprintf("%s=%d", connectTimeOutKey, int(connectionTimeout.Seconds()))
+	if tls.RootCertFile != "" {
+		params = append(params, fmt.Sprintf("%s=%s", sslRootCertKey, url.QueryEscape(tls.RootCertFile)))
+	}
+	if tls.CertFile != "" {
+		params = append(params, fmt.Sprintf("%s=%s", sslCertKey, url.QueryEscape(tls.CertFile)))
+	}
+	if tls.KeyFile != "" {
+		params = append(params, fmt.Sprintf("%s=%s", sslKeyKey, url.QueryEscape(tls.KeyFile)))
+	}
+
+	return strings.Join(params, "&")
 }
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnit_GenerateConnectionString(t *testing.T) {
@@ -15,6 +17,7 @@ func TestUnit_GenerateConnectionString(t *testing.T) {
 		user           string
 		password       string
 		connectTimeout time.Duration
+		pool           PoolConfig
 
 		expectedConnectionString string
 	}
@@ -81,6 +84,22 @@ func TestUnit_GenerateConnectionString(t *testing.T) {
 			connectTimeout:           10 * time.Second,
 			expectedConnectionString: "postgresql://?connect_timeout=10",
 		},
+		{
+			host:           "localhost",
+			connectTimeout: 10 * time.Second,
+			pool: PoolConfig{
+				MinConns:          2,
+				MaxConns:          20,
+				MaxConnLifetime:   time.Hour,
+				MaxConnIdleTime:   5 * time.Minute,
+				HealthCheckPeriod: 30 * time.Second,
+			},
+			expectedConnectionString: "postgresql://localhost?connect_timeout=10&pool_min_conns=2&pool_max_conns=20&pool_max_conn_lifetime=1h0m0s&pool_max_conn_idle_time=5m0s&pool_health_check_period=30s",
+		},
+		{
+			pool:                     PoolConfig{MaxConns: 8},
+			expectedConnectionString: "postgresql://?pool_max_conns=8",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -92,6 +111,7 @@ func TestUnit_GenerateConnectionString(t *testing.T) {
 				User:           testCase.user,
 				Password:       testCase.password,
 				ConnectTimeout: testCase.connectTimeout,
+				Pool:           testCase.pool,
 			}
 
 			actual := generateConnectionString(config)
@@ -100,3 +120,51 @@ func TestUnit_GenerateConnectionString(t *testing.T) {
 		})
 	}
 }
+
+func TestUnit_GenerateConnectionString_ExpectPoolSettingsToBeParsed(t *testing.T) {
+	config := Config{
+		Host: "localhost",
+		Pool: PoolConfig{
+			MinConns:          2,
+			MaxConns:          20,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   5 * time.Minute,
+			HealthCheckPeriod: 30 * time.Second,
+		},
+	}
+
+	actual, err := pgxpool.ParseConfig(generateConnectionString(config))
+
+	require.Nil(t, err)
+	assert.Equal(t, int32(2), actual.MinConns)
+	assert.Equal(t, int32(20), actual.MaxConns)
+	assert.Equal(t, time.Hour, actual.MaxConnLifetime)
+	assert.Equal(t, 5*time.Minute, actual.MaxConnIdleTime)
+	assert.Equal(t, 30*time.Second, actual.HealthCheckPeriod)
+}
+
+func TestUnit_GenerateConnectionString_ExpectTLSSettingsToBeParsed(t *testing.T) {
+	config := Config{
+		Host: "localhost",
+		TLS: TLSConfig{
+			Mode: "require",
+		},
+	}
+
+	actual, err := pgxpool.ParseConfig(generateConnectionString(config))
+
+	require.Nil(t, err)
+	assert.NotNil(t, actual.ConnConfig.TLSConfig)
+}
+
+func TestUnit_GenerateConnectionString_ExpectStatementTimeoutToBeParsed(t *testing.T) {
+	config := Config{
+		Host:                "localhost",
+		DefaultQueryTimeout: 3 * time.Second,
+	}
+
+	actual, err := pgxpool.ParseConfig(generateConnectionString(config))
+
+	require.Nil(t, err)
+	assert.Equal(t, "3000", actual.ConnConfig.RuntimeParams["statement_timeout"])
+}
@@ -0,0 +1,25 @@
+package postgresql
+
+import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+
+const (
+	errInvalidConnectionUrl errors.ErrorCode = 120
+	errUnsupportedUrlParam  errors.ErrorCode = 121
+	errMissingConnectionUrl errors.ErrorCode = 122
+)
+
+var (
+	ErrInvalidConnectionUrl = errors.FromCode(errInvalidConnectionUrl)
+	ErrUnsupportedUrlParam  = errors.FromCode(errUnsupportedUrlParam)
+	ErrMissingConnectionUrl = errors.FromCode(errMissingConnectionUrl)
+)
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errInvalidConnectionUrl, Name: "postgresql.invalid_connection_url"},
+		{Code: errUnsupportedUrlParam, Name: "postgresql.unsupported_url_param"},
+		{Code: errMissingConnectionUrl, Name: "postgresql.missing_connection_url"},
+	} {
+		errors.Register(info)
+	}
+}
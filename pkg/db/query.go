@@ -2,20 +2,24 @@ package db
 
 import (
 	"context"
+	stderrors "errors"
 	"reflect"
 	"time"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
 	"github.com/jackc/pgx/v5"
 )
 
 func QueryOne[T any](ctx context.Context, conn Connection, sql string, arguments ...any) (T, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
 	var out T
 
-	connImpl, ok := conn.(*connectionImpl)
+	querier, ok := conn.(Querier)
 	if !ok {
 		return out, ErrUnsupportedOperation
 	}
-	rows, err := connImpl.query(ctx, sql, arguments...)
+	rows, err := querier.Query(ctx, sql, arguments...)
 	if err != nil {
 		return out, analyzeAndWrapDatabaseError(err)
 	}
@@ -35,21 +39,36 @@ func QueryOne[T any](ctx context.Context, conn Connection, sql string, arguments
 	return out, nil
 }
 
+// QueryMaybeOne is similar to QueryOne but reports the absence of rows with the
+// boolean instead of returning ErrNoMatchingRows.
+func QueryMaybeOne[T any](ctx context.Context, conn Connection, sql string, arguments ...any) (T, bool, error) {
+	out, err := QueryOne[T](ctx, conn, sql, arguments...)
+	if stderrors.Is(err, ErrNoMatchingRows) {
+		return out, false, nil
+	} else if err != nil {
+		return out, false, err
+	}
+
+	return out, true, nil
+}
+
 func QueryAll[T any](ctx context.Context, conn Connection, sql string, arguments ...any) ([]T, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
 	var out []T
 
-	connImpl, ok := conn.(*connectionImpl)
+	querier, ok := conn.(Querier)
 	if !ok {
 		return out, ErrUnsupportedOperation
 	}
-	rows, err := connImpl.query(ctx, sql, arguments...)
+	rows, err := querier.Query(ctx, sql, arguments...)
 	if err != nil {
 		return out, analyzeAndWrapDatabaseError(err)
 	}
 
 	out, err = pgx.CollectRows(rows, getCollectorForType[T]())
 	if err != nil {
-		return out, ErrUnsupportedOperation
+		return out, analyzeAndWrapDatabaseError(err)
 	}
 
 	return out, nil
@@ -58,16 +77,21 @@ func QueryAll[T any](ctx context.Context, conn Connection, sql string, arguments
 var timeStructName = reflect.ValueOf(time.Time{}).Type().Name()
 
 func getCollectorForType[T any]() pgx.RowToFunc[T] {
-	var value T
-
-	kind := reflect.ValueOf(value).Kind()
-	typeName := reflect.ValueOf(value).Type().Name()
-
 	// https://pkg.go.dev/github.com/jackc/pgx/v5#RowToStructByName
-	if kind == reflect.Struct &&
-		typeName != timeStructName {
+	if isRowStruct[T]() {
 		return pgx.RowToStructByName[T]
 	}
 
 	return pgx.RowTo[T]
 }
+
+// isRowStruct returns true when the fields of T are mapped to the columns
+// of a row, as opposed to types mapped to a single column.
+func isRowStruct[T any]() bool {
+	var value T
+
+	kind := reflect.ValueOf(value).Kind()
+	typeName := reflect.ValueOf(value).Type().Name()
+
+	return kind == reflect.Struct && typeName != timeStructName
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
+	"github.com/jackc/pgx/v5"
+)
+
+// Iterator reads the rows of a query one at a time so that large result
+// sets do not need to be loaded in memory:
+//
+//	it, err := db.QueryIter[T](ctx, conn, sql)
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//
+//	for it.Next() {
+//		process(it.Value())
+//	}
+//	return it.Err()
+type Iterator[T any] interface {
+	// Next advances to the next row. It returns false when there are no
+	// more rows or an error occurred.
+	Next() bool
+	Value() T
+	Err() error
+	// Close releases the connection used by the iterator. It is safe to
+	// call it several times.
+	Close()
+}
+
+type iteratorImpl[T any] struct {
+	rows      pgx.Rows
+	collector pgx.RowToFunc[T]
+	value     T
+	err       error
+}
+
+func QueryIter[T any](ctx context.Context, conn Connection, sql string, arguments ...any) (Iterator[T], error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
+	querier, ok := conn.(Querier)
+	if !ok {
+		return nil, ErrUnsupportedOperation
+	}
+	rows, err := querier.Query(ctx, sql, arguments...)
+	if err != nil {
+		return nil, analyzeAndWrapDatabaseError(err)
+	}
+
+	return newIterator[T](rows), nil
+}
+
+func QueryIterTx[T any](ctx context.Context, tx Transaction, sql string, arguments ...any) (Iterator[T], error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
+	querier, ok := tx.(Querier)
+	if !ok {
+		return nil, ErrUnsupportedOperation
+	}
+	rows, err := querier.Query(ctx, sql, arguments...)
+	if err != nil {
+		return nil, analyzeAndWrapDatabaseError(err)
+	}
+
+	return newIterator[T](rows), nil
+}
+
+func newIterator[T any](rows pgx.Rows) *iteratorImpl[T] {
+	return &iteratorImpl[T]{
+		rows:      rows,
+		collector: getCollectorForType[T](),
+	}
+}
+
+func (it *iteratorImpl[T]) Next() bool {
+	if it.err != nil || !it.rows.Next() {
+		it.Close()
+		return false
+	}
+
+	value, err := it.collector(it.rows)
+	if err != nil {
+		it.err = analyzeAndWrapDatabaseError(err)
+		it.Close()
+		return false
+	}
+
+	it.value = value
+	return true
+}
+
+func (it *iteratorImpl[T]) Value() T {
+	return it.value
+}
+
+func (it *iteratorImpl[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+
+	return analyzeAndWrapDatabaseError(it.rows.Err())
+}
+
+func (it *iteratorImpl[T]) Close() {
+	it.rows.Close()
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIT_QueryIter(t *testing.T) {
+	t.Run("returns error when connection is not supported", func(t *testing.T) {
+		_, err := QueryIter[int](t.Context(), &dummyConnection{}, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+	})
+
+	t.Run("returns error when connection is closed", func(t *testing.T) {
+		conn := newTestConnection(t)
+		conn.Close(t.Context())
+
+		_, err := QueryIter[int](t.Context(), conn, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrNotConnected, err, "Actual err: %v", err)
+	})
+
+	t.Run("successfully iterates over no rows", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		sqlQuery := "SELECT id, name FROM my_table WHERE name = $1"
+		it, err := QueryIter[element](t.Context(), conn, sqlQuery, "does-not-exist")
+		require.NoError(t, err, "Actual err: %v", err)
+		defer it.Close()
+
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+	})
+
+	t.Run("successfully iterates over rows", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v1 := insertTestData(t, conn)
+		v2 := insertTestData(t, conn)
+
+		sqlQuery := `SELECT id, name FROM my_table WHERE id IN ($1, $2)`
+		it, err := QueryIter[element](t.Context(), conn, sqlQuery, v1.Id, v2.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+		defer it.Close()
+
+		var actual []element
+		for it.Next() {
+			actual = append(actual, it.Value())
+		}
+		require.NoError(t, it.Err(), "Actual err: %v", it.Err())
+
+		assert.ElementsMatch(t, []element{v1, v2}, actual)
+	})
+
+	t.Run("releases connection when closed early", func(t *testing.T) {
+		conn := newTestConnection(t)
+		insertTestData(t, conn)
+
+		it, err := QueryIter[string](t.Context(), conn, "SELECT name FROM my_table")
+		require.NoError(t, err, "Actual err: %v", err)
+		require.True(t, it.Next())
+		it.Close()
+		it.Close()
+
+		assert.Equal(t, int32(0), conn.Stats().AcquiredConns)
+	})
+
+	t.Run("reports error when mapping fails", func(t *testing.T) {
+		conn := newTestConnection(t)
+		insertTestData(t, conn)
+
+		it, err := QueryIter[int](t.Context(), conn, "SELECT name FROM my_table")
+		require.NoError(t, err, "Actual err: %v", err)
+		defer it.Close()
+
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+}
+
+func TestIT_QueryIterTx(t *testing.T) {
+	t.Run("returns error when transaction is not supported", func(t *testing.T) {
+		_, err := QueryIterTx[int](t.Context(), &dummyTransaction{}, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+	})
+
+	t.Run("successfully iterates over rows", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+		v1 := insertTestDataTx(t, tx)
+
+		sqlQuery := `SELECT id, name FROM my_table WHERE id = $1`
+		it, err := QueryIterTx[element](t.Context(), tx, sqlQuery, v1.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+		defer it.Close()
+
+		require.True(t, it.Next())
+		assert.Equal(t, v1, it.Value())
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+	})
+}
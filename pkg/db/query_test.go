@@ -84,7 +84,7 @@ func TestIT_QueryOne(t *testing.T) {
 			SqlCode:    "23505",
 			Schema:     "test_db_schema",
 			Table:      "my_table",
-			Column:     "",
+			Column:     "name",
 			Constraint: "my_table_name_key",
 			Cause:      actual.Cause,
 		}
@@ -137,6 +137,47 @@ func TestIT_QueryOne(t *testing.T) {
 	})
 }
 
+func TestIT_QueryMaybeOne(t *testing.T) {
+	t.Run("returns error when connection is not supported", func(t *testing.T) {
+		_, _, err := QueryMaybeOne[int](t.Context(), &dummyConnection{}, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+	})
+
+	t.Run("reports missing row without error", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		sqlQuery := "SELECT name FROM my_table WHERE name = $1"
+		_, found, err := QueryMaybeOne[string](t.Context(), conn, sqlQuery, "does-not-exist")
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.False(t, found)
+	})
+
+	t.Run("successfully fetches row", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := insertTestData(t, conn)
+
+		sqlQuery := "SELECT id, name FROM my_table WHERE id = $1"
+		actual, found, err := QueryMaybeOne[element](t.Context(), conn, sqlQuery, v.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.True(t, found)
+		assert.Equal(t, v, actual)
+	})
+
+	t.Run("returns error when too many rows match", func(t *testing.T) {
+		conn := newTestConnection(t)
+		insertTestData(t, conn)
+		insertTestData(t, conn)
+
+		_, found, err := QueryMaybeOne[string](t.Context(), conn, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrTooManyMatchingRows, err, "Actual err: %v", err)
+		assert.False(t, found)
+	})
+}
+
 func TestIT_QueryAll(t *testing.T) {
 	t.Run("returns error when connection is not supported", func(t *testing.T) {
 		_, err := QueryAll[int](t.Context(), &dummyConnection{}, sampleSqlQuery)
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-STATEMENT-TIMEOUT
+const statementTimeoutParam = "statement_timeout"
+
+// queryTimeout returns the statement timeout configured for the pool. It
+// is also used as the default deadline of the queries so that they don't
+// wait indefinitely, e.g. for a connection of the pool to be available.
+func queryTimeout(config *pgxpool.Config) time.Duration {
+	value, ok := config.ConnConfig.RuntimeParams[statementTimeoutParam]
+	if !ok {
+		return 0
+	}
+
+	// Without unit the value is in milliseconds.
+	if ms, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond
+	}
+	if d, err := time.ParseDuration(strings.ReplaceAll(value, " ", "")); err == nil {
+		return d
+	}
+	return 0
+}
+
+// withDefaultTimeout applies the timeout to the context unless it already
+// has a deadline.
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// wrapTimeoutError converts the errors caused by the expiration of the
+// default deadline to ErrStatementTimeout. Deadlines set by the caller are
+// left untouched.
+func wrapTimeoutError(ctx context.Context, parent context.Context, err error) error {
+	if err == nil || ctx == parent || ctx.Err() != context.DeadlineExceeded {
+		return err
+	}
+	return errors.WrapCode(err, ErrStatementTimeout)
+}
+
+// timeoutRows releases the default deadline of the query once the rows
+// are consumed.
+type timeoutRows struct {
+	pgx.Rows
+	ctx    context.Context
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+func (r *timeoutRows) Err() error {
+	return wrapTimeoutError(r.ctx, r.parent, r.Rows.Err())
+}
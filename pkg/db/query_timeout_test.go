@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	berrors "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_QueryTimeout(t *testing.T) {
+	testCases := map[string]time.Duration{
+		"postgresql://localhost":                        0,
+		"postgresql://localhost?statement_timeout=1500": 1500 * time.Millisecond,
+		"postgresql://localhost?statement_timeout=2s":   2 * time.Second,
+		"postgresql://localhost?statement_timeout=abc":  0,
+	}
+
+	for connStr, expected := range testCases {
+		t.Run(connStr, func(t *testing.T) {
+			config, err := pgxpool.ParseConfig(connStr)
+			require.NoError(t, err, "Actual err: %v", err)
+
+			assert.Equal(t, expected, queryTimeout(config))
+		})
+	}
+}
+
+func TestUnit_WithDefaultTimeout(t *testing.T) {
+	t.Run("applies timeout", func(t *testing.T) {
+		ctx, cancel := withDefaultTimeout(t.Context(), time.Minute)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("keeps deadline of caller", func(t *testing.T) {
+		parent, parentCancel := context.WithTimeout(t.Context(), time.Hour)
+		defer parentCancel()
+
+		ctx, cancel := withDefaultTimeout(parent, time.Minute)
+		defer cancel()
+
+		assert.Equal(t, parent, ctx)
+	})
+
+	t.Run("does nothing when timeout is disabled", func(t *testing.T) {
+		ctx, cancel := withDefaultTimeout(t.Context(), 0)
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
+
+func TestUnit_WrapTimeoutError(t *testing.T) {
+	t.Run("wraps error when default deadline expired", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		actual, ok := berrors.AsErrorWithCode(wrapTimeoutError(ctx, t.Context(), errSomeError))
+
+		require.True(t, ok)
+		assert.Equal(t, ErrStatementTimeout, actual.Code)
+	})
+
+	t.Run("keeps error when deadline is the one of the caller", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		actual := wrapTimeoutError(ctx, ctx, context.DeadlineExceeded)
+
+		assert.Equal(t, context.DeadlineExceeded, actual)
+	})
+
+	t.Run("keeps error when deadline did not expire", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Hour)
+		defer cancel()
+
+		actual := wrapTimeoutError(ctx, t.Context(), errSomeError)
+
+		assert.Equal(t, errSomeError, actual)
+	})
+}
+
+func TestIT_DefaultQueryTimeout(t *testing.T) {
+	config := dbTestConfig
+	config.DefaultQueryTimeout = 100 * time.Millisecond
+
+	conn, err := New(t.Context(), config)
+	require.NoError(t, err, "Actual err: %v", err)
+	defer conn.Close(t.Context())
+
+	t.Run("exec", func(t *testing.T) {
+		_, err := conn.Exec(t.Context(), "SELECT pg_sleep(1)")
+
+		assert.True(t, berrors.IsErrorWithCode(err, ErrStatementTimeout), "Actual err: %v", err)
+	})
+
+	t.Run("query all", func(t *testing.T) {
+		_, err := QueryAll[string](t.Context(), conn, "SELECT pg_sleep(1)::text")
+
+		assert.True(t, berrors.IsErrorWithCode(err, ErrStatementTimeout), "Actual err: %v", err)
+	})
+}
@@ -2,18 +2,22 @@ package db
 
 import (
 	"context"
+	stderrors "errors"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
 	"github.com/jackc/pgx/v5"
 )
 
 func QueryOneTx[T any](ctx context.Context, tx Transaction, sql string, arguments ...any) (T, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
 	var out T
 
-	txImpl, ok := tx.(*transactionImpl)
+	querier, ok := tx.(Querier)
 	if !ok {
 		return out, ErrUnsupportedOperation
 	}
-	rows, err := txImpl.query(ctx, sql, arguments...)
+	rows, err := querier.Query(ctx, sql, arguments...)
 	if err != nil {
 		return out, analyzeAndWrapDatabaseError(err)
 	}
@@ -33,21 +37,36 @@ func QueryOneTx[T any](ctx context.Context, tx Transaction, sql string, argument
 	return out, nil
 }
 
+// QueryMaybeOneTx is similar to QueryOneTx but reports the absence of rows with the
+// boolean instead of returning ErrNoMatchingRows.
+func QueryMaybeOneTx[T any](ctx context.Context, tx Transaction, sql string, arguments ...any) (T, bool, error) {
+	out, err := QueryOneTx[T](ctx, tx, sql, arguments...)
+	if stderrors.Is(err, ErrNoMatchingRows) {
+		return out, false, nil
+	} else if err != nil {
+		return out, false, err
+	}
+
+	return out, true, nil
+}
+
 func QueryAllTx[T any](ctx context.Context, tx Transaction, sql string, arguments ...any) ([]T, error) {
+	defer timing.Track(ctx, timing.SegmentDb)()
+
 	var out []T
 
-	txImpl, ok := tx.(*transactionImpl)
+	querier, ok := tx.(Querier)
 	if !ok {
 		return out, ErrUnsupportedOperation
 	}
-	rows, err := txImpl.query(ctx, sql, arguments...)
+	rows, err := querier.Query(ctx, sql, arguments...)
 	if err != nil {
 		return out, analyzeAndWrapDatabaseError(err)
 	}
 
 	out, err = pgx.CollectRows(rows, getCollectorForType[T]())
 	if err != nil {
-		return out, ErrUnsupportedOperation
+		return out, analyzeAndWrapDatabaseError(err)
 	}
 
 	return out, nil
@@ -81,7 +81,7 @@ func TestIT_QueryOneTx(t *testing.T) {
 			SqlCode:    "23505",
 			Schema:     "test_db_schema",
 			Table:      "my_table",
-			Column:     "",
+			Column:     "name",
 			Constraint: "my_table_name_key",
 			Cause:      actual.Cause,
 		}
@@ -134,6 +134,36 @@ func TestIT_QueryOneTx(t *testing.T) {
 	})
 }
 
+func TestIT_QueryMaybeOneTx(t *testing.T) {
+	t.Run("returns error when transaction is not supported", func(t *testing.T) {
+		_, _, err := QueryMaybeOneTx[int](t.Context(), &dummyTransaction{}, sampleSqlQuery)
+
+		assert.ErrorIs(t, ErrUnsupportedOperation, err, "Actual err: %v", err)
+	})
+
+	t.Run("reports missing row without error", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+
+		sqlQuery := "SELECT name FROM my_table WHERE name = $1"
+		_, found, err := QueryMaybeOneTx[string](t.Context(), tx, sqlQuery, "does-not-exist")
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.False(t, found)
+	})
+
+	t.Run("successfully fetches row", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+		v := insertTestDataTx(t, tx)
+
+		sqlQuery := "SELECT id, name FROM my_table WHERE id = $1"
+		actual, found, err := QueryMaybeOneTx[element](t.Context(), tx, sqlQuery, v.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.True(t, found)
+		assert.Equal(t, v, actual)
+	})
+}
+
 func TestIT_QueryAllTx(t *testing.T) {
 	t.Run("returns error when transaction is not supported", func(t *testing.T) {
 		_, err := QueryAllTx[int](t.Context(), &dummyTransaction{}, sampleSqlQuery)
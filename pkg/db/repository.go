@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Repository implements the common operations on a table from the
+// definition of a struct:
+//
+//	type User struct {
+//		_     struct{}  `table:"users"`
+//		Id    uuid.UUID `db:"id,pk"`
+//		Email string    `db:"email"`
+//	}
+//
+// The table is defined by the `table` tag of a blank field. The columns
+// are mapped like when querying (using the `db` tag or the name of the
+// field) and the primary key is made of the columns with the `pk` option.
+// The errors follow the ones of QueryOne: ErrNoMatchingRows is returned
+// when the row does not exist.
+//
+// The operations accept either a Connection or a Transaction.
+type Repository[T any] struct {
+	columnMapping
+}
+
+// columnMapping describes how the fields of a struct map to the columns
+// of a table.
+type columnMapping struct {
+	table   string
+	columns []string
+	keys    []string
+	fields  []reflect.StructField
+}
+
+func NewRepository[T any]() (*Repository[T], error) {
+	if !isRowStruct[T]() {
+		return nil, ErrInvalidRepository
+	}
+
+	r := &Repository[T]{columnMapping: newColumnMapping(reflect.TypeFor[T]())}
+	if r.table == "" || len(r.keys) == 0 {
+		return nil, ErrInvalidRepository
+	}
+
+	return r, nil
+}
+
+func newColumnMapping(typ reflect.Type) columnMapping {
+	var m columnMapping
+	for _, field := range reflect.VisibleFields(typ) {
+		if table, ok := field.Tag.Lookup("table"); ok && field.Name == "_" {
+			m.table = table
+			continue
+		}
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("db")
+		column, options, _ := strings.Cut(tag, ",")
+		if column == "-" {
+			continue
+		}
+		if !tagged || column == "" {
+			column = toSnakeCase(field.Name)
+		}
+
+		m.columns = append(m.columns, column)
+		m.fields = append(m.fields, field)
+		if slices.Contains(strings.Split(options, ","), "pk") {
+			m.keys = append(m.keys, column)
+		}
+	}
+
+	return m
+}
+
+// GetById fetches the row with the provided primary key. The values of the
+// key should be in the order of the fields of the struct.
+func (r *Repository[T]) GetById(ctx context.Context, exec Executor, key ...any) (T, error) {
+	sql := fmt.Sprintf("SELECT %s FROM %s WHERE %s", r.columnList(), r.table, r.keyCondition(1))
+	return queryOne[T](ctx, exec, sql, key...)
+}
+
+func (r *Repository[T]) List(ctx context.Context, exec Executor) ([]T, error) {
+	sql := fmt.Sprintf("SELECT %s FROM %s ORDER BY %s", r.columnList(), r.table, strings.Join(r.keys, ", "))
+	return queryAll[T](ctx, exec, sql)
+}
+
+// Insert inserts the value and returns the row as stored in the database,
+// e.g. with the values of columns having a default.
+func (r *Repository[T]) Insert(ctx context.Context, exec Executor, value T) (T, error) {
+	sql := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+		r.table,
+		r.columnList(),
+		placeholders(1, len(r.columns)),
+		r.columnList(),
+	)
+	return queryOne[T](ctx, exec, sql, r.values(value, r.columns)...)
+}
+
+// Update replaces all the columns of the row identified by the primary key
+// of the value.
+func (r *Repository[T]) Update(ctx context.Context, exec Executor, value T) (T, error) {
+	columns := r.nonKeyColumns()
+
+	var assignments []string
+	for i, column := range columns {
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, i+1))
+	}
+
+	sql := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s RETURNING %s",
+		r.table,
+		strings.Join(assignments, ", "),
+		r.keyCondition(len(columns)+1),
+		r.columnList(),
+	)
+	arguments := append(r.values(value, columns), r.values(value, r.keys)...)
+	return queryOne[T](ctx, exec, sql, arguments...)
+}
+
+func (r *Repository[T]) Delete(ctx context.Context, exec Executor, key ...any) error {
+	sql := fmt.Sprintf("DELETE FROM %s WHERE %s", r.table, r.keyCondition(1))
+	return execOnSingleRow(ctx, exec, sql, key...)
+}
+
+// Upsert inserts the value or updates the row with the same primary key.
+func (r *Repository[T]) Upsert(ctx context.Context, exec Executor, value T) (T, error) {
+	sql := fmt.Sprintf("%s RETURNING %s", upsertSql(r.table, r.columns, r.keys), r.columnList())
+	return queryOne[T](ctx, exec, sql, r.values(value, r.columns)...)
+}
+
+func (m columnMapping) columnList() string {
+	return strings.Join(m.columns, ", ")
+}
+
+func (m columnMapping) nonKeyColumns() []string {
+	var out []string
+	for _, column := range m.columns {
+		if !slices.Contains(m.keys, column) {
+			out = append(out, column)
+		}
+	}
+	return out
+}
+
+func (m columnMapping) keyCondition(firstPlaceholder int) string {
+	var conditions []string
+	for i, key := range m.keys {
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", key, firstPlaceholder+i))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func (m columnMapping) values(value any, columns []string) []any {
+	v := reflect.ValueOf(value)
+
+	out := make([]any, 0, len(columns))
+	for _, column := range columns {
+		index := slices.Index(m.columns, column)
+		out = append(out, columnValue(v, m.fields[index]))
+	}
+	return out
+}
+
+func placeholders(first int, count int) string {
+	out := make([]string, 0, count)
+	for i := range count {
+		out = append(out, fmt.Sprintf("$%d", first+i))
+	}
+	return strings.Join(out, ", ")
+}
+
+// toSnakeCase converts the name of a field to the name of its column. The
+// conversion is consistent with the mapping used when querying which
+// ignores the case and the underscores.
+func toSnakeCase(name string) string {
+	var out strings.Builder
+	for i, c := range name {
+		if 'A' <= c && c <= 'Z' {
+			if i > 0 && !('A' <= rune(name[i-1]) && rune(name[i-1]) <= 'Z') {
+				out.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
+
+func queryOne[T any](ctx context.Context, exec Executor, sql string, arguments ...any) (T, error) {
+	switch e := exec.(type) {
+	case Transaction:
+		return QueryOneTx[T](ctx, e, sql, arguments...)
+	case Connection:
+		return QueryOne[T](ctx, e, sql, arguments...)
+	default:
+		var out T
+		return out, ErrUnsupportedOperation
+	}
+}
+
+func queryAll[T any](ctx context.Context, exec Executor, sql string, arguments ...any) ([]T, error) {
+	switch e := exec.(type) {
+	case Transaction:
+		return QueryAllTx[T](ctx, e, sql, arguments...)
+	case Connection:
+		return QueryAll[T](ctx, e, sql, arguments...)
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryElement struct {
+	_    struct{}  `table:"my_table"`
+	Id   uuid.UUID `db:"id,pk"`
+	Name string
+}
+
+type repositoryComposite struct {
+	_         struct{} `table:"composite"`
+	TenantId  string   `db:"tenant_id,pk"`
+	UserID    int      `db:",pk"`
+	FirstName string
+	Ignored   string `db:"-"`
+}
+
+func TestUnit_NewRepository(t *testing.T) {
+	r, err := NewRepository[repositoryComposite]()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, "composite", r.table)
+	assert.Equal(t, []string{"tenant_id", "user_id", "first_name"}, r.columns)
+	assert.Equal(t, []string{"tenant_id", "user_id"}, r.keys)
+	assert.Equal(t, "tenant_id = $1 AND user_id = $2", r.keyCondition(1))
+}
+
+func TestUnit_NewRepository_WhenTypeIsInvalid_ExpectError(t *testing.T) {
+	type noTable struct {
+		Id int `db:"id,pk"`
+	}
+	type noKey struct {
+		_  struct{} `table:"t"`
+		Id int      `db:"id"`
+	}
+
+	_, err := NewRepository[int]()
+	assert.Equal(t, ErrInvalidRepository, err, "Actual err: %v", err)
+	_, err = NewRepository[noTable]()
+	assert.Equal(t, ErrInvalidRepository, err, "Actual err: %v", err)
+	_, err = NewRepository[noKey]()
+	assert.Equal(t, ErrInvalidRepository, err, "Actual err: %v", err)
+}
+
+func TestUnit_ToSnakeCase(t *testing.T) {
+	testCases := map[string]string{
+		"Id":        "id",
+		"FirstName": "first_name",
+		"UserID":    "user_id",
+		"name":      "name",
+	}
+
+	for in, expected := range testCases {
+		t.Run(in, func(t *testing.T) {
+			assert.Equal(t, expected, toSnakeCase(in))
+		})
+	}
+}
+
+func TestIT_Repository(t *testing.T) {
+	r, err := NewRepository[repositoryElement]()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	t.Run("inserts and fetches row", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := repositoryElement{Id: uuid.New(), Name: uuid.NewString()}
+
+		inserted, err := r.Insert(t.Context(), conn, v)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, v, inserted)
+
+		actual, err := r.GetById(t.Context(), conn, v.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, v, actual)
+	})
+
+	t.Run("returns error when row does not exist", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		_, err := r.GetById(t.Context(), conn, uuid.New())
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+
+		_, err = r.Update(t.Context(), conn, repositoryElement{Id: uuid.New()})
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+
+		err = r.Delete(t.Context(), conn, uuid.New())
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+	})
+
+	t.Run("lists rows", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := insertTestData(t, conn)
+
+		actual, err := r.List(t.Context(), conn)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.Contains(t, actual, repositoryElement{Id: v.Id, Name: v.Name})
+	})
+
+	t.Run("updates row", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := insertTestData(t, conn)
+
+		updated := repositoryElement{Id: v.Id, Name: uuid.NewString()}
+		actual, err := r.Update(t.Context(), conn, updated)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assert.Equal(t, updated, actual)
+		assertNameForId(t, conn, v.Id, updated.Name)
+	})
+
+	t.Run("deletes row", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := insertTestData(t, conn)
+
+		err := r.Delete(t.Context(), conn, v.Id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertIdDoesNotExist(t, conn, v.Id)
+	})
+
+	t.Run("upserts row", func(t *testing.T) {
+		conn := newTestConnection(t)
+		v := repositoryElement{Id: uuid.New(), Name: uuid.NewString()}
+
+		_, err := r.Upsert(t.Context(), conn, v)
+		require.NoError(t, err, "Actual err: %v", err)
+		assertNameForId(t, conn, v.Id, v.Name)
+
+		v.Name = uuid.NewString()
+		_, err = r.Upsert(t.Context(), conn, v)
+		require.NoError(t, err, "Actual err: %v", err)
+		assertNameForId(t, conn, v.Id, v.Name)
+	})
+
+	t.Run("works within transaction", func(t *testing.T) {
+		conn, tx := newTestTransaction(t)
+		v := repositoryElement{Id: uuid.New(), Name: uuid.NewString()}
+
+		_, err := r.Insert(t.Context(), tx, v)
+		require.NoError(t, err, "Actual err: %v", err)
+		tx.Close(t.Context())
+
+		assertNameForId(t, conn, v.Id, v.Name)
+	})
+}
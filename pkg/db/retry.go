@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultBackoffInitialInterval = 500 * time.Millisecond
+	defaultBackoffMaxInterval     = 10 * time.Second
+	defaultBackoffMultiplier      = 2
+	defaultBackoffMaxWait         = time.Minute
+)
+
+// BackoffPolicy defines how the connection attempts are spaced. Zero values
+// use the defaults: 500ms initially, doubling up to 10s, for at most 1 min.
+type BackoffPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// MaxWait bounds the total time spent retrying.
+	MaxWait time.Duration
+	// Logger receives a log for each failed attempt. Defaults to
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+// NewWithRetry is like New but retries establishing the connection until
+// it succeeds or the MaxWait of the policy is elapsed. This is useful when
+// the service might start before the database, e.g. in docker compose.
+// Errors which can't be solved by waiting, such as invalid credentials,
+// are returned immediately.
+func NewWithRetry(ctx context.Context, config Config, policy BackoffPolicy, opts ...Option) (Connection, error) {
+	policy = policy.withDefaults()
+	deadline := time.Now().Add(policy.MaxWait)
+
+	for attempt := 1; ; attempt++ {
+		conn, err := New(ctx, config, opts...)
+		if err == nil {
+			return conn, nil
+		}
+		if conn == nil || err == ErrAuthenticationFailed {
+			return nil, err
+		}
+		conn.Close(ctx)
+
+		wait := policy.delay(attempt)
+		if time.Now().Add(wait).After(deadline) {
+			policy.Logger.ErrorContext(ctx, "Giving up connecting to database", slog.Int("attempt", attempt), slog.Any("error", err))
+			return nil, err
+		}
+
+		policy.Logger.WarnContext(
+			ctx,
+			"Failed to connect to database",
+			slog.Int("attempt", attempt),
+			slog.Duration("retryIn", wait),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p BackoffPolicy) withDefaults() BackoffPolicy {
+	if p.InitialInterval == 0 {
+		p.InitialInterval = defaultBackoffInitialInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = defaultBackoffMaxInterval
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultBackoffMultiplier
+	}
+	if p.MaxWait == 0 {
+		p.MaxWait = defaultBackoffMaxWait
+	}
+	if p.Logger == nil {
+		p.Logger = slog.Default()
+	}
+	return p
+}
+
+// delay returns the wait before the next attempt. The interval grows
+// exponentially and is randomized by +/- 50% so that several instances
+// do not retry in lockstep.
+func (p BackoffPolicy) delay(attempt int) time.Duration {
+	interval := float64(p.InitialInterval)
+	for range attempt - 1 {
+		interval *= p.Multiplier
+		if interval >= float64(p.MaxInterval) {
+			interval = float64(p.MaxInterval)
+			break
+		}
+	}
+
+	jitter := 0.5 + rand.Float64()
+	return time.Duration(interval * jitter)
+}
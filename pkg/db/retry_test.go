@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db/postgresql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_BackoffPolicy_Delay(t *testing.T) {
+	policy := BackoffPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+	}.withDefaults()
+
+	type testCase struct {
+		attempt  int
+		interval time.Duration
+	}
+
+	testCases := []testCase{
+		{attempt: 1, interval: time.Second},
+		{attempt: 2, interval: 2 * time.Second},
+		{attempt: 3, interval: 4 * time.Second},
+		{attempt: 4, interval: 5 * time.Second},
+		{attempt: 50, interval: 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		actual := policy.delay(tc.attempt)
+
+		assert.GreaterOrEqual(t, actual, tc.interval/2, "Attempt %d", tc.attempt)
+		assert.LessOrEqual(t, actual, tc.interval*3/2, "Attempt %d", tc.attempt)
+	}
+}
+
+func TestUnit_NewWithRetry_WhenConfigurationIsInvalid_ExpectImmediateFailure(t *testing.T) {
+	config := postgresql.Config{
+		Host: ":/not-a-host",
+	}
+
+	start := time.Now()
+	conn, err := NewWithRetry(t.Context(), config, BackoffPolicy{})
+
+	assert.Nil(t, conn)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestUnit_NewWithRetry_WhenDatabaseIsUnreachable_ExpectFailureAfterMaxWait(t *testing.T) {
+	config := postgresql.NewConfigForLocalhost("db", "user", "password")
+	config.Port = 1
+	policy := BackoffPolicy{
+		InitialInterval: 10 * time.Millisecond,
+		MaxWait:         100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	conn, err := NewWithRetry(t.Context(), config, policy)
+
+	assert.Nil(t, conn)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestUnit_NewWithRetry_WhenContextIsCancelled_ExpectError(t *testing.T) {
+	config := postgresql.NewConfigForLocalhost("db", "user", "password")
+	config.Port = 1
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	conn, err := NewWithRetry(ctx, config, BackoffPolicy{})
+
+	assert.Nil(t, conn)
+	assert.Error(t, err)
+}
+
+func TestIT_NewWithRetry(t *testing.T) {
+	conn, err := NewWithRetry(t.Context(), dbTestConfig, BackoffPolicy{})
+	require.NoError(t, err, "Actual err: %v", err)
+	defer conn.Close(t.Context())
+
+	err = conn.Ping(t.Context())
+	assert.Nil(t, err)
+}
+
+func TestIT_NewWithRetry_WhenCredentialsAreInvalid_ExpectImmediateFailure(t *testing.T) {
+	config := dbTestConfig
+	config.Password = "not-the-right-password"
+
+	conn, err := NewWithRetry(t.Context(), config, BackoffPolicy{})
+
+	assert.Nil(t, conn)
+	assert.Equal(t, ErrAuthenticationFailed, err, "Actual err: %v", err)
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const defaultReplicaHealthCheckInterval = 5 * time.Second
+
+type RoutingStrategy int
+
+const (
+	// RoundRobin sends the reads to each replica in turn.
+	RoundRobin RoutingStrategy = iota
+	// LeastLoaded sends the reads to the replica with the fewest
+	// connections in use.
+	LeastLoaded
+)
+
+type RoutedConfig struct {
+	Strategy RoutingStrategy
+	// HealthCheckInterval is the interval at which the replicas are pinged.
+	// Defaults to 5s.
+	HealthCheckInterval time.Duration
+	// Logger receives a log when a replica changes state. Defaults to
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+// RoutedConnection sends the read-only queries to the replicas and all the
+// other operations (writes, transactions, bulk insertions) to the primary.
+// The replicas are periodically pinged: the unhealthy ones are skipped
+// until they recover and the reads fall back to the primary when none is
+// available.
+//
+// A query is considered read-only when it starts with SELECT and does not
+// lock rows (FOR UPDATE, FOR SHARE, ...). Selects calling functions with
+// side effects and queries which must see the result of a previous write
+// should be run in a transaction.
+type RoutedConnection struct {
+	primary  Connection
+	replicas []*replica
+	strategy RoutingStrategy
+	next     atomic.Uint64
+	log      *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type replica struct {
+	conn    Connection
+	healthy atomic.Bool
+}
+
+var (
+	_ Connection = (*RoutedConnection)(nil)
+	_ Copier     = (*RoutedConnection)(nil)
+	_ Locker     = (*RoutedConnection)(nil)
+)
+
+// NewRouted creates a connection routing the queries between the primary
+// and the replicas. It takes ownership of the connections: closing it
+// closes all of them.
+func NewRouted(primary Connection, replicas []Connection, config RoutedConfig) *RoutedConnection {
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = defaultReplicaHealthCheckInterval
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rc := &RoutedConnection{
+		primary:  primary,
+		strategy: config.Strategy,
+		log:      config.Logger,
+		cancel:   cancel,
+	}
+	for _, conn := range replicas {
+		r := &replica{conn: conn}
+		r.healthy.Store(true)
+		rc.replicas = append(rc.replicas, r)
+	}
+
+	rc.wg.Go(func() {
+		rc.monitorReplicas(ctx, config.HealthCheckInterval)
+	})
+
+	return rc
+}
+
+func (rc *RoutedConnection) Close(ctx context.Context) {
+	rc.cancel()
+	rc.wg.Wait()
+
+	rc.primary.Close(ctx)
+	for _, r := range rc.replicas {
+		r.conn.Close(ctx)
+	}
+}
+
+func (rc *RoutedConnection) Ping(ctx context.Context) error {
+	return rc.primary.Ping(ctx)
+}
+
+func (rc *RoutedConnection) BeginTx(ctx context.Context) (Transaction, error) {
+	return rc.primary.BeginTx(ctx)
+}
+
+func (rc *RoutedConnection) Exec(ctx context.Context, sql string, arguments ...any) (int64, error) {
+	return rc.primary.Exec(ctx, sql, arguments...)
+}
+
+// Stats returns the statistics of the pool of the primary.
+func (rc *RoutedConnection) Stats() PoolStats {
+	return rc.primary.Stats()
+}
+
+// ReplicaStats returns the statistics of the pools of the replicas, in the
+// order they were provided.
+func (rc *RoutedConnection) ReplicaStats() []PoolStats {
+	out := make([]PoolStats, 0, len(rc.replicas))
+	for _, r := range rc.replicas {
+		out = append(out, r.conn.Stats())
+	}
+	return out
+}
+
+func (rc *RoutedConnection) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+	conn := rc.primary
+	if isReadOnlyQuery(sql) {
+		conn = rc.pickReplica()
+	}
+
+	querier, ok := conn.(Querier)
+	if !ok {
+		return nil, ErrUnsupportedOperation
+	}
+	return querier.Query(ctx, sql, arguments...)
+}
+
+func (rc *RoutedConnection) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	c, ok := rc.primary.(Copier)
+	if !ok {
+		return 0, ErrUnsupportedOperation
+	}
+	return c.CopyFrom(ctx, table, columns, source)
+}
+
+func (rc *RoutedConnection) AcquireLock(ctx context.Context, key int64) (Lock, error) {
+	return AcquireLock(ctx, rc.primary, key)
+}
+
+func (rc *RoutedConnection) TryAcquireLock(ctx context.Context, key int64) (Lock, bool, error) {
+	return TryAcquireLock(ctx, rc.primary, key)
+}
+
+// pickReplica returns the connection to use for a read: one of the healthy
+// replicas or the primary if there is none.
+func (rc *RoutedConnection) pickReplica() Connection {
+	var healthy []*replica
+	for _, r := range rc.replicas {
+		if r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+	if len(healthy) == 0 {
+		return rc.primary
+	}
+
+	switch rc.strategy {
+	case LeastLoaded:
+		best := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.conn.Stats().AcquiredConns < best.conn.Stats().AcquiredConns {
+				best = r
+			}
+		}
+		return best.conn
+	default:
+		index := (rc.next.Add(1) - 1) % uint64(len(healthy))
+		return healthy[index].conn
+	}
+}
+
+func (rc *RoutedConnection) monitorReplicas(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rc.checkReplicas(ctx, interval)
+		}
+	}
+}
+
+func (rc *RoutedConnection) checkReplicas(ctx context.Context, timeout time.Duration) {
+	for index, r := range rc.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := r.conn.Ping(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if r.healthy.Swap(healthy) == healthy {
+			continue
+		}
+
+		if healthy {
+			rc.log.Info("Replica recovered", slog.Int("replica", index))
+		} else {
+			rc.log.Warn("Replica is unhealthy", slog.Int("replica", index), slog.Any("error", err))
+		}
+	}
+}
+
+var lockingClauses = []string{"for update", "for no key update", "for share", "for key share"}
+
+func isReadOnlyQuery(sql string) bool {
+	normalized := strings.ToLower(strings.Join(strings.Fields(sql), " "))
+	if !strings.HasPrefix(normalized, "select ") {
+		return false
+	}
+
+	for _, clause := range lockingClauses {
+		if strings.Contains(normalized, clause) {
+			return false
+		}
+	}
+	return true
+}
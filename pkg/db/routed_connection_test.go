@@ -0,0 +1,113 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_IsReadOnlyQuery(t *testing.T) {
+	testCases := map[string]bool{
+		"SELECT * FROM my_table":                       true,
+		"  select id\n\tFROM my_table WHERE id = $1":   true,
+		"SELECT * FROM my_table FOR UPDATE":            false,
+		"SELECT * FROM my_table FOR  NO KEY\nUPDATE":   false,
+		"SELECT * FROM my_table FOR SHARE SKIP LOCKED": false,
+		"INSERT INTO my_table (id) VALUES ($1)":        false,
+		"WITH t AS (DELETE FROM my_table) SELECT 1":    false,
+		"UPDATE my_table SET name = 'select'":          false,
+	}
+
+	for sql, expected := range testCases {
+		t.Run(sql, func(t *testing.T) {
+			assert.Equal(t, expected, isReadOnlyQuery(sql))
+		})
+	}
+}
+
+func TestUnit_RoutedConnection_RoundRobin(t *testing.T) {
+	primary, replicas := newRoutedTestConnections(2)
+	rc := NewRouted(primary, replicas, RoutedConfig{HealthCheckInterval: time.Hour})
+	defer rc.Close(t.Context())
+
+	assert.Same(t, replicas[0], rc.pickReplica())
+	assert.Same(t, replicas[1], rc.pickReplica())
+	assert.Same(t, replicas[0], rc.pickReplica())
+}
+
+func TestUnit_RoutedConnection_WhenReplicasAreUnhealthy_ExpectFallbackToPrimary(t *testing.T) {
+	primary, replicas := newRoutedTestConnections(2)
+	rc := NewRouted(primary, replicas, RoutedConfig{HealthCheckInterval: time.Hour})
+	defer rc.Close(t.Context())
+
+	// The test connections are not connected: pinging them fails.
+	rc.checkReplicas(t.Context(), time.Second)
+
+	assert.False(t, rc.replicas[0].healthy.Load())
+	assert.False(t, rc.replicas[1].healthy.Load())
+	assert.Same(t, primary, rc.pickReplica())
+}
+
+func TestUnit_RoutedConnection_SkipsUnhealthyReplicas(t *testing.T) {
+	primary, replicas := newRoutedTestConnections(3)
+	rc := NewRouted(primary, replicas, RoutedConfig{HealthCheckInterval: time.Hour})
+	defer rc.Close(t.Context())
+
+	rc.replicas[1].healthy.Store(false)
+
+	for range 4 {
+		assert.NotSame(t, replicas[1], rc.pickReplica())
+	}
+}
+
+func TestUnit_RoutedConnection_LeastLoaded(t *testing.T) {
+	primary, replicas := newRoutedTestConnections(2)
+	rc := NewRouted(primary, replicas, RoutedConfig{
+		Strategy:            LeastLoaded,
+		HealthCheckInterval: time.Hour,
+	})
+	defer rc.Close(t.Context())
+
+	// All the pools are empty: the first replica is picked.
+	assert.Same(t, replicas[0], rc.pickReplica())
+	assert.Same(t, replicas[0], rc.pickReplica())
+}
+
+func TestUnit_RoutedConnection_WritesGoToPrimary(t *testing.T) {
+	primary, replicas := newRoutedTestConnections(1)
+	rc := NewRouted(primary, replicas, RoutedConfig{HealthCheckInterval: time.Hour})
+	defer rc.Close(t.Context())
+
+	_, err := rc.BeginTx(t.Context())
+	assert.Equal(t, ErrNotConnected, err, "Actual err: %v", err)
+	_, err = rc.Exec(t.Context(), "DELETE FROM my_table")
+	assert.Equal(t, ErrNotConnected, err, "Actual err: %v", err)
+}
+
+func TestIT_RoutedConnection(t *testing.T) {
+	primary := newTestConnection(t)
+	replica, err := New(t.Context(), dbTestConfig)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	rc := NewRouted(primary, []Connection{replica}, RoutedConfig{HealthCheckInterval: 10 * time.Millisecond})
+	defer rc.Close(t.Context())
+
+	v := insertTestData(t, rc)
+
+	actual, err := QueryOne[string](t.Context(), rc, "SELECT name FROM my_table WHERE id = $1", v.Id)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, v.Name, actual)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, rc.replicas[0].healthy.Load())
+}
+
+func newRoutedTestConnections(replicaCount int) (Connection, []Connection) {
+	var replicas []Connection
+	for range replicaCount {
+		replicas = append(replicas, &connectionImpl{})
+	}
+	return &connectionImpl{}, replicas
+}
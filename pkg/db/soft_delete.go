@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// NotDeleted is the condition to use in hand-written queries to exclude
+// the soft deleted rows.
+const NotDeleted = "deleted_at IS NULL"
+
+// Executor is implemented by both Connection and Transaction.
+type Executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (int64, error)
+}
+
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	withDeleted bool
+}
+
+// WithDeleted includes the soft deleted rows in the results.
+func WithDeleted() ListOption {
+	return func(o *listOptions) {
+		o.withDeleted = true
+	}
+}
+
+// The helpers below operate on tables with an id primary key and a nullable
+// deleted_at timestamp. The table name is inserted as is in the queries:
+// it should never come from user input. The T type should map all the
+// columns of the table as expected by QueryAll.
+
+func ListAll[T any](ctx context.Context, conn Connection, table string, opts ...ListOption) ([]T, error) {
+	return QueryAll[T](ctx, conn, listSql(table, opts))
+}
+
+func ListAllTx[T any](ctx context.Context, tx Transaction, table string, opts ...ListOption) ([]T, error) {
+	return QueryAllTx[T](ctx, tx, listSql(table, opts))
+}
+
+func GetById[T any](ctx context.Context, conn Connection, table string, id any, opts ...ListOption) (T, error) {
+	return QueryOne[T](ctx, conn, getByIdSql(table, opts), id)
+}
+
+func GetByIdTx[T any](ctx context.Context, tx Transaction, table string, id any, opts ...ListOption) (T, error) {
+	return QueryOneTx[T](ctx, tx, getByIdSql(table, opts), id)
+}
+
+// SoftDelete marks the row as deleted. It returns ErrNoMatchingRows if
+// the row does not exist or is already deleted.
+func SoftDelete(ctx context.Context, exec Executor, table string, id any) error {
+	sql := fmt.Sprintf("UPDATE %s SET deleted_at = now() WHERE id = $1 AND %s", table, NotDeleted)
+	return execOnSingleRow(ctx, exec, sql, id)
+}
+
+// Restore reverts a soft deletion. It returns ErrNoMatchingRows if the
+// row does not exist or is not deleted.
+func Restore(ctx context.Context, exec Executor, table string, id any) error {
+	sql := fmt.Sprintf("UPDATE %s SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", table)
+	return execOnSingleRow(ctx, exec, sql, id)
+}
+
+func listSql(table string, opts []ListOption) string {
+	sql := fmt.Sprintf("SELECT * FROM %s", table)
+	if !applyListOptions(opts).withDeleted {
+		sql += " WHERE " + NotDeleted
+	}
+
+	return sql
+}
+
+func getByIdSql(table string, opts []ListOption) string {
+	sql := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", table)
+	if !applyListOptions(opts).withDeleted {
+		sql += " AND " + NotDeleted
+	}
+
+	return sql
+}
+
+func applyListOptions(opts []ListOption) listOptions {
+	var out listOptions
+	for _, opt := range opts {
+		opt(&out)
+	}
+
+	return out
+}
+
+func execOnSingleRow(ctx context.Context, exec Executor, sql string, arguments ...any) error {
+	affected, err := exec.Exec(ctx, sql, arguments...)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoMatchingRows
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type softDeletedElement struct {
+	Id        uuid.UUID
+	Name      string
+	DeletedAt *time.Time
+}
+
+const softDeleteTable = "soft_delete_table"
+
+func TestUnit_SoftDelete_ListSql(t *testing.T) {
+	actual := listSql(softDeleteTable, nil)
+	assert.Equal(t, "SELECT * FROM soft_delete_table WHERE deleted_at IS NULL", actual)
+
+	actual = listSql(softDeleteTable, []ListOption{WithDeleted()})
+	assert.Equal(t, "SELECT * FROM soft_delete_table", actual)
+}
+
+func TestUnit_SoftDelete_GetByIdSql(t *testing.T) {
+	actual := getByIdSql(softDeleteTable, nil)
+	assert.Equal(t, "SELECT * FROM soft_delete_table WHERE id = $1 AND deleted_at IS NULL", actual)
+
+	actual = getByIdSql(softDeleteTable, []ListOption{WithDeleted()})
+	assert.Equal(t, "SELECT * FROM soft_delete_table WHERE id = $1", actual)
+}
+
+func TestIT_SoftDelete(t *testing.T) {
+	t.Run("hides deleted row from reads", func(t *testing.T) {
+		conn := newTestConnection(t)
+		id := insertSoftDeletedTestData(t, conn)
+
+		err := SoftDelete(t.Context(), conn, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		_, err = GetById[softDeletedElement](t.Context(), conn, softDeleteTable, id)
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+
+		actual, err := GetById[softDeletedElement](t.Context(), conn, softDeleteTable, id, WithDeleted())
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.NotNil(t, actual.DeletedAt)
+
+		all, err := ListAll[softDeletedElement](t.Context(), conn, softDeleteTable)
+		require.NoError(t, err, "Actual err: %v", err)
+		for _, e := range all {
+			assert.NotEqual(t, id, e.Id)
+		}
+	})
+
+	t.Run("returns error when row is already deleted", func(t *testing.T) {
+		conn := newTestConnection(t)
+		id := insertSoftDeletedTestData(t, conn)
+
+		err := SoftDelete(t.Context(), conn, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		err = SoftDelete(t.Context(), conn, softDeleteTable, id)
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+	})
+
+	t.Run("works in a transaction", func(t *testing.T) {
+		conn, tx := newTestTransaction(t)
+		id := insertSoftDeletedTestData(t, conn)
+
+		err := SoftDelete(t.Context(), tx, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		_, err = GetByIdTx[softDeletedElement](t.Context(), tx, softDeleteTable, id)
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+	})
+}
+
+func TestIT_Restore(t *testing.T) {
+	t.Run("makes row visible again", func(t *testing.T) {
+		conn := newTestConnection(t)
+		id := insertSoftDeletedTestData(t, conn)
+
+		err := SoftDelete(t.Context(), conn, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+		err = Restore(t.Context(), conn, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		actual, err := GetById[softDeletedElement](t.Context(), conn, softDeleteTable, id)
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Nil(t, actual.DeletedAt)
+	})
+
+	t.Run("returns error when row is not deleted", func(t *testing.T) {
+		conn := newTestConnection(t)
+		id := insertSoftDeletedTestData(t, conn)
+
+		err := Restore(t.Context(), conn, softDeleteTable, id)
+		assert.ErrorIs(t, err, ErrNoMatchingRows, "Actual err: %v", err)
+	})
+}
+
+func insertSoftDeletedTestData(t *testing.T, conn Connection) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	_, err := conn.Exec(t.Context(), "INSERT INTO soft_delete_table (id, name) VALUES ($1, $2)", id, uuid.NewString())
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return id
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -14,10 +15,23 @@ type Transaction interface {
 
 	Exec(ctx context.Context, sql string, arguments ...any) (int64, error)
 
+	// BeginTx starts a nested transaction backed by a savepoint. Closing
+	// it releases the savepoint or, if it failed, rolls back to it: only
+	// the changes of the nested transaction are lost and the parent one
+	// can continue. The nested transaction shares the time stamp of its
+	// parent.
+	BeginTx(ctx context.Context) (Transaction, error)
+
 	// Rollback allows to mark the transaction for rollback, independently of any
 	// error which might or might not have occurred during the execution.
 	// This function can only be called if the transaction was not already committed.
 	Rollback() error
+
+	// Commit closes the transaction like Close but returns the error of the
+	// final COMMIT, or of the ROLLBACK if the transaction failed or was
+	// marked for rollback. It allows to detect errors only raised when the
+	// transaction is committed, such as serialization failures.
+	Commit(ctx context.Context) error
 }
 
 type transactionImpl struct {
@@ -27,26 +41,31 @@ type transactionImpl struct {
 }
 
 func (ti *transactionImpl) Close(ctx context.Context) {
+	// The transaction interface does not return an error on Close: callers
+	// interested in the outcome of the transaction should use Commit.
+	// nolint: errcheck
+	ti.Commit(ctx)
+}
+
+func (ti *transactionImpl) Commit(ctx context.Context) error {
 	if ti.tx == nil {
-		return
+		return ErrAlreadyCommitted
 	}
 
+	tx := ti.tx
+	ti.tx = nil
+
+	var err error
 	if ti.err != nil {
-		// The transaction interface does not return an error on Close. This means it
-		// it meaningless to check this error because it is not possible to propagate
-		// it back to the caller. This is an acceptable design choice: the transaction
-		// already failed and rolling it back is the best effort strategy. If this
-		// fails too, there's not much which can be done.
-		// nolint: errcheck
-		ti.tx.Rollback(ctx)
+		err = tx.Rollback(ctx)
 	} else {
-		// See reasoning above: as nothing is returned from this function it is not
-		// worth it to check the error here
-		// nolint: errcheck
-		ti.tx.Commit(ctx)
+		err = tx.Commit(ctx)
 	}
 
-	ti.tx = nil
+	if err != nil {
+		return analyzeAndWrapDatabaseError(err)
+	}
+	return nil
 }
 
 func (ti *transactionImpl) TimeStamp() time.Time {
@@ -58,6 +77,8 @@ func (ti *transactionImpl) Exec(ctx context.Context, sql string, arguments ...an
 		return int64(0), ErrAlreadyCommitted
 	}
 
+	defer timing.Track(ctx, timing.SegmentDb)()
+
 	tag, err := ti.tx.Exec(ctx, sql, arguments...)
 	ti.updateErrorStatus(err)
 
@@ -68,6 +89,26 @@ func (ti *transactionImpl) Exec(ctx context.Context, sql string, arguments ...an
 	return tag.RowsAffected(), err
 }
 
+func (ti *transactionImpl) BeginTx(ctx context.Context) (Transaction, error) {
+	if ti.tx == nil {
+		return nil, ErrAlreadyCommitted
+	}
+
+	// pgx creates a savepoint when beginning a transaction from another.
+	pgxTx, err := ti.tx.Begin(ctx)
+	if err != nil {
+		ti.updateErrorStatus(err)
+		return nil, analyzeAndWrapDatabaseError(err)
+	}
+
+	tx := &transactionImpl{
+		timeStamp: ti.timeStamp,
+		tx:        pgxTx,
+	}
+
+	return tx, nil
+}
+
 func (ti *transactionImpl) Rollback() error {
 	if ti.tx == nil {
 		return ErrAlreadyCommitted
@@ -78,7 +119,7 @@ func (ti *transactionImpl) Rollback() error {
 	return nil
 }
 
-func (ti *transactionImpl) query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+func (ti *transactionImpl) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
 	if ti.tx == nil {
 		return nil, ErrAlreadyCommitted
 	}
@@ -89,8 +130,58 @@ func (ti *transactionImpl) query(ctx context.Context, sql string, arguments ...a
 	return rows, err
 }
 
+func (ti *transactionImpl) CopyFrom(
+	ctx context.Context, table pgx.Identifier, columns []string, source pgx.CopyFromSource,
+) (int64, error) {
+	if ti.tx == nil {
+		return 0, ErrAlreadyCommitted
+	}
+
+	count, err := ti.tx.CopyFrom(ctx, table, columns, source)
+	ti.updateErrorStatus(err)
+
+	return count, analyzeAndWrapDatabaseError(err)
+}
+
 func (t *transactionImpl) updateErrorStatus(err error) {
 	if err != nil {
 		t.err = err
 	}
 }
+
+// TransactionStarter is implemented by both Connection and Transaction so
+// that WithTransaction can be nested.
+type TransactionStarter interface {
+	BeginTx(ctx context.Context) (Transaction, error)
+}
+
+// WithTransaction runs the function in a transaction which is committed if
+// it succeeds and rolled back otherwise. The error of the commit is
+// returned. When the starter is a transaction a savepoint is used, allowing
+// to compose functions which each want to be transactional:
+//
+//	err := db.WithTransaction(ctx, conn, func(tx db.Transaction) error {
+//		// Only the changes made by this call are rolled back on failure.
+//		_ = db.WithTransaction(ctx, tx, func(nested db.Transaction) error {
+//			...
+//		})
+//		...
+//	})
+func WithTransaction(ctx context.Context, starter TransactionStarter, fn func(tx Transaction) error) error {
+	tx, err := starter.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	// Only effective if the function panics or fails.
+	defer tx.Close(ctx)
+
+	if err := fn(tx); err != nil {
+		// The transaction can't be committed already: the error can't
+		// happen.
+		// nolint: errcheck
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
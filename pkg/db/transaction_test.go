@@ -3,6 +3,7 @@ package db
 import (
 	"testing"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,17 @@ func TestIT_Transaction_Close(t *testing.T) {
 	})
 }
 
+func TestIT_Transaction_Commit(t *testing.T) {
+	t.Run("returns error when already committed", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+		require.NoError(t, tx.Commit(t.Context()))
+
+		err := tx.Commit(t.Context())
+
+		assert.ErrorIs(t, err, ErrAlreadyCommitted, "Actual err: %v", err)
+	})
+}
+
 func TestIT_Transaction_Exec(t *testing.T) {
 	t.Run("successfully selects data", func(t *testing.T) {
 		_, tx := newTestTransaction(t)
@@ -149,3 +161,118 @@ func TestIT_Transaction_Rollback(t *testing.T) {
 		assertIdDoesNotExist(t, conn, id)
 	})
 }
+
+func TestIT_Transaction_BeginTx(t *testing.T) {
+	t.Run("returns error when transaction already committed", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+		tx.Close(t.Context())
+
+		_, err := tx.BeginTx(t.Context())
+
+		assert.ErrorIs(t, ErrAlreadyCommitted, err, "Actual err: %v", err)
+	})
+
+	t.Run("nested transaction shares time stamp of parent", func(t *testing.T) {
+		_, tx := newTestTransaction(t)
+
+		nested, err := tx.BeginTx(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+		defer nested.Close(t.Context())
+
+		assert.Equal(t, tx.TimeStamp(), nested.TimeStamp())
+	})
+
+	t.Run("commits nested transaction with parent", func(t *testing.T) {
+		conn, tx := newTestTransaction(t)
+
+		nested, err := tx.BeginTx(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+		element := insertTestDataTx(t, nested)
+		nested.Close(t.Context())
+
+		tx.Close(t.Context())
+
+		assertNameForId(t, conn, element.Id, element.Name)
+	})
+
+	t.Run("rolls back only nested transaction", func(t *testing.T) {
+		conn, tx := newTestTransaction(t)
+		outer := insertTestDataTx(t, tx)
+
+		nested, err := tx.BeginTx(t.Context())
+		require.NoError(t, err, "Actual err: %v", err)
+		inner := insertTestDataTx(t, nested)
+		_, err = nested.Exec(t.Context(), "INSERT INTO my_table VALUES ($1, $2)", inner.Id, inner.Name)
+		require.Error(t, err)
+		nested.Close(t.Context())
+
+		tx.Close(t.Context())
+
+		assertNameForId(t, conn, outer.Id, outer.Name)
+		assertIdDoesNotExist(t, conn, inner.Id)
+	})
+}
+
+func TestIT_WithTransaction(t *testing.T) {
+	t.Run("commits when function succeeds", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		var element element
+		err := WithTransaction(t.Context(), conn, func(tx Transaction) error {
+			element = insertTestDataTx(t, tx)
+			return nil
+		})
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertNameForId(t, conn, element.Id, element.Name)
+	})
+
+	t.Run("rolls back when function fails", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		var element element
+		err := WithTransaction(t.Context(), conn, func(tx Transaction) error {
+			element = insertTestDataTx(t, tx)
+			return errSomeError
+		})
+		assert.ErrorIs(t, err, errSomeError)
+
+		assertIdDoesNotExist(t, conn, element.Id)
+	})
+
+	t.Run("rolls back only nested scope", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		var outer, inner element
+		err := WithTransaction(t.Context(), conn, func(tx Transaction) error {
+			outer = insertTestDataTx(t, tx)
+
+			err := WithTransaction(t.Context(), tx, func(nested Transaction) error {
+				inner = insertTestDataTx(t, nested)
+				return errSomeError
+			})
+			assert.ErrorIs(t, err, errSomeError)
+
+			return nil
+		})
+		require.NoError(t, err, "Actual err: %v", err)
+
+		assertNameForId(t, conn, outer.Id, outer.Name)
+		assertIdDoesNotExist(t, conn, inner.Id)
+	})
+
+	t.Run("returns error when commit fails", func(t *testing.T) {
+		conn := newTestConnection(t)
+
+		err := WithTransaction(t.Context(), conn, func(tx Transaction) error {
+			// A deferred constraint is only checked at COMMIT.
+			_, err := tx.Exec(t.Context(), "CREATE TEMPORARY TABLE deferred_table (id INTEGER UNIQUE DEFERRABLE INITIALLY DEFERRED)")
+			require.NoError(t, err, "Actual err: %v", err)
+			_, err = tx.Exec(t.Context(), "INSERT INTO deferred_table VALUES (1), (1)")
+			require.NoError(t, err, "Actual err: %v", err)
+			return nil
+		})
+
+		assert.True(t, errors.IsErrorWithCode(err, ErrUniqueConstraintViolation), "Actual err: %v", err)
+	})
+}
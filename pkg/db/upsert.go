@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Upsert inserts the value in the table or updates the row conflicting with
+// it on the provided columns. The columns are mapped from the fields of the
+// struct like for the Repository. The returned boolean is true when a row
+// was inserted and false when an existing row was updated.
+//
+// The conflict columns must be covered by a unique constraint or index.
+func Upsert[T any](
+	ctx context.Context,
+	exec Executor,
+	table string,
+	value T,
+	conflict ...string,
+) (bool, error) {
+	if !isRowStruct[T]() || len(conflict) == 0 {
+		return false, ErrInvalidUpsert
+	}
+
+	m := newColumnMapping(reflect.TypeFor[T]())
+	for _, column := range conflict {
+		if !slices.Contains(m.columns, column) {
+			return false, ErrInvalidUpsert
+		}
+	}
+
+	// The system column xmax is only set for rows which were updated.
+	sql := upsertSql(table, m.columns, conflict) + " RETURNING (xmax = 0) AS inserted"
+	return queryOne[bool](ctx, exec, sql, m.values(value, m.columns)...)
+}
+
+func upsertSql(table string, columns []string, conflict []string) string {
+	var updated []string
+	for _, column := range columns {
+		if !slices.Contains(conflict, column) {
+			updated = append(updated, column)
+		}
+	}
+	// When all the columns are part of the conflict target, a no-op update
+	// is still needed for the existing row to be returned.
+	if len(updated) == 0 {
+		updated = conflict[:1]
+	}
+
+	var assignments []string
+	for _, column := range updated {
+		assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		table,
+		strings.Join(columns, ", "),
+		placeholders(1, len(columns)),
+		strings.Join(conflict, ", "),
+		strings.Join(assignments, ", "),
+	)
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type upsertElement struct {
+	Id   uuid.UUID `db:"id"`
+	Name string
+}
+
+func TestUnit_UpsertSql(t *testing.T) {
+	type testCase struct {
+		columns  []string
+		conflict []string
+		expected string
+	}
+
+	testCases := map[string]testCase{
+		"single conflict column": {
+			columns:  []string{"id", "name"},
+			conflict: []string{"id"},
+			expected: "INSERT INTO t (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name",
+		},
+		"multiple conflict columns": {
+			columns:  []string{"a", "b", "c"},
+			conflict: []string{"a", "b"},
+			expected: "INSERT INTO t (a, b, c) VALUES ($1, $2, $3) ON CONFLICT (a, b) DO UPDATE SET c = EXCLUDED.c",
+		},
+		"all columns in conflict": {
+			columns:  []string{"id"},
+			conflict: []string{"id"},
+			expected: "INSERT INTO t (id) VALUES ($1) ON CONFLICT (id) DO UPDATE SET id = EXCLUDED.id",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, upsertSql("t", tc.columns, tc.conflict))
+		})
+	}
+}
+
+func TestUnit_Upsert_WhenArgumentsAreInvalid_ExpectError(t *testing.T) {
+	_, err := Upsert(t.Context(), nil, "my_table", 2)
+	assert.Equal(t, ErrInvalidUpsert, err, "Actual err: %v", err)
+
+	_, err = Upsert(t.Context(), nil, "my_table", upsertElement{})
+	assert.Equal(t, ErrInvalidUpsert, err, "Actual err: %v", err)
+
+	_, err = Upsert(t.Context(), nil, "my_table", upsertElement{}, "not_a_column")
+	assert.Equal(t, ErrInvalidUpsert, err, "Actual err: %v", err)
+}
+
+func TestIT_Upsert(t *testing.T) {
+	conn := newTestConnection(t)
+	v := upsertElement{Id: uuid.New(), Name: uuid.NewString()}
+
+	inserted, err := Upsert(t.Context(), conn, "my_table", v, "id")
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, inserted)
+	assertNameForId(t, conn, v.Id, v.Name)
+
+	v.Name = uuid.NewString()
+	inserted, err = Upsert(t.Context(), conn, "my_table", v, "id")
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.False(t, inserted)
+	assertNameForId(t, conn, v.Id, v.Name)
+}
+
+func TestIT_Upsert_WithinTransaction(t *testing.T) {
+	conn, tx := newTestTransaction(t)
+	v := upsertElement{Id: uuid.New(), Name: uuid.NewString()}
+
+	inserted, err := Upsert(t.Context(), tx, "my_table", v, "id")
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, inserted)
+	tx.Close(t.Context())
+
+	assertNameForId(t, conn, v.Id, v.Name)
+}
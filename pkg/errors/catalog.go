@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+const defaultErrorMessage = "an unexpected error occurred"
+
+// CodeInfo describes an error code so that its meaning is not scattered
+// across the packages using it.
+type CodeInfo struct {
+	Code ErrorCode
+	// Name identifies the code in a readable way, e.g. "db.no_matching_rows".
+	Name string
+	// Message is the message of the errors created with FromCode. It
+	// defaults to a generic message.
+	Message string
+	// HttpStatus is the status of the responses failing with this code.
+	// It defaults to 500.
+	HttpStatus int
+	// GrpcCode is the status of the gRPC calls failing with this code. It
+	// defaults to the code corresponding to the HTTP status.
+	GrpcCode GrpcCode
+}
+
+var (
+	catalogLock sync.RWMutex
+	catalog     = map[ErrorCode]CodeInfo{
+		GenericErrorCode: {
+			Code: GenericErrorCode,
+			Name: "generic",
+		},
+		errNotImplemented: {
+			Code:    errNotImplemented,
+			Name:    "not_implemented",
+			Message: "not implemented",
+		},
+	}
+)
+
+// Register adds the code to the catalog. It is meant to be called when
+// the package defining the code is initialized, before creating errors
+// with it, and panics if the code is already registered or reserved for
+// another namespace (see ReserveRange):
+//
+//	var errInvalidName = errors.Register(errors.CodeInfo{
+//		Code: 1000, Name: "users.invalid_name", Message: "invalid name", HttpStatus: http.StatusBadRequest,
+//	})
+//	var ErrInvalidName = errors.FromCode(errInvalidName)
+func Register(info CodeInfo) ErrorCode {
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+
+	if existing, ok := catalog[info.Code]; ok {
+		panic(fmt.Sprintf("error code %d is already registered as %q", info.Code, existing.Name))
+	}
+	if err := checkCodeRange(info); err != nil {
+		panic(err.Error())
+	}
+	catalog[info.Code] = info
+
+	return info.Code
+}
+
+func Lookup(code ErrorCode) (CodeInfo, bool) {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+
+	info, ok := catalog[code]
+	return info, ok
+}
+
+// Codes returns the registered codes ordered by value, e.g. to document
+// them.
+func Codes() []CodeInfo {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+
+	out := make([]CodeInfo, 0, len(catalog))
+	for _, code := range slices.Sorted(maps.Keys(catalog)) {
+		out = append(out, catalog[code])
+	}
+	return out
+}
+
+// HttpStatusOf returns the HTTP status registered for the code or 500.
+func HttpStatusOf(code ErrorCode) int {
+	if info, ok := Lookup(code); ok && info.HttpStatus != 0 {
+		return info.HttpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// GrpcCodeOf returns the gRPC code registered for the code or the one
+// corresponding to its HTTP status.
+func GrpcCodeOf(code ErrorCode) GrpcCode {
+	if info, ok := Lookup(code); ok && info.GrpcCode != GrpcOk {
+		return info.GrpcCode
+	}
+	return GrpcCodeFromHttpStatus(HttpStatusOf(code))
+}
+
+func determineCommonErrorMessage(code ErrorCode) string {
+	if info, ok := Lookup(code); ok && info.Message != "" {
+		return info.Message
+	}
+	return defaultErrorMessage
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const catalogTestCode = ErrorCode(9000)
+
+func registerTestCode(t *testing.T, info CodeInfo) {
+	Register(info)
+	t.Cleanup(func() {
+		catalogLock.Lock()
+		defer catalogLock.Unlock()
+		delete(catalog, info.Code)
+	})
+}
+
+func TestUnit_Register_ExpectCodeToBeFound(t *testing.T) {
+	info := CodeInfo{Code: catalogTestCode, Name: "test.code", Message: "some message", HttpStatus: http.StatusConflict}
+	registerTestCode(t, info)
+
+	actual, ok := Lookup(catalogTestCode)
+
+	assert.True(t, ok)
+	assert.Equal(t, info, actual)
+}
+
+func TestUnit_Register_WhenCodeIsAlreadyRegistered_ExpectPanic(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code"})
+
+	assert.PanicsWithValue(t, `error code 9000 is already registered as "test.code"`, func() {
+		Register(CodeInfo{Code: catalogTestCode, Name: "test.other"})
+	})
+}
+
+func TestUnit_Lookup_WhenCodeIsNotRegistered_ExpectFalse(t *testing.T) {
+	_, ok := Lookup(catalogTestCode)
+
+	assert.False(t, ok)
+}
+
+func TestUnit_Codes_ExpectSortedByCode(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode + 1, Name: "test.second"})
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.first"})
+
+	actual := Codes()
+
+	var names []string
+	for _, info := range actual {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"generic", "not_implemented", "test.first", "test.second"}, names)
+}
+
+func TestUnit_FromCode_WhenCodeHasMessage_ExpectMessageToBeUsed(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code", Message: "some message"})
+
+	err := FromCode(catalogTestCode)
+
+	assert.Equal(t, "some message. Code: 9000", err.Error())
+}
+
+func TestUnit_FromCode_WhenCodeIsNotRegistered_ExpectDefaultMessage(t *testing.T) {
+	err := FromCode(catalogTestCode)
+
+	assert.Equal(t, "an unexpected error occurred. Code: 9000", err.Error())
+}
+
+func TestUnit_HttpStatusOf(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code", HttpStatus: http.StatusNotFound})
+	registerTestCode(t, CodeInfo{Code: catalogTestCode + 1, Name: "test.no_status"})
+
+	assert.Equal(t, http.StatusNotFound, HttpStatusOf(catalogTestCode))
+	assert.Equal(t, http.StatusInternalServerError, HttpStatusOf(catalogTestCode+1))
+	assert.Equal(t, http.StatusInternalServerError, HttpStatusOf(catalogTestCode+2))
+}
+
+func TestUnit_GrpcCodeOf(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code", HttpStatus: http.StatusNotFound, GrpcCode: GrpcAborted})
+	registerTestCode(t, CodeInfo{Code: catalogTestCode + 1, Name: "test.no_grpc", HttpStatus: http.StatusNotFound})
+
+	assert.Equal(t, GrpcAborted, GrpcCodeOf(catalogTestCode))
+	assert.Equal(t, GrpcNotFound, GrpcCodeOf(catalogTestCode+1))
+	assert.Equal(t, GrpcInternal, GrpcCodeOf(catalogTestCode+2))
+}
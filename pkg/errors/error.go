@@ -7,9 +7,15 @@ import (
 )
 
 type ErrorWithCode struct {
-	Code    ErrorCode
+	Code ErrorCode
+	// Message defaults to the message of the code in the catalog. It is
+	// resolved when the error is rendered so that the errors created before
+	// their code is registered (e.g. package level sentinels) still use it.
 	Message string
-	Cause   error
+	// PublicMessage is safe to be returned to the clients: contrary to the
+	// message and the cause it can't contain internal details.
+	PublicMessage string
+	Cause         error
 }
 
 func New(message string) error {
@@ -21,8 +27,7 @@ func New(message string) error {
 
 func FromCode(code ErrorCode) error {
 	return &ErrorWithCode{
-		Code:    code,
-		Message: determineCommonErrorMessage(code),
+		Code: code,
 	}
 }
 
@@ -50,9 +55,8 @@ func Wrap(cause error, message string) error {
 
 func WrapCode(cause error, code ErrorCode) error {
 	return &ErrorWithCode{
-		Code:    code,
-		Message: determineCommonErrorMessage(code),
-		Cause:   cause,
+		Code:  code,
+		Cause: cause,
 	}
 }
 
@@ -86,10 +90,74 @@ func AsErrorWithCode(err error) (*ErrorWithCode, bool) {
 	return nil, false
 }
 
+// codedError is implemented by the errors exposing a code, e.g. the
+// ErrorWithCode or db.DatabaseError.
+type codedError interface {
+	ErrorCode() ErrorCode
+}
+
+// CodeOf returns the code of the first error of the chain which has one.
+// Besides ErrorWithCode, it detects the errors exposing their code with an
+// ErrorCode method such as db.DatabaseError.
+func CodeOf(err error) (ErrorCode, bool) {
+	var withCode codedError
+	if errors.As(err, &withCode) {
+		return withCode.ErrorCode(), true
+	}
+
+	return GenericErrorCode, false
+}
+
+// IsErrorWithCode returns true when the error or any of the errors it
+// wraps, including the ones joined with errors.Join, has the code. Besides
+// ErrorWithCode, it detects the errors exposing their code with an
+// ErrorCode method such as db.DatabaseError.
+func IsErrorWithCode(err error, code ErrorCode) bool {
+	if err == nil {
+		return false
+	}
+
+	if withCode, ok := err.(codedError); ok && withCode.ErrorCode() == code {
+		return true
+	}
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return IsErrorWithCode(wrapper.Unwrap(), code)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			if IsErrorWithCode(wrapped, code) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (e *ErrorWithCode) ErrorCode() ErrorCode {
+	return e.Code
+}
+
+func (e *ErrorWithCode) Unwrap() error {
+	return e.Cause
+}
+
+// Is allows errors.Is to match an error with the sentinel having the same
+// code, e.g. an error created with WrapCode. The generic code is shared by
+// unrelated errors so it is never matched.
+func (e *ErrorWithCode) Is(target error) bool {
+	other, ok := target.(*ErrorWithCode)
+	if !ok || other == nil {
+		return false
+	}
+	return e.Code != GenericErrorCode && e.Code == other.Code
+}
+
 func (e *ErrorWithCode) Error() string {
 	var out string
 
-	out += e.Message
+	out += e.message()
 	out += fmt.Sprintf(". Code: %d", e.Code)
 
 	if e.Cause != nil {
@@ -106,34 +174,39 @@ func (e *ErrorWithCode) MarshalJSON() ([]byte, error) {
 		Cause   json.RawMessage `json:"cause,omitempty"`
 	}{
 		Code:    e.Code,
-		Message: e.Message,
+		Message: e.message(),
 		Cause:   e.marshalCause(),
 	})
 }
 
+func (e *ErrorWithCode) message() string {
+	if e.Message == "" {
+		return determineCommonErrorMessage(e.Code)
+	}
+	return e.Message
+}
+
 func (e *ErrorWithCode) marshalCause() json.RawMessage {
 	if e.Cause == nil {
 		return nil
 	}
 
+	return marshalError(e.Cause)
+}
+
+func marshalError(err error) json.RawMessage {
 	var out []byte
 
 	// Voluntarily ignoring the marshalling errors as there's nothing we
 	// can do about it.
-	if impl, ok := e.Cause.(*ErrorWithCode); ok {
+	switch impl := err.(type) {
+	case *ErrorWithCode:
 		out, _ = json.Marshal(impl)
-	} else {
-		out, _ = json.Marshal(e.Cause.Error())
+	case *MultiError:
+		out, _ = json.Marshal(impl)
+	default:
+		out, _ = json.Marshal(err.Error())
 	}
 
 	return out
 }
-
-func determineCommonErrorMessage(code ErrorCode) string {
-	switch code {
-	case errNotImplemented:
-		return "not implemented"
-	default:
-		return "an unexpected error occurred"
-	}
-}
@@ -35,40 +35,31 @@ func TestUnit_Error_FromCode(t *testing.T) {
 		impl, ok := err.(*ErrorWithCode)
 		require.True(t, ok)
 
-		expected := &ErrorWithCode{
-			Code:    someCode,
-			Message: "an unexpected error occurred",
-			Cause:   nil,
-		}
-		assert.Equal(t, expected, impl)
+		assert.Equal(t, someCode, impl.Code)
+		assert.Nil(t, impl.Cause)
+		assert.Equal(t, "an unexpected error occurred. Code: 26", err.Error())
 	})
 
 	t.Run("correctly maps not implemented code", func(t *testing.T) {
 		err := FromCode(errNotImplemented)
 
-		impl, ok := err.(*ErrorWithCode)
-		require.True(t, ok)
-
-		expected := &ErrorWithCode{
-			Code:    errNotImplemented,
-			Message: "not implemented",
-			Cause:   nil,
-		}
-		assert.Equal(t, expected, impl)
+		assert.Equal(t, fmt.Sprintf("not implemented. Code: %d", errNotImplemented), err.Error())
 	})
 
 	t.Run("correctly maps generic code", func(t *testing.T) {
 		err := FromCode(GenericErrorCode)
 
-		impl, ok := err.(*ErrorWithCode)
-		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("an unexpected error occurred. Code: %d", GenericErrorCode), err.Error())
+	})
+
+	t.Run("uses message of code registered after the error was created", func(t *testing.T) {
+		err := FromCode(catalogTestCode)
+		registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code", Message: "some message"})
 
-		expected := &ErrorWithCode{
-			Code:    GenericErrorCode,
-			Message: "an unexpected error occurred",
-			Cause:   nil,
-		}
-		assert.Equal(t, expected, impl)
+		assert.Equal(t, "some message. Code: 9000", err.Error())
+		out, marshalErr := json.Marshal(err)
+		require.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"code":9000,"message":"some message"}`, string(out))
 	})
 }
 
@@ -120,12 +111,9 @@ func TestUnit_Error_WrapCode(t *testing.T) {
 	impl, ok := err.(*ErrorWithCode)
 	require.True(t, ok)
 
-	expected := &ErrorWithCode{
-		Code:    someCode,
-		Message: "an unexpected error occurred",
-		Cause:   errSomeError,
-	}
-	assert.Equal(t, expected, impl)
+	assert.Equal(t, someCode, impl.Code)
+	assert.Equal(t, errSomeError, impl.Cause)
+	assert.Equal(t, "an unexpected error occurred. Code: 26 (cause: some error)", err.Error())
 }
 
 func TestUnit_Error_Wrapf(t *testing.T) {
@@ -309,3 +297,113 @@ func TestUnit_Error_As(t *testing.T) {
 		assert.Equal(t, testErr, err)
 	})
 }
+
+func TestUnit_Error_UnwrapMethod(t *testing.T) {
+	t.Run("gives access to the cause", func(t *testing.T) {
+		err := Wrap(errSomeError, "wrapper")
+
+		assert.True(t, errors.Is(err, errSomeError))
+	})
+
+	t.Run("detects error with the same code", func(t *testing.T) {
+		sentinel := FromCode(someCode)
+		err := fmt.Errorf("context: %w", WrapCode(errSomeError, someCode))
+
+		assert.True(t, errors.Is(err, sentinel))
+		assert.True(t, errors.Is(err, errSomeError))
+	})
+
+	t.Run("does not detect error with a different code", func(t *testing.T) {
+		err := FromCode(someCode)
+
+		assert.False(t, errors.Is(err, FromCode(someCode+1)))
+	})
+
+	t.Run("does not detect error with generic code", func(t *testing.T) {
+		err := New("foo")
+
+		assert.False(t, errors.Is(err, New("bar")))
+	})
+
+	t.Run("detects cause with errors.As", func(t *testing.T) {
+		cause := FromCode(someCode)
+		err := Wrap(cause, "wrapper")
+
+		var actual *ErrorWithCode
+		ok := errors.As(fmt.Errorf("context: %w", err), &actual)
+
+		require.True(t, ok)
+		assert.Equal(t, err, actual)
+	})
+}
+
+func TestUnit_Error_IsErrorWithCode(t *testing.T) {
+	t.Run("detects code of the error", func(t *testing.T) {
+		assert.True(t, IsErrorWithCode(FromCode(someCode), someCode))
+	})
+
+	t.Run("detects code of a wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", Wrap(FromCode(someCode), "wrapper"))
+
+		assert.True(t, IsErrorWithCode(err, someCode))
+	})
+
+	t.Run("detects code of a joined error", func(t *testing.T) {
+		err := errors.Join(errSomeError, fmt.Errorf("context: %w", FromCode(someCode)))
+
+		assert.True(t, IsErrorWithCode(err, someCode))
+	})
+
+	t.Run("does not detect missing code", func(t *testing.T) {
+		err := errors.Join(errSomeError, Wrap(FromCode(someCode), "wrapper"))
+
+		assert.False(t, IsErrorWithCode(err, someCode+1))
+	})
+
+	t.Run("does not detect random error", func(t *testing.T) {
+		assert.False(t, IsErrorWithCode(errSomeError, someCode))
+	})
+
+	t.Run("does not detect nil error", func(t *testing.T) {
+		assert.False(t, IsErrorWithCode(nil, someCode))
+	})
+}
+
+func TestUnit_Error_CodeOf(t *testing.T) {
+	t.Run("returns code of a wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", FromCode(someCode))
+
+		actual, ok := CodeOf(err)
+
+		assert.True(t, ok)
+		assert.Equal(t, someCode, actual)
+	})
+
+	t.Run("returns code of error exposing it", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", &codedSample{code: someCode})
+
+		actual, ok := CodeOf(err)
+
+		assert.True(t, ok)
+		assert.Equal(t, someCode, actual)
+	})
+
+	t.Run("returns generic code for random error", func(t *testing.T) {
+		actual, ok := CodeOf(errSomeError)
+
+		assert.False(t, ok)
+		assert.Equal(t, GenericErrorCode, actual)
+	})
+}
+
+type codedSample struct {
+	code ErrorCode
+}
+
+func (e *codedSample) ErrorCode() ErrorCode {
+	return e.code
+}
+
+func (e *codedSample) Error() string {
+	return "coded sample"
+}
@@ -0,0 +1,64 @@
+package errors
+
+import "net/http"
+
+// GrpcCode mirrors the canonical gRPC status codes (see the codes package
+// of grpc-go) so that the catalog does not depend on gRPC.
+type GrpcCode uint32
+
+const (
+	GrpcOk                 GrpcCode = 0
+	GrpcCanceled           GrpcCode = 1
+	GrpcUnknown            GrpcCode = 2
+	GrpcInvalidArgument    GrpcCode = 3
+	GrpcDeadlineExceeded   GrpcCode = 4
+	GrpcNotFound           GrpcCode = 5
+	GrpcAlreadyExists      GrpcCode = 6
+	GrpcPermissionDenied   GrpcCode = 7
+	GrpcResourceExhausted  GrpcCode = 8
+	GrpcFailedPrecondition GrpcCode = 9
+	GrpcAborted            GrpcCode = 10
+	GrpcOutOfRange         GrpcCode = 11
+	GrpcUnimplemented      GrpcCode = 12
+	GrpcInternal           GrpcCode = 13
+	GrpcUnavailable        GrpcCode = 14
+	GrpcDataLoss           GrpcCode = 15
+	GrpcUnauthenticated    GrpcCode = 16
+)
+
+// GrpcCodeFromHttpStatus returns the gRPC code closest to the HTTP status.
+func GrpcCodeFromHttpStatus(status int) GrpcCode {
+	switch status {
+	case http.StatusOK:
+		return GrpcOk
+	case http.StatusBadRequest:
+		return GrpcInvalidArgument
+	case http.StatusUnauthorized:
+		return GrpcUnauthenticated
+	case http.StatusForbidden:
+		return GrpcPermissionDenied
+	case http.StatusNotFound:
+		return GrpcNotFound
+	case http.StatusConflict:
+		return GrpcAlreadyExists
+	case http.StatusPreconditionFailed:
+		return GrpcFailedPrecondition
+	case http.StatusTooManyRequests:
+		return GrpcResourceExhausted
+	case http.StatusNotImplemented:
+		return GrpcUnimplemented
+	case http.StatusServiceUnavailable:
+		return GrpcUnavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return GrpcDeadlineExceeded
+	}
+
+	switch {
+	case status >= 500:
+		return GrpcInternal
+	case status >= 400:
+		return GrpcFailedPrecondition
+	default:
+		return GrpcUnknown
+	}
+}
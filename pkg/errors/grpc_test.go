@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_GrpcCodeFromHttpStatus(t *testing.T) {
+	type testCase struct {
+		status   int
+		expected GrpcCode
+	}
+
+	testCases := []testCase{
+		{status: http.StatusBadRequest, expected: GrpcInvalidArgument},
+		{status: http.StatusUnauthorized, expected: GrpcUnauthenticated},
+		{status: http.StatusNotFound, expected: GrpcNotFound},
+		{status: http.StatusConflict, expected: GrpcAlreadyExists},
+		{status: http.StatusTooManyRequests, expected: GrpcResourceExhausted},
+		{status: http.StatusUnprocessableEntity, expected: GrpcFailedPrecondition},
+		{status: http.StatusServiceUnavailable, expected: GrpcUnavailable},
+		{status: http.StatusGatewayTimeout, expected: GrpcDeadlineExceeded},
+		{status: http.StatusBadGateway, expected: GrpcInternal},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(http.StatusText(testCase.status), func(t *testing.T) {
+			actual := GrpcCodeFromHttpStatus(testCase.status)
+
+			require.Equal(t, testCase.expected, actual)
+		})
+	}
+}
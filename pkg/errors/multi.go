@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MultiError aggregates several errors, e.g. one per invalid field or one
+// per subsystem failing to stop. It is compatible with errors.Join: the
+// errors it contains are reachable with errors.Is and errors.As and keep
+// their own code.
+type MultiError struct {
+	Code   ErrorCode
+	Errors []error
+}
+
+// Join aggregates the errors, discarding the nil ones. It returns nil when
+// none of the errors is set.
+func Join(errs ...error) error {
+	return JoinCode(GenericErrorCode, errs...)
+}
+
+func JoinCode(code ErrorCode, errs ...error) error {
+	out := &MultiError{Code: code}
+	for _, err := range errs {
+		if err != nil {
+			out.Errors = append(out.Errors, err)
+		}
+	}
+
+	if len(out.Errors) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+// Errors returns the errors aggregated in the error, flattening the nested
+// aggregations (MultiError or errors.Join) including the ones wrapped in
+// another error. An error which is not an aggregation is returned as is.
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	for wrapped := err; wrapped != nil; {
+		switch impl := wrapped.(type) {
+		case interface{ Unwrap() []error }:
+			var out []error
+			for _, contained := range impl.Unwrap() {
+				out = append(out, Errors(contained)...)
+			}
+			return out
+		case interface{ Unwrap() error }:
+			wrapped = impl.Unwrap()
+		default:
+			wrapped = nil
+		}
+	}
+
+	return []error{err}
+}
+
+func (e *MultiError) ErrorCode() ErrorCode {
+	return e.Code
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (e *MultiError) MarshalJSON() ([]byte, error) {
+	errs := make([]json.RawMessage, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, marshalError(err))
+	}
+
+	return json.Marshal(struct {
+		Code   ErrorCode         `json:"code"`
+		Errors []json.RawMessage `json:"errors"`
+	}{
+		Code:   e.Code,
+		Errors: errs,
+	})
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_MultiError_Join(t *testing.T) {
+	t.Run("ignores nil errors", func(t *testing.T) {
+		err := Join(nil, errSomeError, nil)
+
+		var actual *MultiError
+		require.ErrorAs(t, err, &actual)
+		assert.Equal(t, GenericErrorCode, actual.Code)
+		assert.Equal(t, []error{errSomeError}, actual.Errors)
+	})
+
+	t.Run("returns nil without errors", func(t *testing.T) {
+		assert.Nil(t, Join())
+		assert.Nil(t, Join(nil, nil))
+	})
+
+	t.Run("preserves the code", func(t *testing.T) {
+		err := JoinCode(someCode, errSomeError)
+
+		assert.True(t, IsErrorWithCode(err, someCode))
+	})
+
+	t.Run("preserves the codes of the errors", func(t *testing.T) {
+		err := Join(errSomeError, WrapCode(errSomeError, someCode))
+
+		assert.True(t, IsErrorWithCode(err, someCode))
+		assert.True(t, errors.Is(err, errSomeError))
+		assert.True(t, errors.Is(err, FromCode(someCode)))
+	})
+
+	t.Run("formats like errors.Join", func(t *testing.T) {
+		err := Join(New("foo"), errSomeError)
+
+		expected := errors.Join(New("foo"), errSomeError).Error()
+		assert.Equal(t, expected, err.Error())
+	})
+}
+
+func TestUnit_MultiError_MarshalJSON(t *testing.T) {
+	err := JoinCode(someCode, FromCode(someCode+1), errSomeError)
+
+	out, marshalErr := json.Marshal(err)
+
+	require.NoError(t, marshalErr, "Actual err: %v", marshalErr)
+	expected := `
+	{
+		"code": 26,
+		"errors": [
+			{"code": 27, "message": "an unexpected error occurred"},
+			"some error"
+		]
+	}`
+	assert.JSONEq(t, expected, string(out))
+}
+
+func TestUnit_MultiError_AsCause(t *testing.T) {
+	err := Wrap(Join(errSomeError), "shutdown failed")
+
+	out, marshalErr := json.Marshal(err)
+
+	require.NoError(t, marshalErr, "Actual err: %v", marshalErr)
+	expected := `
+	{
+		"code": 1,
+		"message": "shutdown failed",
+		"cause": {
+			"code": 1,
+			"errors": ["some error"]
+		}
+	}`
+	assert.JSONEq(t, expected, string(out))
+}
+
+func TestUnit_Errors(t *testing.T) {
+	t.Run("returns nil for nil error", func(t *testing.T) {
+		assert.Nil(t, Errors(nil))
+	})
+
+	t.Run("returns the error when not aggregated", func(t *testing.T) {
+		err := Wrap(errSomeError, "wrapper")
+
+		assert.Equal(t, []error{err}, Errors(err))
+	})
+
+	t.Run("flattens nested aggregations", func(t *testing.T) {
+		first, second, third := New("first"), New("second"), New("third")
+		err := Join(first, fmt.Errorf("context: %w", errors.Join(second, Join(third))))
+
+		assert.Equal(t, []error{first, second, third}, Errors(err))
+	})
+}
@@ -0,0 +1,45 @@
+package errors
+
+import "errors"
+
+// NewPublic creates an error with a message which can be returned to the
+// clients, e.g. in the body of an HTTP response.
+func NewPublic(code ErrorCode, message string) error {
+	return &ErrorWithCode{
+		Code:          code,
+		Message:       message,
+		PublicMessage: message,
+	}
+}
+
+// WithPublicMessage attaches a message which can be returned to the clients
+// to the error. The error is kept as the cause so that the logs still have
+// the full chain and its code, if any, is preserved.
+func WithPublicMessage(err error, message string) error {
+	code, _ := CodeOf(err)
+
+	return &ErrorWithCode{
+		Code:          code,
+		Message:       message,
+		PublicMessage: message,
+		Cause:         err,
+	}
+}
+
+// PublicMessageOf returns the message of the error which can be returned to
+// the clients. This is the first public message found in the chain and,
+// without it, the message of the code of the error in the catalog. The
+// other errors result in a generic message.
+func PublicMessageOf(err error) string {
+	for wrapped := err; wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		if impl, ok := wrapped.(*ErrorWithCode); ok && impl.PublicMessage != "" {
+			return impl.PublicMessage
+		}
+	}
+
+	if code, ok := CodeOf(err); ok {
+		return determineCommonErrorMessage(code)
+	}
+
+	return defaultErrorMessage
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_NewPublic(t *testing.T) {
+	err := NewPublic(someCode, "invalid name")
+
+	assert.True(t, IsErrorWithCode(err, someCode))
+	assert.Equal(t, "invalid name", PublicMessageOf(err))
+}
+
+func TestUnit_WithPublicMessage(t *testing.T) {
+	t.Run("preserves the code and the cause", func(t *testing.T) {
+		cause := WrapCode(errSomeError, someCode)
+
+		err := WithPublicMessage(cause, "service unavailable")
+
+		assert.True(t, IsErrorWithCode(err, someCode))
+		assert.ErrorIs(t, err, errSomeError)
+		assert.Contains(t, err.Error(), "some error")
+	})
+
+	t.Run("uses generic code without code", func(t *testing.T) {
+		err := WithPublicMessage(errSomeError, "service unavailable")
+
+		assert.True(t, IsErrorWithCode(err, GenericErrorCode))
+	})
+}
+
+func TestUnit_PublicMessageOf(t *testing.T) {
+	t.Run("uses first public message in the chain", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", WithPublicMessage(NewPublic(someCode, "inner"), "outer"))
+
+		assert.Equal(t, "outer", PublicMessageOf(err))
+	})
+
+	t.Run("uses message of the code in the catalog", func(t *testing.T) {
+		registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code", Message: "some message"})
+		err := fmt.Errorf("context: %w", WrapCode(errSomeError, catalogTestCode))
+
+		assert.Equal(t, "some message", PublicMessageOf(err))
+	})
+
+	t.Run("does not leak message of error with code", func(t *testing.T) {
+		err := Wrap(errSomeError, "failed to query users table")
+
+		assert.Equal(t, defaultErrorMessage, PublicMessageOf(err))
+	})
+
+	t.Run("does not leak message of random error", func(t *testing.T) {
+		assert.Equal(t, defaultErrorMessage, PublicMessageOf(errSomeError))
+	})
+}
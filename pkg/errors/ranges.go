@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FirstServiceErrorCode is the first code available to the services using
+// the toolkit: the codes below it are reserved for the toolkit itself.
+const FirstServiceErrorCode ErrorCode = 1000
+
+// CodeRange is a range of codes reserved for a namespace, i.e. the prefix
+// of the name of the codes such as "db" for "db.no_matching_rows".
+type CodeRange struct {
+	Namespace string
+	First     ErrorCode
+	Last      ErrorCode
+}
+
+var codeRanges = []CodeRange{
+	{Namespace: "errors", First: 1, Last: 99},
+	{Namespace: "db", First: 100, Last: 119},
+	{Namespace: "postgresql", First: 120, Last: 129},
+	{Namespace: "dbtest", First: 130, Last: 139},
+	{Namespace: "db", First: 140, Last: 199},
+	{Namespace: "process", First: 200, Last: 299},
+	{Namespace: "server", First: 300, Last: 399},
+	{Namespace: "middleware", First: 400, Last: 499},
+	{Namespace: "audit", First: 500, Last: 599},
+	{Namespace: "migrations", First: 600, Last: 699},
+}
+
+// ReserveRange reserves the codes between first and last (included) for the
+// namespace: registering one of them with a name from another namespace then
+// panics. This allows the services to split their codes between packages.
+// It panics if the range overlaps the toolkit codes, another range or a code
+// registered with another namespace.
+func ReserveRange(namespace string, first ErrorCode, last ErrorCode) {
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+
+	if first > last {
+		panic(fmt.Sprintf("invalid range [%d, %d] for namespace %q", first, last, namespace))
+	}
+	if first < FirstServiceErrorCode {
+		panic(fmt.Sprintf("range [%d, %d] of namespace %q overlaps the codes of the toolkit", first, last, namespace))
+	}
+
+	for _, existing := range codeRanges {
+		if first <= existing.Last && existing.First <= last {
+			panic(fmt.Sprintf(
+				"range [%d, %d] of namespace %q overlaps range [%d, %d] of namespace %q",
+				first, last, namespace, existing.First, existing.Last, existing.Namespace,
+			))
+		}
+	}
+
+	for code, info := range catalog {
+		if first <= code && code <= last && namespaceOf(info.Name) != namespace {
+			panic(fmt.Sprintf("range [%d, %d] of namespace %q contains code %q", first, last, namespace, info.Name))
+		}
+	}
+
+	codeRanges = append(codeRanges, CodeRange{Namespace: namespace, First: first, Last: last})
+}
+
+// checkCodeRange verifies that the code belongs to the namespace of its
+// name, if it is reserved. The codes of the toolkit are always reserved.
+func checkCodeRange(info CodeInfo) error {
+	namespace := namespaceOf(info.Name)
+
+	for _, r := range codeRanges {
+		if r.First <= info.Code && info.Code <= r.Last {
+			if r.Namespace == namespace {
+				return nil
+			}
+			return fmt.Errorf("error code %d of %q is reserved for namespace %q", info.Code, info.Name, r.Namespace)
+		}
+	}
+
+	if info.Code < FirstServiceErrorCode {
+		return fmt.Errorf("error code %d of %q is reserved for the toolkit", info.Code, info.Name)
+	}
+
+	return nil
+}
+
+func namespaceOf(name string) string {
+	namespace, _, found := strings.Cut(name, ".")
+	if !found {
+		return ""
+	}
+	return namespace
+}
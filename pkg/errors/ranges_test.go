@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func reserveTestRange(t *testing.T, namespace string, first ErrorCode, last ErrorCode) {
+	ranges := len(codeRanges)
+	ReserveRange(namespace, first, last)
+	t.Cleanup(func() {
+		catalogLock.Lock()
+		defer catalogLock.Unlock()
+		codeRanges = codeRanges[:ranges]
+	})
+}
+
+func TestUnit_Register_WhenCodeIsReservedForToolkit_ExpectPanic(t *testing.T) {
+	assert.PanicsWithValue(t, `error code 105 of "users.invalid_name" is reserved for namespace "db"`, func() {
+		Register(CodeInfo{Code: 105, Name: "users.invalid_name"})
+	})
+	assert.PanicsWithValue(t, `error code 999 of "users.invalid_name" is reserved for the toolkit`, func() {
+		Register(CodeInfo{Code: 999, Name: "users.invalid_name"})
+	})
+}
+
+func TestUnit_Register_WhenCodeIsInRangeOfNamespace_ExpectSuccess(t *testing.T) {
+	reserveTestRange(t, "test", catalogTestCode, catalogTestCode+99)
+
+	assert.NotPanics(t, func() {
+		registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code"})
+	})
+}
+
+func TestUnit_Register_WhenCodeIsInRangeOfOtherNamespace_ExpectPanic(t *testing.T) {
+	reserveTestRange(t, "test", catalogTestCode, catalogTestCode+99)
+
+	assert.PanicsWithValue(t, `error code 9001 of "users.invalid_name" is reserved for namespace "test"`, func() {
+		Register(CodeInfo{Code: catalogTestCode + 1, Name: "users.invalid_name"})
+	})
+}
+
+func TestUnit_ReserveRange_WhenRangeIsInvalid_ExpectPanic(t *testing.T) {
+	assert.PanicsWithValue(t, `invalid range [9001, 9000] for namespace "test"`, func() {
+		ReserveRange("test", catalogTestCode+1, catalogTestCode)
+	})
+}
+
+func TestUnit_ReserveRange_WhenRangeOverlapsToolkit_ExpectPanic(t *testing.T) {
+	assert.PanicsWithValue(t, `range [900, 1099] of namespace "test" overlaps the codes of the toolkit`, func() {
+		ReserveRange("test", 900, 1099)
+	})
+}
+
+func TestUnit_ReserveRange_WhenRangeOverlapsOtherRange_ExpectPanic(t *testing.T) {
+	reserveTestRange(t, "test", catalogTestCode, catalogTestCode+99)
+
+	assert.PanicsWithValue(t, `range [9050, 9149] of namespace "users" overlaps range [9000, 9099] of namespace "test"`, func() {
+		ReserveRange("users", catalogTestCode+50, catalogTestCode+149)
+	})
+}
+
+func TestUnit_ReserveRange_WhenRangeContainsCodeOfOtherNamespace_ExpectPanic(t *testing.T) {
+	registerTestCode(t, CodeInfo{Code: catalogTestCode, Name: "test.code"})
+
+	assert.PanicsWithValue(t, `range [9000, 9099] of namespace "users" contains code "test.code"`, func() {
+		ReserveRange("users", catalogTestCode, catalogTestCode+99)
+	})
+}
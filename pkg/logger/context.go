@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+)
+
+// IntoContext attaches the logger to the context. It is the same as
+// reqctx.WithLogger: the logger attached by the middleware of the server
+// can be retrieved with either.
+func IntoContext(ctx context.Context, log *slog.Logger) context.Context {
+	return reqctx.WithLogger(ctx, log)
+}
+
+// FromContext returns the logger attached to the context or the default
+// logger if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	return reqctx.Logger(ctx)
+}
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_FromContext_WhenNoLogger_ExpectDefault(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+}
+
+func TestUnit_FromContext_ReturnsLoggerAttachedWithIntoContext(t *testing.T) {
+	log := slog.New(slog.DiscardHandler)
+
+	ctx := IntoContext(context.Background(), log)
+
+	assert.Same(t, log, FromContext(ctx))
+}
+
+func TestUnit_FromContext_ReturnsLoggerAttachedByMiddlewares(t *testing.T) {
+	log := slog.New(slog.DiscardHandler)
+
+	ctx := reqctx.WithLogger(context.Background(), log)
+
+	assert.Same(t, log, FromContext(ctx))
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"time"
+)
+
+type ErrorEvent struct {
+	Time    time.Time
+	Message string
+	// Attrs are the attributes of the record, including the ones attached
+	// to the logger. Groups are represented as group attributes.
+	Attrs []slog.Attr
+	// Err is the first error found in the attributes, if any.
+	Err error
+	// Stack is the stack of the goroutine which produced the error.
+	Stack []byte
+}
+
+// ErrorHook receives the errors, typically to forward them to an error
+// tracking or alerting service. It is called synchronously and should
+// not block.
+type ErrorHook func(ctx context.Context, event ErrorEvent)
+
+// WithErrorHook returns a logger calling the hook for each record at the
+// error level or above, in addition to writing it.
+func WithErrorHook(log *slog.Logger, hook ErrorHook) *slog.Logger {
+	return slog.New(&errorHookHandler{
+		next: log.Handler(),
+		hook: hook,
+	})
+}
+
+type errorHookHandler struct {
+	next   slog.Handler
+	hook   ErrorHook
+	attrs  []slog.Attr
+	groups []string
+}
+
+func (h *errorHookHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelError || h.next.Enabled(ctx, level)
+}
+
+func (h *errorHookHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelError {
+		var attrs []slog.Attr
+		record.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+
+		all := append(slices.Clone(h.attrs), nest(h.groups, attrs)...)
+		h.hook(ctx, ErrorEvent{
+			Time:    record.Time,
+			Message: record.Message,
+			Attrs:   all,
+			Err:     findError(all),
+			Stack:   debug.Stack(),
+		})
+	}
+
+	if !h.next.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *errorHookHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := h.clone()
+	out.next = h.next.WithAttrs(attrs)
+	out.attrs = append(out.attrs, nest(h.groups, attrs)...)
+	return out
+}
+
+func (h *errorHookHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	out := h.clone()
+	out.next = h.next.WithGroup(name)
+	out.groups = append(out.groups, name)
+	return out
+}
+
+func (h *errorHookHandler) clone() *errorHookHandler {
+	return &errorHookHandler{
+		next:   h.next,
+		hook:   h.hook,
+		attrs:  slices.Clone(h.attrs),
+		groups: slices.Clone(h.groups),
+	}
+}
+
+// nest wraps the attributes in the groups, from the innermost one.
+func nest(groups []string, attrs []slog.Attr) []slog.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{{Key: groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	return attrs
+}
+
+func findError(attrs []slog.Attr) error {
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+		if value.Kind() == slog.KindGroup {
+			if err := findError(value.Group()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if value.Kind() != slog.KindAny {
+			continue
+		}
+		if err, ok := value.Any().(error); ok {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorHookRecorder() (ErrorHook, *[]ErrorEvent) {
+	var events []ErrorEvent
+	hook := func(ctx context.Context, event ErrorEvent) {
+		events = append(events, event)
+	}
+	return hook, &events
+}
+
+func TestUnit_WithErrorHook_ExpectHookCalledForErrorsOnly(t *testing.T) {
+	var out bytes.Buffer
+	hook, events := newErrorHookRecorder()
+	log := WithErrorHook(New(&out), hook)
+
+	log.Warn("some warning")
+	log.Error("some error")
+
+	require.Len(t, *events, 1)
+	assert.Equal(t, "some error", (*events)[0].Message)
+	assert.False(t, (*events)[0].Time.IsZero())
+	assert.NotEmpty(t, (*events)[0].Stack)
+	assert.Contains(t, out.String(), "some warning")
+	assert.Contains(t, out.String(), "some error")
+}
+
+func TestUnit_WithErrorHook_ExpectAttributesAndError(t *testing.T) {
+	hook, events := newErrorHookRecorder()
+	log := WithErrorHook(New(&bytes.Buffer{}), hook)
+	err := errors.New("some failure")
+
+	log.With(slog.String("requestId", "abc")).WithGroup("db").Error("Query failed", slog.Any("error", err))
+
+	require.Len(t, *events, 1)
+	expected := []slog.Attr{
+		slog.String("requestId", "abc"),
+		slog.Group("db", slog.Any("error", err)),
+	}
+	assert.Equal(t, slog.GroupValue(expected...).String(), slog.GroupValue((*events)[0].Attrs...).String())
+	assert.Equal(t, err, (*events)[0].Err)
+}
+
+func TestUnit_WithErrorHook_WhenLevelOfLoggerIsAboveError_ExpectHookStillCalled(t *testing.T) {
+	var out bytes.Buffer
+	hook, events := newErrorHookRecorder()
+	log := WithErrorHook(NewWithLevel(&out, zerolog.Disabled), hook)
+
+	log.Error("some error")
+
+	assert.Len(t, *events, 1)
+	assert.Empty(t, out.String())
+}
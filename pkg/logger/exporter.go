@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// WithExporter returns a logger which also sends the records to the
+// exporter, for example the handler of an OtlpExporter. The exporter
+// decides which levels it handles: the level of the logger does not apply
+// to it.
+func WithExporter(log *slog.Logger, exporter slog.Handler) *slog.Logger {
+	return slog.New(&exportHandler{
+		next:     log.Handler(),
+		exporter: exporter,
+	})
+}
+
+type exportHandler struct {
+	next     slog.Handler
+	exporter slog.Handler
+}
+
+func (h *exportHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level) || h.exporter.Enabled(ctx, level)
+}
+
+func (h *exportHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	if h.next.Enabled(ctx, record.Level) {
+		errs = append(errs, h.next.Handle(ctx, record.Clone()))
+	}
+	if h.exporter.Enabled(ctx, record.Level) {
+		errs = append(errs, h.exporter.Handle(ctx, record.Clone()))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *exportHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &exportHandler{next: h.next.WithAttrs(attrs), exporter: h.exporter.WithAttrs(attrs)}
+}
+
+func (h *exportHandler) WithGroup(name string) slog.Handler {
+	return &exportHandler{next: h.next.WithGroup(name), exporter: h.exporter.WithGroup(name)}
+}
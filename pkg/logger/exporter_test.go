@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingHandler struct {
+	slog.Handler
+}
+
+func (h failingHandler) Handle(_ context.Context, _ slog.Record) error {
+	return errors.New("export failed")
+}
+
+func TestUnit_WithExporter_ExpectRecordsSentToBothHandlers(t *testing.T) {
+	var out, exported bytes.Buffer
+	log := WithExporter(New(&out), slog.NewJSONHandler(&exported, nil))
+
+	log.With(slog.String("name", "John")).WithGroup("request").Info("hello", slog.Int("id", 2))
+
+	assert.Contains(t, out.String(), "hello")
+	assert.JSONEq(t, `{"level":"INFO","msg":"hello","name":"John","request":{"id":2}}`, removeTime(t, exported.Bytes()))
+}
+
+func TestUnit_WithExporter_ExpectLevelOfLoggerNotAppliedToExporter(t *testing.T) {
+	var out, exported bytes.Buffer
+	log := WithExporter(NewWithLevel(&out, zerolog.WarnLevel), slog.NewJSONHandler(&exported, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	log.Debug("hello")
+
+	assert.Empty(t, out.String())
+	assert.Contains(t, exported.String(), "hello")
+}
+
+func TestUnit_WithExporter_WhenExportFails_ExpectRecordStillLogged(t *testing.T) {
+	var out, exported bytes.Buffer
+	exporter := failingHandler{Handler: slog.NewJSONHandler(&exported, nil)}
+	handler := WithExporter(New(&out), exporter).Handler()
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	err := handler.Handle(t.Context(), record)
+
+	assert.EqualError(t, err, "export failed")
+	assert.Contains(t, out.String(), "hello")
+}
+
+func removeTime(t *testing.T, data []byte) string {
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(data, &line))
+	delete(line, "time")
+
+	out, err := json.Marshal(line)
+	require.NoError(t, err)
+	return string(out)
+}
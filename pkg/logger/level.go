@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// LevelVar holds the minimum level of the loggers created with it. It can
+// be changed while the loggers are in use, for example to temporarily
+// enable the debug logs during an incident.
+type LevelVar struct {
+	level atomic.Int32
+}
+
+func NewLevelVar(level zerolog.Level) *LevelVar {
+	v := &LevelVar{}
+	v.SetLevel(level)
+	return v
+}
+
+func (v *LevelVar) Level() zerolog.Level {
+	return zerolog.Level(v.level.Load())
+}
+
+func (v *LevelVar) SetLevel(level zerolog.Level) {
+	v.level.Store(int32(level))
+}
+
+// ParseLevel converts the name of a level (e.g. "debug", "info", "warn" or
+// "error") to the corresponding level.
+func ParseLevel(name string) (zerolog.Level, error) {
+	return zerolog.ParseLevel(name)
+}
+
+// LevelOf returns the variable holding the minimum level of a logger
+// created by this package, so that it can be adjusted at runtime.
+func LevelOf(log *slog.Logger) (*LevelVar, bool) {
+	switch handler := log.Handler().(type) {
+	case *slogHandler:
+		return handler.level, true
+	case *samplingHandler:
+		return LevelOf(slog.New(handler.next))
+	case *redactionHandler:
+		return LevelOf(slog.New(handler.next))
+	case *exportHandler:
+		return LevelOf(slog.New(handler.next))
+	case *errorHookHandler:
+		return LevelOf(slog.New(handler.next))
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_LevelVar_SetLevel_ExpectLevelChanged(t *testing.T) {
+	level := NewLevelVar(zerolog.InfoLevel)
+
+	level.SetLevel(zerolog.DebugLevel)
+
+	assert.Equal(t, zerolog.DebugLevel, level.Level())
+}
+
+func TestUnit_LevelVar_SetLevel_WhenUsedConcurrently_ExpectNoRace(t *testing.T) {
+	level := NewLevelVar(zerolog.InfoLevel)
+	log := NewWithLevelVar(&bytes.Buffer{}, level)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			level.SetLevel(zerolog.DebugLevel)
+			log.Debug("hello")
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, zerolog.DebugLevel, level.Level())
+}
+
+func TestUnit_NewWithLevelVar_WhenLevelChanges_ExpectLoggerAndChildrenUpdated(t *testing.T) {
+	var out bytes.Buffer
+	level := NewLevelVar(zerolog.InfoLevel)
+	log := NewWithLevelVar(&out, level)
+	child := log.With(slog.String("name", "John"))
+
+	child.Debug("before")
+	level.SetLevel(zerolog.DebugLevel)
+	child.Debug("after")
+	log.Debug("parent")
+
+	assert.NotContains(t, out.String(), "before")
+	assert.Contains(t, out.String(), "after")
+	assert.Contains(t, out.String(), "parent")
+}
+
+func TestUnit_ParseLevel(t *testing.T) {
+	type testCase struct {
+		name     string
+		expected zerolog.Level
+	}
+
+	testCases := []testCase{
+		{name: "debug", expected: zerolog.DebugLevel},
+		{name: "info", expected: zerolog.InfoLevel},
+		{name: "warn", expected: zerolog.WarnLevel},
+		{name: "error", expected: zerolog.ErrorLevel},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := ParseLevel(testCase.name)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestUnit_ParseLevel_WhenNameIsUnknown_ExpectError(t *testing.T) {
+	_, err := ParseLevel("verbose")
+
+	assert.NotNil(t, err)
+}
+
+func TestUnit_LevelOf_ExpectLevelOfLogger(t *testing.T) {
+	level := NewLevelVar(zerolog.WarnLevel)
+	log := NewWithLevelVar(&bytes.Buffer{}, level).With(slog.String("name", "John"))
+
+	actual, ok := LevelOf(log)
+
+	assert.True(t, ok)
+	assert.Same(t, level, actual)
+}
+
+func TestUnit_LevelOf_WhenLoggerIsSampled_ExpectLevelOfLogger(t *testing.T) {
+	level := NewLevelVar(zerolog.WarnLevel)
+	log := WithSampling(NewWithLevelVar(&bytes.Buffer{}, level), SamplingConfig{First: 1})
+
+	actual, ok := LevelOf(log)
+
+	assert.True(t, ok)
+	assert.Same(t, level, actual)
+}
+
+func TestUnit_LevelOf_WhenLoggerIsRedacted_ExpectLevelOfLogger(t *testing.T) {
+	level := NewLevelVar(zerolog.WarnLevel)
+	log := WithRedaction(NewWithLevelVar(&bytes.Buffer{}, level), NewRedactor(RedactionConfig{}))
+
+	actual, ok := LevelOf(log)
+
+	assert.True(t, ok)
+	assert.Same(t, level, actual)
+}
+
+func TestUnit_LevelOf_WhenLoggerIsNotFromThisPackage_ExpectFalse(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	_, ok := LevelOf(log)
+
+	assert.False(t, ok)
+}
@@ -3,30 +3,59 @@ package logger
 import (
 	"io"
 	"log/slog"
+	"maps"
+	"slices"
 
 	"github.com/rs/zerolog"
 )
 
 func New(out io.Writer) *slog.Logger {
-	safeOutput := out
-	if _, ok := safeOutput.(*safeConsoleWriter); !ok {
-		safeOutput = newSafeConsoleWriter(out)
+	return NewWithLevel(out, zerolog.TraceLevel)
+}
+
+func NewWithLevel(out io.Writer, level zerolog.Level) *slog.Logger {
+	return NewWithLevelVar(out, NewLevelVar(level))
+}
+
+// NewWithLevelVar creates a logger whose minimum level is read from the
+// provided variable: changing it affects this logger and the loggers
+// derived from it.
+func NewWithLevelVar(out io.Writer, level *LevelVar) *slog.Logger {
+	var writer io.Writer
+	if outputs, ok := out.(Outputs); ok {
+		writer = newOutputsWriter(outputs)
+	} else {
+		writer = NewPrettyWriter(newSafeWriter(out))
 	}
 
-	zlog := zerolog.New(NewPrettyWriter(safeOutput))
-	handler := zerolog.NewSlogHandler(zlog)
+	zlog := zerolog.New(writer)
+	handler := newSlogHandler(zlog, level)
 
 	return slog.New(handler)
 }
 
-func NewWithLevel(out io.Writer, level zerolog.Level) *slog.Logger {
-	safeOutput := out
-	if _, ok := safeOutput.(*safeConsoleWriter); !ok {
-		safeOutput = newSafeConsoleWriter(out)
+// WithFields returns a child logger attaching the fields to every record.
+// The fields are attached in the order of their keys so that the output
+// is stable.
+func WithFields(log *slog.Logger, fields map[string]any) *slog.Logger {
+	if len(fields) == 0 {
+		return log
 	}
 
-	zlog := zerolog.New(NewPrettyWriter(safeOutput)).Level(level)
-	handler := zerolog.NewSlogHandler(zlog)
+	args := make([]any, 0, len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		args = append(args, slog.Any(key, fields[key]))
+	}
 
-	return slog.New(handler)
+	return log.With(args...)
+}
+
+func newSafeWriter(out io.Writer) io.Writer {
+	switch out.(type) {
+	case *safeConsoleWriter, *RotatingFileWriter:
+		// Those writers are already safe for concurrent use.
+		return out
+	default:
+		return newSafeConsoleWriter(out)
+	}
 }
@@ -29,3 +29,32 @@ func TestUnit_Logger_DoesNotLogWhenSeverityIsHigherThanMessage(t *testing.T) {
 
 	assert.Empty(t, out)
 }
+
+func TestUnit_WithFields_ExpectFieldsInEveryRecord(t *testing.T) {
+	var out bytes.Buffer
+	log := New(&out)
+
+	child := WithFields(log, map[string]any{"tenant": "acme", "service": "users"})
+	child.Info("first")
+	child.Info("second")
+
+	assert.Regexp(t, "first.* .*service=.*users .*tenant=.*acme\n.*second.* .*service=.*users .*tenant=.*acme\n", out.String())
+}
+
+func TestUnit_WithFields_DoesNotModifyParentLogger(t *testing.T) {
+	var out bytes.Buffer
+	log := New(&out)
+
+	WithFields(log, map[string]any{"tenant": "acme"})
+	log.Info("hello")
+
+	assert.NotContains(t, out.String(), "tenant")
+}
+
+func TestUnit_WithFields_WhenNoFields_ExpectSameLogger(t *testing.T) {
+	log := New(&bytes.Buffer{})
+
+	actual := WithFields(log, nil)
+
+	assert.Same(t, log, actual)
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const otlpScopeName = "github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+
+type OtlpConfig struct {
+	// EndpointUrl is the OTLP/HTTP endpoint of the collector, e.g.
+	// http://localhost:4318/v1/logs. The connection is not secured when
+	// the scheme is http.
+	EndpointUrl string
+	// Headers are sent with each export, e.g. to authenticate with the
+	// observability backend.
+	Headers map[string]string
+	// ServiceName is reported as the service.name of the records.
+	ServiceName string
+	// Level is the minimum level of the exported records. It defaults to
+	// info.
+	Level slog.Level
+}
+
+// OtlpExporter sends the log records to an OpenTelemetry collector. Its
+// handler is meant to be used with WithExporter. The trace and span
+// identifiers of the context provided when logging are attached to the
+// records.
+type OtlpExporter struct {
+	provider *sdklog.LoggerProvider
+	handler  slog.Handler
+}
+
+func NewOtlpExporter(ctx context.Context, config OtlpConfig) (*OtlpExporter, error) {
+	opts := []otlploghttp.Option{otlploghttp.WithEndpointURL(config.EndpointUrl)}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(config.Headers))
+	}
+
+	exporter, err := otlploghttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []attribute.KeyValue
+	if config.ServiceName != "" {
+		attributes = append(attributes, attribute.String("service.name", config.ServiceName))
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(resource.NewSchemaless(attributes...)),
+	)
+
+	return &OtlpExporter{
+		provider: provider,
+		handler: &minLevelHandler{
+			next:  otelslog.NewHandler(otlpScopeName, otelslog.WithLoggerProvider(provider)),
+			level: config.Level,
+		},
+	}, nil
+}
+
+func (e *OtlpExporter) Handler() slog.Handler {
+	return e.handler
+}
+
+// Shutdown exports the pending records and stops the exporter. It should
+// be called before the service exits to not lose the last records.
+func (e *OtlpExporter) Shutdown(ctx context.Context) error {
+	return e.provider.Shutdown(ctx)
+}
+
+type minLevelHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.next.Enabled(ctx, level)
+}
+
+func (h *minLevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h *minLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &minLevelHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *minLevelHandler) WithGroup(name string) slog.Handler {
+	return &minLevelHandler{next: h.next.WithGroup(name), level: h.level}
+}
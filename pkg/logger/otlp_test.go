@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUnit_OtlpExporter_ExpectRecordsExportedWithTraceContext(t *testing.T) {
+	collector := newTestOtlpCollector(t)
+	exporter, err := NewOtlpExporter(t.Context(), OtlpConfig{
+		EndpointUrl: collector.server.URL + "/v1/logs",
+		Headers:     map[string]string{"Authorization": "my-token"},
+		ServiceName: "my-service",
+	})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	var out bytes.Buffer
+	log := WithExporter(New(&out), exporter.Handler())
+	traceId := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanId := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceId,
+		SpanID:  spanId,
+	}))
+
+	log.InfoContext(ctx, "hello", slog.Int("id", 2))
+	err = exporter.Shutdown(t.Context())
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "my-token", collector.header.Get("Authorization"))
+	require.Len(t, collector.records, 1)
+	record := collector.records[0]
+	assert.Equal(t, "hello", record.GetBody().GetStringValue())
+	assert.Equal(t, traceId[:], record.GetTraceId())
+	assert.Equal(t, spanId[:], record.GetSpanId())
+	assert.Equal(t, "my-service", collector.resource["service.name"])
+	assert.Contains(t, out.String(), "hello")
+}
+
+func TestUnit_OtlpExporter_ExpectRecordsBelowLevelNotExported(t *testing.T) {
+	collector := newTestOtlpCollector(t)
+	exporter, err := NewOtlpExporter(t.Context(), OtlpConfig{
+		EndpointUrl: collector.server.URL + "/v1/logs",
+		Level:       slog.LevelWarn,
+	})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	log := slog.New(exporter.Handler())
+	log.Info("ignored")
+	log.Warn("exported")
+	err = exporter.Shutdown(t.Context())
+
+	require.NoError(t, err, "Actual err: %v", err)
+	require.Len(t, collector.records, 1)
+	assert.Equal(t, "exported", collector.records[0].GetBody().GetStringValue())
+}
+
+type testOtlpCollector struct {
+	server   *httptest.Server
+	lock     sync.Mutex
+	header   http.Header
+	resource map[string]string
+	records  []*logspb.LogRecord
+}
+
+func newTestOtlpCollector(t *testing.T) *testOtlpCollector {
+	collector := &testOtlpCollector{resource: make(map[string]string)}
+	collector.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		var request collectorlogs.ExportLogsServiceRequest
+		err = proto.Unmarshal(body, &request)
+		require.NoError(t, err, "Actual err: %v", err)
+
+		collector.lock.Lock()
+		defer collector.lock.Unlock()
+		collector.header = r.Header.Clone()
+		for _, logs := range request.GetResourceLogs() {
+			for _, attr := range logs.GetResource().GetAttributes() {
+				collector.resource[attr.GetKey()] = attr.GetValue().GetStringValue()
+			}
+			for _, scope := range logs.GetScopeLogs() {
+				collector.records = append(collector.records, scope.GetLogRecords()...)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(collector.server.Close)
+
+	return collector
+}
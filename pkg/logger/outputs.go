@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/rs/zerolog"
+)
+
+// Output describes one of the destinations of the logs.
+type Output struct {
+	Writer io.Writer
+	// Levels restricts the logs written to this output to the listed
+	// levels. All levels are written when it is empty.
+	Levels []zerolog.Level
+}
+
+// Outputs can be passed to New to tee the logs to several writers, each of
+// them receiving only the levels it is configured for:
+//
+//	logger.New(logger.Outputs{
+//		{Writer: os.Stdout, Levels: []zerolog.Level{zerolog.DebugLevel, zerolog.InfoLevel, zerolog.WarnLevel}},
+//		{Writer: os.Stderr, Levels: []zerolog.Level{zerolog.ErrorLevel}},
+//		{Writer: file},
+//	})
+type Outputs []Output
+
+// Write writes the data to all the outputs regardless of their levels. It
+// is only used when the outputs are not passed to New.
+func (o Outputs) Write(p []byte) (int, error) {
+	for _, output := range o {
+		if _, err := output.Writer.Write(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func newOutputsWriter(outputs Outputs) io.Writer {
+	// The outputs sharing a writer (e.g. stdout for several levels) also
+	// need to share the lock protecting it.
+	safeWriters := make(map[io.Writer]io.Writer)
+
+	writers := make([]io.Writer, 0, len(outputs))
+	for _, output := range outputs {
+		safe, ok := safeWriters[output.Writer]
+		if !ok {
+			safe = newSafeWriter(output.Writer)
+			if reflect.TypeOf(output.Writer).Comparable() {
+				safeWriters[output.Writer] = safe
+			}
+		}
+
+		writers = append(writers, &levelFilterWriter{
+			levels: output.Levels,
+			writer: NewPrettyWriter(safe),
+		})
+	}
+
+	return zerolog.MultiLevelWriter(writers...)
+}
+
+type levelFilterWriter struct {
+	levels []zerolog.Level
+	writer io.Writer
+}
+
+func (w *levelFilterWriter) Write(p []byte) (int, error) {
+	return w.writer.Write(p)
+}
+
+func (w *levelFilterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if len(w.levels) > 0 && !slices.Contains(w.levels, level) {
+		return len(p), nil
+	}
+	return w.writer.Write(p)
+}
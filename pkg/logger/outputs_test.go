@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Outputs_ExpectLogsRoutedByLevel(t *testing.T) {
+	var stdout, stderr, file bytes.Buffer
+	log := New(Outputs{
+		{Writer: &stdout, Levels: []zerolog.Level{zerolog.InfoLevel, zerolog.WarnLevel}},
+		{Writer: &stderr, Levels: []zerolog.Level{zerolog.ErrorLevel}},
+		{Writer: &file},
+	})
+
+	log.Info("some information")
+	log.Error("some error")
+
+	assert.Contains(t, stdout.String(), "some information")
+	assert.NotContains(t, stdout.String(), "some error")
+	assert.Contains(t, stderr.String(), "some error")
+	assert.NotContains(t, stderr.String(), "some information")
+	assert.Contains(t, file.String(), "some information")
+	assert.Contains(t, file.String(), "some error")
+}
+
+func TestUnit_Outputs_WhenLevelIsBelowLoggerLevel_ExpectNothingWritten(t *testing.T) {
+	var out bytes.Buffer
+	log := NewWithLevel(Outputs{{Writer: &out}}, zerolog.WarnLevel)
+
+	log.Info("hello")
+
+	assert.Empty(t, out.String())
+}
+
+func TestUnit_Outputs_WhenWriterIsSharedByOutputs_ExpectConcurrentLogsSerialized(t *testing.T) {
+	var out bytes.Buffer
+	log := New(Outputs{
+		{Writer: &out, Levels: []zerolog.Level{zerolog.InfoLevel}},
+		{Writer: &out, Levels: []zerolog.Level{zerolog.ErrorLevel}},
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			log.Info("some information")
+		})
+		wg.Go(func() {
+			log.Error("some error")
+		})
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 20)
+}
+
+func TestUnit_Outputs_Write_ExpectDataWrittenToAllWriters(t *testing.T) {
+	var first, second bytes.Buffer
+	outputs := Outputs{
+		{Writer: &first, Levels: []zerolog.Level{zerolog.ErrorLevel}},
+		{Writer: &second},
+	}
+
+	n, err := outputs.Write([]byte("hello"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "hello", first.String())
+	assert.Equal(t, "hello", second.String())
+}
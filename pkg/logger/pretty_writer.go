@@ -9,8 +9,12 @@ import (
 
 func NewPrettyWriter(out io.Writer) io.Writer {
 	// https://github.com/rs/zerolog?tab=readme-ov-file#pretty-logging
+	// Colors are only meaningful in a terminal.
+	_, toFile := out.(*RotatingFileWriter)
+
 	return zerolog.ConsoleWriter{
 		Out:          out,
+		NoColor:      toFile,
 		TimeFormat:   time.DateTime,
 		TimeLocation: time.UTC,
 	}
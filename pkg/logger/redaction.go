@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const RedactedValue = "***"
+
+// DefaultRedactedFields lists common sensitive fields. They are masked by
+// the body dump middleware unless configured otherwise.
+var DefaultRedactedFields = []string{
+	"password",
+	"token",
+	"accessToken",
+	"refreshToken",
+	"secret",
+	"apiKey",
+	"authorization",
+}
+
+var (
+	EmailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	CardNumberPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+type RedactionConfig struct {
+	// Fields lists the keys of the attributes which values are masked. The
+	// comparison ignores the case.
+	Fields []string
+	// Patterns lists the expressions which matches are masked in the
+	// messages and in the string values (e.g. EmailPattern).
+	Patterns []*regexp.Regexp
+}
+
+type Redactor struct {
+	config RedactionConfig
+}
+
+func NewRedactor(config RedactionConfig) *Redactor {
+	return &Redactor{config: config}
+}
+
+func (r *Redactor) IsRedactedField(key string) bool {
+	return slices.ContainsFunc(r.config.Fields, func(field string) bool {
+		return strings.EqualFold(field, key)
+	})
+}
+
+// RedactString masks the parts of the value matching the patterns.
+func (r *Redactor) RedactString(value string) string {
+	for _, pattern := range r.config.Patterns {
+		value = pattern.ReplaceAllString(value, RedactedValue)
+	}
+	return value
+}
+
+// WithRedaction returns a logger masking the sensitive data of the records
+// before they are written.
+func WithRedaction(log *slog.Logger, redactor *Redactor) *slog.Logger {
+	return slog.New(&redactionHandler{
+		next:     log.Handler(),
+		redactor: redactor,
+	})
+}
+
+type redactionHandler struct {
+	next     slog.Handler
+	redactor *Redactor
+}
+
+func (h *redactionHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactionHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.RedactString(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		redacted = append(redacted, h.redactAttr(a))
+	}
+
+	return &redactionHandler{next: h.next.WithAttrs(redacted), redactor: h.redactor}
+}
+
+func (h *redactionHandler) WithGroup(name string) slog.Handler {
+	return &redactionHandler{next: h.next.WithGroup(name), redactor: h.redactor}
+}
+
+func (h *redactionHandler) redactAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if h.redactor.IsRedactedField(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		redacted := make([]slog.Attr, 0, len(group))
+		for _, a := range group {
+			redacted = append(redacted, h.redactAttr(a))
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindString:
+		return slog.String(attr.Key, h.redactor.RedactString(attr.Value.String()))
+	case slog.KindAny:
+		// Errors often embed the values which caused them.
+		if err, ok := attr.Value.Any().(error); ok {
+			if msg := h.redactor.RedactString(err.Error()); msg != err.Error() {
+				return slog.String(attr.Key, msg)
+			}
+		}
+	}
+
+	return attr
+}
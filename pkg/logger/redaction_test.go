@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedactedLogger(out *bytes.Buffer) *slog.Logger {
+	redactor := NewRedactor(RedactionConfig{
+		Fields:   []string{"password", "authorization"},
+		Patterns: []*regexp.Regexp{EmailPattern, CardNumberPattern},
+	})
+	return WithRedaction(slog.New(slog.NewTextHandler(out, nil)), redactor)
+}
+
+func TestUnit_Redactor_RedactString(t *testing.T) {
+	redactor := NewRedactor(RedactionConfig{Patterns: []*regexp.Regexp{EmailPattern, CardNumberPattern}})
+
+	actual := redactor.RedactString("john.doe@example.com paid with 4111 1111 1111 1111 order 1234")
+
+	assert.Equal(t, "*** paid with *** order 1234", actual)
+}
+
+func TestUnit_Redactor_IsRedactedField_ExpectCaseToBeIgnored(t *testing.T) {
+	redactor := NewRedactor(RedactionConfig{Fields: []string{"authorization"}})
+
+	assert.True(t, redactor.IsRedactedField("Authorization"))
+	assert.False(t, redactor.IsRedactedField("name"))
+}
+
+func TestUnit_WithRedaction_ExpectFieldsMasked(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestRedactedLogger(&out)
+
+	log.Info("Login", slog.String("user", "john"), slog.String("Password", "my-password"))
+
+	assert.Contains(t, out.String(), "user=john")
+	assert.Contains(t, out.String(), "Password=***")
+	assert.NotContains(t, out.String(), "my-password")
+}
+
+func TestUnit_WithRedaction_ExpectPatternsMaskedInMessageAndValues(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestRedactedLogger(&out)
+
+	log.Info(
+		"Sent email to john@example.com",
+		slog.String("to", "jane@example.com"),
+		slog.Any("error", errors.New("invalid card 4111111111111111")),
+	)
+
+	assert.Contains(t, out.String(), `msg="Sent email to ***"`)
+	assert.Contains(t, out.String(), "to=***")
+	assert.Contains(t, out.String(), `error="invalid card ***"`)
+}
+
+func TestUnit_WithRedaction_ExpectAttributesOfGroupsAndChildLoggersMasked(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestRedactedLogger(&out)
+
+	log.With(slog.String("authorization", "Bearer abc")).WithGroup("request").Info(
+		"hello",
+		slog.Group("headers", slog.String("Authorization", "Bearer def")),
+	)
+
+	assert.Contains(t, out.String(), "authorization=***")
+	assert.Contains(t, out.String(), "request.headers.Authorization=***")
+	assert.NotContains(t, out.String(), "Bearer")
+}
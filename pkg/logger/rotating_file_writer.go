@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	backupTimeFormat = "2006-01-02T15-04-05.000000000"
+	compressedSuffix = ".gz"
+)
+
+type RotationConfig struct {
+	// Path is the file the logs are written to. Rotated files are kept
+	// next to it, suffixed with the time of the rotation.
+	Path string
+	// MaxSize is the size in bytes after which the file is rotated. No
+	// rotation happens when it is not set.
+	MaxSize int64
+	// MaxAge is the duration after which rotated files are deleted. They
+	// are kept forever when it is not set.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files to keep. All of them are
+	// kept when it is not set.
+	MaxBackups int
+	// Compress enables the gzip compression of the rotated files.
+	Compress bool
+}
+
+// RotatingFileWriter writes logs to a file which is rotated when it grows
+// beyond the configured size. It is safe for concurrent use and can be
+// passed directly to New.
+type RotatingFileWriter struct {
+	config RotationConfig
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+
+	// cleanup serializes the compression and deletion of rotated files,
+	// which happen in the background not to block the writes.
+	cleanup sync.Mutex
+	pending sync.WaitGroup
+}
+
+func NewRotatingFileWriter(config RotationConfig) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		config: config,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.config.MaxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.config.MaxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the current file, renames it with the time of the rotation
+// and starts a new one.
+func (w *RotatingFileWriter) Rotate() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.rotate()
+}
+
+// Reopen closes and opens again the file at the configured path. It is
+// meant to be called when an external tool such as logrotate moved the
+// file (see ReopenOnSignal).
+func (w *RotatingFileWriter) Reopen() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	return w.open()
+}
+
+// Close closes the file and waits for the rotated files to be processed.
+func (w *RotatingFileWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	err := w.file.Close()
+	w.pending.Wait()
+
+	return err
+}
+
+// ReopenOnSignal reopens the file whenever one of the signals (SIGHUP by
+// default, as sent by logrotate) is received. The returned function stops
+// listening to the signals.
+func ReopenOnSignal(w *RotatingFileWriter, signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(received, signals...)
+
+	go func() {
+		for {
+			select {
+			case <-received:
+				// There is nowhere to report the failure: the writes will
+				// fail and be reported by the logger.
+				_ = w.Reopen()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+}
+
+func (w *RotatingFileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.config.Path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(w.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+
+	return nil
+}
+
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	backup := w.backupName(time.Now().UTC())
+	if err := os.Rename(w.config.Path, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.pending.Go(func() {
+		w.cleanup.Lock()
+		defer w.cleanup.Unlock()
+
+		if w.config.Compress {
+			compress(backup)
+		}
+		w.removeExpiredBackups()
+	})
+
+	return nil
+}
+
+func (w *RotatingFileWriter) backupName(at time.Time) string {
+	ext := filepath.Ext(w.config.Path)
+	prefix := strings.TrimSuffix(w.config.Path, ext)
+	return prefix + "-" + at.Format(backupTimeFormat) + ext
+}
+
+type backupFile struct {
+	path string
+	at   time.Time
+}
+
+func (w *RotatingFileWriter) listBackups() []backupFile {
+	ext := filepath.Ext(w.config.Path)
+	prefix := filepath.Base(strings.TrimSuffix(w.config.Path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(w.config.Path))
+	if err != nil {
+		return nil
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), compressedSuffix)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		at, err := time.Parse(backupTimeFormat, timestamp)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backupFile{
+			path: filepath.Join(filepath.Dir(w.config.Path), entry.Name()),
+			at:   at,
+		})
+	}
+
+	// Most recent first.
+	slices.SortFunc(backups, func(lhs backupFile, rhs backupFile) int {
+		return rhs.at.Compare(lhs.at)
+	})
+
+	return backups
+}
+
+func (w *RotatingFileWriter) removeExpiredBackups() {
+	cutoff := time.Now().Add(-w.config.MaxAge)
+
+	for i, backup := range w.listBackups() {
+		tooMany := w.config.MaxBackups > 0 && i >= w.config.MaxBackups
+		tooOld := w.config.MaxAge > 0 && backup.at.Before(cutoff)
+		if tooMany || tooOld {
+			os.Remove(backup.path)
+		}
+	}
+}
+
+func compress(path string) {
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path+compressedSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return
+	}
+
+	gz := gzip.NewWriter(out)
+	_, err = io.Copy(gz, in)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	// Keep the uncompressed file rather than losing logs.
+	if err != nil {
+		os.Remove(path + compressedSuffix)
+		return
+	}
+	os.Remove(path)
+}
@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRotatingFileWriter(t *testing.T, config RotationConfig) *RotatingFileWriter {
+	if config.Path == "" {
+		config.Path = filepath.Join(t.TempDir(), "logs", "service.log")
+	}
+
+	w, err := NewRotatingFileWriter(config)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return w
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "Actual err: %v", err)
+	return string(data)
+}
+
+func TestUnit_RotatingFileWriter_Write_ExpectFileCreatedWithContent(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{})
+
+	_, err := w.Write([]byte("hello\n"))
+	require.NoError(t, err, "Actual err: %v", err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "hello\n", readFile(t, w.config.Path))
+}
+
+func TestUnit_RotatingFileWriter_WhenFileExists_ExpectAppended(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o640))
+	w := newTestRotatingFileWriter(t, RotationConfig{Path: path, MaxSize: 10})
+
+	_, err := w.Write([]byte("second\n"))
+	require.NoError(t, err, "Actual err: %v", err)
+	require.NoError(t, w.Close())
+
+	// The existing content counts towards the size of the file.
+	assert.Equal(t, "second\n", readFile(t, path))
+	assert.Len(t, listFiles(t, filepath.Dir(path)), 2)
+}
+
+func TestUnit_RotatingFileWriter_WhenMaxSizeIsReached_ExpectRotation(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{MaxSize: 10})
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err, "Actual err: %v", err)
+	}
+	require.NoError(t, w.Close())
+
+	files := listFiles(t, filepath.Dir(w.config.Path))
+	assert.Len(t, files, 3)
+	assert.Equal(t, "line 3\n", readFile(t, w.config.Path))
+	for _, file := range files {
+		assert.True(t, strings.HasPrefix(file, "service"), "Unexpected file %s", file)
+		assert.True(t, strings.HasSuffix(file, ".log"), "Unexpected file %s", file)
+	}
+}
+
+func TestUnit_RotatingFileWriter_WhenMaxBackupsIsSet_ExpectOldestBackupsDeleted(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{MaxBackups: 2})
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err, "Actual err: %v", err)
+		require.NoError(t, w.Rotate())
+	}
+	require.NoError(t, w.Close())
+
+	var contents []string
+	for _, backup := range w.listBackups() {
+		contents = append(contents, readFile(t, backup.path))
+	}
+	assert.Equal(t, []string{"line 4\n", "line 3\n"}, contents)
+}
+
+func TestUnit_RotatingFileWriter_WhenMaxAgeIsSet_ExpectExpiredBackupsDeleted(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{MaxAge: time.Hour})
+	expired := w.backupName(time.Now().Add(-2 * time.Hour).UTC())
+	require.NoError(t, os.WriteFile(expired, []byte("expired\n"), 0o640))
+
+	require.NoError(t, w.Rotate())
+	require.NoError(t, w.Close())
+
+	assert.NoFileExists(t, expired)
+	assert.Len(t, w.listBackups(), 1)
+}
+
+func TestUnit_RotatingFileWriter_WhenCompressIsSet_ExpectGzippedBackup(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{Compress: true})
+
+	_, err := w.Write([]byte("hello\n"))
+	require.NoError(t, err, "Actual err: %v", err)
+	require.NoError(t, w.Rotate())
+	require.NoError(t, w.Close())
+
+	backups := w.listBackups()
+	require.Len(t, backups, 1)
+	assert.True(t, strings.HasSuffix(backups[0].path, ".log.gz"))
+
+	file, err := os.Open(backups[0].path)
+	require.NoError(t, err, "Actual err: %v", err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err, "Actual err: %v", err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "hello\n", string(data))
+}
+
+func TestUnit_RotatingFileWriter_Reopen_WhenFileWasMoved_ExpectNewFile(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{})
+	moved := w.config.Path + ".1"
+
+	_, err := w.Write([]byte("before\n"))
+	require.NoError(t, err, "Actual err: %v", err)
+	require.NoError(t, os.Rename(w.config.Path, moved))
+	require.NoError(t, w.Reopen())
+	_, err = w.Write([]byte("after\n"))
+	require.NoError(t, err, "Actual err: %v", err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "before\n", readFile(t, moved))
+	assert.Equal(t, "after\n", readFile(t, w.config.Path))
+}
+
+func TestUnit_ReopenOnSignal_ExpectFileReopened(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{})
+	stop := ReopenOnSignal(w, syscall.SIGUSR1)
+	defer stop()
+
+	require.NoError(t, os.Rename(w.config.Path, w.config.Path+".1"))
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(w.config.Path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Close())
+}
+
+func TestUnit_New_WithRotatingFileWriter_ExpectLogsWithoutColors(t *testing.T) {
+	w := newTestRotatingFileWriter(t, RotationConfig{})
+	log := New(w)
+
+	log.Info("hello")
+	require.NoError(t, w.Close())
+
+	content := readFile(t, w.config.Path)
+	assert.Contains(t, content, "INF hello")
+	assert.NotContains(t, content, "\x1b[")
+}
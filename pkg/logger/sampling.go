@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const defaultSamplingPeriod = time.Second
+
+type SamplingConfig struct {
+	// Period is the duration over which identical logs are counted. It
+	// defaults to one second.
+	Period time.Duration
+	// First is the number of identical logs written in each period.
+	First int
+	// Thereafter allows to write one out of Thereafter identical logs once
+	// First is reached. None of them are written when it is not set.
+	Thereafter int
+}
+
+// WithSampling returns a logger which limits the number of identical logs
+// (same level and message) written in each period. It prevents a hot path
+// to flood the output with the same line, for example during an outage.
+// The loggers derived from the returned one share the same limits.
+func WithSampling(log *slog.Logger, config SamplingConfig) *slog.Logger {
+	if config.Period <= 0 {
+		config.Period = defaultSamplingPeriod
+	}
+
+	return slog.New(&samplingHandler{
+		next:    log.Handler(),
+		sampler: newSampler(config, time.Now),
+	})
+}
+
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.sampler.allow(record.Level, record.Message) {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
+
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+type sampler struct {
+	config SamplingConfig
+	now    func() time.Time
+
+	lock        sync.Mutex
+	periodStart time.Time
+	counts      map[samplingKey]int
+}
+
+func newSampler(config SamplingConfig, now func() time.Time) *sampler {
+	return &sampler{
+		config:      config,
+		now:         now,
+		periodStart: now(),
+		counts:      make(map[samplingKey]int),
+	}
+}
+
+func (s *sampler) allow(level slog.Level, message string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Resetting all the counts at once keeps the memory bounded by the
+	// number of distinct messages in a period.
+	now := s.now()
+	if now.Sub(s.periodStart) >= s.config.Period {
+		s.periodStart = now
+		clear(s.counts)
+	}
+
+	key := samplingKey{level: level, message: message}
+	s.counts[key]++
+	count := s.counts[key]
+
+	if count <= s.config.First {
+		return true
+	}
+	return s.config.Thereafter > 0 && (count-s.config.First)%s.config.Thereafter == 0
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Sampler_ExpectFirstThenOneOutOfThereafter(t *testing.T) {
+	s := newSampler(SamplingConfig{Period: time.Second, First: 2, Thereafter: 3}, time.Now)
+
+	var actual []bool
+	for range 8 {
+		actual = append(actual, s.allow(slog.LevelError, "failure"))
+	}
+
+	expected := []bool{true, true, false, false, true, false, false, true}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_Sampler_WhenThereafterIsNotSet_ExpectOnlyFirst(t *testing.T) {
+	s := newSampler(SamplingConfig{Period: time.Second, First: 1}, time.Now)
+
+	assert.True(t, s.allow(slog.LevelError, "failure"))
+	assert.False(t, s.allow(slog.LevelError, "failure"))
+	assert.False(t, s.allow(slog.LevelError, "failure"))
+}
+
+func TestUnit_Sampler_ExpectMessagesAndLevelsCountedSeparately(t *testing.T) {
+	s := newSampler(SamplingConfig{Period: time.Second, First: 1}, time.Now)
+
+	assert.True(t, s.allow(slog.LevelError, "failure"))
+	assert.True(t, s.allow(slog.LevelError, "other failure"))
+	assert.True(t, s.allow(slog.LevelWarn, "failure"))
+	assert.False(t, s.allow(slog.LevelError, "failure"))
+}
+
+func TestUnit_Sampler_WhenPeriodElapsed_ExpectCountsReset(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	s := newSampler(SamplingConfig{Period: time.Second, First: 1}, func() time.Time { return now })
+
+	assert.True(t, s.allow(slog.LevelError, "failure"))
+	assert.False(t, s.allow(slog.LevelError, "failure"))
+	now = now.Add(time.Second)
+	assert.True(t, s.allow(slog.LevelError, "failure"))
+}
+
+func TestUnit_WithSampling_ExpectIdenticalLogsLimited(t *testing.T) {
+	var out bytes.Buffer
+	log := WithSampling(New(&out), SamplingConfig{First: 2})
+
+	for range 10 {
+		log.Error("failure")
+	}
+	log.Info("other")
+
+	assert.Equal(t, 2, strings.Count(out.String(), "failure"))
+	assert.Equal(t, 1, strings.Count(out.String(), "other"))
+}
+
+func TestUnit_WithSampling_ExpectDerivedLoggersToShareLimits(t *testing.T) {
+	var out bytes.Buffer
+	log := WithSampling(New(&out), SamplingConfig{First: 1})
+
+	log.Error("failure")
+	log.With(slog.String("name", "John")).Error("failure")
+
+	assert.Equal(t, 1, strings.Count(out.String(), "failure"))
+}
+
+func TestUnit_WithSampling_ExpectLevelOfLoggerToBeRespected(t *testing.T) {
+	var out bytes.Buffer
+	log := WithSampling(NewWithLevel(&out, zerolog.InfoLevel), SamplingConfig{First: 1})
+
+	log.Debug("hello")
+
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// slogHandler forwards the records of a slog.Logger to zerolog. Unlike the
+// handler provided by zerolog, the attributes attached with With keep the
+// groups which were opened at the time they were attached.
+type slogHandler struct {
+	logger zerolog.Logger
+	level  *LevelVar
+	prefix string
+	attrs  []groupedAttr
+}
+
+type groupedAttr struct {
+	prefix string
+	attr   slog.Attr
+}
+
+func newSlogHandler(logger zerolog.Logger, level *LevelVar) slog.Handler {
+	return &slogHandler{logger: logger, level: level}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	zlevel := toZerologLevel(level)
+	return zlevel >= zerolog.GlobalLevel() && zlevel >= h.level.Level()
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(toZerologLevel(record.Level))
+	if event == nil {
+		return nil
+	}
+
+	if ctx != nil {
+		event = event.Ctx(ctx)
+	}
+
+	for _, a := range h.attrs {
+		event = appendAttr(event, a.prefix, a.attr)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		event = appendAttr(event, h.prefix, a)
+		return true
+	})
+
+	if !record.Time.IsZero() {
+		event = event.Time(zerolog.TimestampFieldName, record.Time)
+	}
+
+	event.Msg(record.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	out := h.clone()
+	for _, a := range attrs {
+		out.attrs = append(out.attrs, groupedAttr{prefix: h.prefix, attr: a})
+	}
+	return out
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	out := h.clone()
+	out.prefix = joinKey(h.prefix, name)
+	return out
+}
+
+func (h *slogHandler) clone() *slogHandler {
+	out := &slogHandler{
+		logger: h.logger,
+		level:  h.level,
+		prefix: h.prefix,
+		attrs:  make([]groupedAttr, len(h.attrs)),
+	}
+	copy(out.attrs, h.attrs)
+	return out
+}
+
+func appendAttr(event *zerolog.Event, prefix string, attr slog.Attr) *zerolog.Event {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		// https://pkg.go.dev/log/slog#Handler: groups without a key are inlined
+		groupPrefix := joinKey(prefix, attr.Key)
+		for _, a := range attr.Value.Group() {
+			event = appendAttr(event, groupPrefix, a)
+		}
+		return event
+	}
+
+	if attr.Key == "" {
+		return event
+	}
+
+	key := joinKey(prefix, attr.Key)
+	value := attr.Value
+
+	switch value.Kind() {
+	case slog.KindString:
+		return event.Str(key, value.String())
+	case slog.KindInt64:
+		return event.Int64(key, value.Int64())
+	case slog.KindUint64:
+		return event.Uint64(key, value.Uint64())
+	case slog.KindFloat64:
+		return event.Float64(key, value.Float64())
+	case slog.KindBool:
+		return event.Bool(key, value.Bool())
+	case slog.KindDuration:
+		return event.Dur(key, value.Duration())
+	case slog.KindTime:
+		return event.Time(key, value.Time())
+	}
+
+	switch v := value.Any().(type) {
+	case error:
+		return event.AnErr(key, v)
+	case time.Duration:
+		return event.Dur(key, v)
+	case []byte:
+		return event.Bytes(key, v)
+	default:
+		return event.Interface(key, v)
+	}
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	if key == "" {
+		return prefix
+	}
+	return prefix + "." + key
+}
+
+func toZerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSlogLogger(out *bytes.Buffer, level zerolog.Level) *slog.Logger {
+	return slog.New(newSlogHandler(zerolog.New(out), NewLevelVar(level)))
+}
+
+func unmarshalLogLine(t *testing.T, out *bytes.Buffer) map[string]any {
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	return line
+}
+
+func TestUnit_SlogHandler_Handle_ExpectMessageLevelAndAttributes(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.TraceLevel)
+
+	log.Warn(
+		"hello",
+		slog.String("name", "John"),
+		slog.Int("age", 32),
+		slog.Bool("admin", true),
+		slog.Duration("elapsed", 2*time.Millisecond),
+		slog.Any("err", errors.New("some error")),
+	)
+
+	line := unmarshalLogLine(t, &out)
+	assert.Equal(t, "hello", line["message"])
+	assert.Equal(t, "warn", line["level"])
+	assert.Equal(t, "John", line["name"])
+	assert.Equal(t, float64(32), line["age"])
+	assert.Equal(t, true, line["admin"])
+	assert.Equal(t, float64(2), line["elapsed"])
+	assert.Equal(t, "some error", line["err"])
+	assert.Contains(t, line, "time")
+}
+
+func TestUnit_SlogHandler_Enabled_WhenLevelIsBelowLoggerLevel_ExpectNothingLogged(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.WarnLevel)
+
+	log.Info("hello")
+
+	assert.False(t, log.Enabled(t.Context(), slog.LevelInfo))
+	assert.True(t, log.Enabled(t.Context(), slog.LevelError))
+	assert.Empty(t, out.String())
+}
+
+func TestUnit_SlogHandler_With_ExpectAttributesInEveryRecord(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.TraceLevel).With(slog.String("request", "abc"))
+
+	log.Info("first")
+	first := unmarshalLogLine(t, &out)
+	out.Reset()
+	log.Info("second", slog.Int("id", 2))
+	second := unmarshalLogLine(t, &out)
+
+	assert.Equal(t, "abc", first["request"])
+	assert.Equal(t, "abc", second["request"])
+	assert.Equal(t, float64(2), second["id"])
+}
+
+func TestUnit_SlogHandler_With_DoesNotModifyParentLogger(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.TraceLevel)
+	_ = log.With(slog.String("request", "abc"))
+
+	log.Info("hello")
+
+	line := unmarshalLogLine(t, &out)
+	assert.NotContains(t, line, "request")
+}
+
+func TestUnit_SlogHandler_WithGroup_ExpectOnlyLaterAttributesInGroup(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.TraceLevel)
+
+	log.With(slog.Int("a", 1)).WithGroup("g").With(slog.Int("b", 2)).WithGroup("h").Info("hello", slog.Int("c", 3))
+
+	line := unmarshalLogLine(t, &out)
+	assert.Equal(t, float64(1), line["a"])
+	assert.Equal(t, float64(2), line["g.b"])
+	assert.Equal(t, float64(3), line["g.h.c"])
+}
+
+func TestUnit_SlogHandler_GroupAttribute_ExpectNestedKeys(t *testing.T) {
+	var out bytes.Buffer
+	log := newTestSlogLogger(&out, zerolog.TraceLevel)
+
+	log.Info(
+		"hello",
+		slog.Group("user", slog.String("name", "John")),
+		slog.Group("", slog.Int("inlined", 1)),
+		slog.Group("empty"),
+	)
+
+	line := unmarshalLogLine(t, &out)
+	assert.Equal(t, "John", line["user.name"])
+	assert.Equal(t, float64(1), line["inlined"])
+	assert.NotContains(t, line, "empty")
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+)
+
+const defaultApiKeyHeader = "X-Api-Key"
+
+// Principal identifies the caller authenticated by an API key.
+type Principal struct {
+	Id     string
+	Scopes []string
+}
+
+// ApiKeyLookup resolves the principal associated to an API key. It should
+// return ErrInvalidApiKey when the key is unknown: any other error is
+// considered as a failure to verify the key and results in a 500.
+type ApiKeyLookup func(ctx context.Context, key string) (Principal, error)
+
+type ApiKeyConfig struct {
+	Lookup ApiKeyLookup
+	// Header defaults to X-Api-Key.
+	Header string
+	// QueryParam allows to provide the key as a query parameter when the
+	// header is not set. It is disabled when empty.
+	QueryParam string
+}
+
+func ApiKeyAuth(lookup ApiKeyLookup) echo.MiddlewareFunc {
+	return ApiKeyAuthWithConfig(ApiKeyConfig{Lookup: lookup})
+}
+
+// ApiKeyAuthWithConfig authenticates requests with the API key they
+// provide. The principal is then available through ApiKeyPrincipal.
+// Requests without a valid key are rejected with a 401. It panics when no
+// lookup is provided.
+func ApiKeyAuthWithConfig(config ApiKeyConfig) echo.MiddlewareFunc {
+	if config.Lookup == nil {
+		panic("api key auth requires a lookup")
+	}
+	if config.Header == "" {
+		config.Header = defaultApiKeyHeader
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			key := c.Request().Header.Get(config.Header)
+			if key == "" && config.QueryParam != "" {
+				key = c.QueryParam(config.QueryParam)
+			}
+			if key == "" {
+				return ErrMissingApiKey
+			}
+
+			principal, err := config.Lookup(c.Request().Context(), key)
+			if stderrors.Is(err, ErrInvalidApiKey) {
+				return ErrInvalidApiKey
+			} else if err != nil {
+				return err
+			}
+
+			reqctx.Set(c, reqctx.WithPrincipal, principal)
+
+			return next(c)
+		}
+	}
+}
+
+// ApiKeyPrincipal returns the principal authenticated by the ApiKeyAuth
+// middleware for this request.
+func ApiKeyPrincipal(c *echo.Context) (Principal, bool) {
+	return reqctx.Principal[Principal](c.Request().Context())
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPrincipal = Principal{Id: "service-a", Scopes: []string{"read"}}
+
+func TestUnit_ApiKeyAuth_WhenNoKey_ExpectMissingApiKeyError(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return ApiKeyAuth(testApiKeyLookup)
+	})
+
+	err := callable(ctx)
+
+	assert.Equal(t, ErrMissingApiKey, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_ApiKeyAuth_WhenNoLookup_ExpectPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		ApiKeyAuthWithConfig(ApiKeyConfig{Header: "X-Key"})
+	})
+}
+
+func TestUnit_ApiKeyAuth_WhenKeyIsValid_ExpectPrincipalAvailable(t *testing.T) {
+	var actual Principal
+	next := func(c *echo.Context) error {
+		var ok bool
+		actual, ok = ApiKeyPrincipal(c)
+		assert.True(t, ok)
+		return nil
+	}
+	callable := ApiKeyAuth(testApiKeyLookup)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("X-Api-Key", "valid-key")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, testPrincipal, actual)
+}
+
+func TestUnit_ApiKeyAuth_WhenKeyIsInvalid_ExpectInvalidApiKeyError(t *testing.T) {
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := ApiKeyAuth(testApiKeyLookup)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("X-Api-Key", "invalid-key")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+
+	assert.Equal(t, ErrInvalidApiKey, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_ApiKeyAuth_WhenLookupFails_ExpectErrorReturned(t *testing.T) {
+	lookup := func(ctx context.Context, key string) (Principal, error) {
+		return Principal{}, fmt.Errorf("lookup failed")
+	}
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := ApiKeyAuth(lookup)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("X-Api-Key", "valid-key")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+
+	assert.Equal(t, fmt.Errorf("lookup failed"), err)
+}
+
+func TestUnit_ApiKeyAuthWithConfig_UsesCustomHeaderAndQueryParam(t *testing.T) {
+	config := ApiKeyConfig{
+		Lookup:     testApiKeyLookup,
+		Header:     "X-Custom-Key",
+		QueryParam: "key",
+	}
+
+	type testCase struct {
+		name    string
+		prepare func(req *http.Request)
+	}
+
+	tests := []testCase{
+		{
+			name: "header",
+			prepare: func(req *http.Request) {
+				req.Header.Set("X-Custom-Key", "valid-key")
+			},
+		},
+		{
+			name: "query param",
+			prepare: func(req *http.Request) {
+				req.URL.RawQuery = "key=valid-key"
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next, called := createTestEchoHandlerFuncWithCalledBoolean()
+			callable := ApiKeyAuthWithConfig(config)(next)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			tc.prepare(req)
+			ctx, _ := generateTestEchoContextFromRequest(req)
+
+			err := callable(ctx)
+
+			assert.Nil(t, err)
+			assert.True(t, *called)
+		})
+	}
+}
+
+func TestUnit_ApiKeyAuth_ErrorsAreConvertedToUnauthorized(t *testing.T) {
+	for _, err := range []error{ErrMissingApiKey, ErrInvalidApiKey} {
+		actual := wrapToHttpError(err)
+
+		assert.Equal(t, http.StatusUnauthorized, echo.StatusCode(actual))
+	}
+}
+
+func testApiKeyLookup(ctx context.Context, key string) (Principal, error) {
+	if key != "valid-key" {
+		return Principal{}, ErrInvalidApiKey
+	}
+	return testPrincipal, nil
+}
@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/labstack/echo/v5"
+)
+
+const defaultBodyDumpMaxSize = 4 * 1024
+
+const redactedValue = logger.RedactedValue
+
+// defaultDumpedContentTypes lists the textual content types: the other
+// ones are considered binary and are not dumped.
+var defaultDumpedContentTypes = []string{
+	"application/json",
+	"application/xml",
+	"application/x-www-form-urlencoded",
+	"text/",
+}
+
+// BodyRedactor returns the body to log. It receives at most the maximum
+// size of the dump so the body might be truncated.
+type BodyRedactor func(contentType string, body []byte) []byte
+
+type BodyDumpConfig struct {
+	Enabled bool
+	// MaxSize is the maximum number of bytes logged for each body. It
+	// defaults to 4 KB.
+	MaxSize int
+	// RedactFields lists the fields of JSON and form bodies which values
+	// are masked. The comparison ignores the case. It defaults to common
+	// sensitive fields such as password or token.
+	RedactFields []string
+	// RedactPatterns lists the expressions which matches are masked in
+	// the bodies (e.g. logger.EmailPattern), whatever their content type.
+	RedactPatterns []*regexp.Regexp
+	// Redactor replaces the default redaction based on RedactFields and
+	// RedactPatterns.
+	Redactor BodyRedactor
+	// ContentTypes lists the prefixes of the content types which are
+	// dumped. It defaults to JSON, XML, forms and text.
+	ContentTypes []string
+}
+
+// BodyDump logs the bodies of the request and the response for debugging
+// purposes. It should not be enabled permanently in production as it is
+// costly and might expose sensitive data not covered by the redaction.
+func BodyDump(config BodyDumpConfig) echo.MiddlewareFunc {
+	if config.MaxSize == 0 {
+		config.MaxSize = defaultBodyDumpMaxSize
+	}
+	if len(config.RedactFields) == 0 {
+		config.RedactFields = logger.DefaultRedactedFields
+	}
+	if config.Redactor == nil {
+		config.Redactor = redactBody(config.RedactFields, config.RedactPatterns)
+	}
+	if len(config.ContentTypes) == 0 {
+		config.ContentTypes = defaultDumpedContentTypes
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+
+			var reqBody []byte
+			var reqTruncated bool
+			reqContentType := req.Header.Get(echo.HeaderContentType)
+			if req.Body != nil && isDumpedContentType(reqContentType, config.ContentTypes) {
+				reqBody, reqTruncated = peekRequestBody(req, config.MaxSize)
+			}
+
+			dump := &bodyDumpWriter{maxSize: config.MaxSize}
+			resp, unwrapErr := echo.UnwrapResponse(c.Response())
+			if unwrapErr == nil {
+				dump.ResponseWriter = resp.ResponseWriter
+				resp.ResponseWriter = dump
+			}
+
+			err := next(c)
+
+			if unwrapErr == nil {
+				resp.ResponseWriter = dump.ResponseWriter
+			}
+
+			attrs := []any{}
+			if reqBody != nil {
+				attrs = append(
+					attrs,
+					slog.String("requestBody", string(config.Redactor(reqContentType, reqBody))),
+					slog.Bool("requestTruncated", reqTruncated),
+				)
+			}
+
+			respContentType := c.Response().Header().Get(echo.HeaderContentType)
+			if dump.body.Len() > 0 && isDumpedContentType(respContentType, config.ContentTypes) {
+				attrs = append(
+					attrs,
+					slog.String("responseBody", string(config.Redactor(respContentType, dump.body.Bytes()))),
+					slog.Bool("responseTruncated", dump.truncated),
+				)
+			}
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+			}
+
+			c.Logger().Info("Request body dump", attrs...)
+
+			return err
+		}
+	}
+}
+
+func redactBody(fields []string, patterns []*regexp.Regexp) BodyRedactor {
+	redactJson := RedactJsonFields(fields...)
+	redactForm := RedactFormFields(fields...)
+	redactor := logger.NewRedactor(logger.RedactionConfig{Patterns: patterns})
+
+	return func(contentType string, body []byte) []byte {
+		body = redactForm(contentType, redactJson(contentType, body))
+		if len(patterns) == 0 {
+			return body
+		}
+		return []byte(redactor.RedactString(string(body)))
+	}
+}
+
+// RedactJsonFields masks the values of the provided fields in JSON bodies.
+// Other bodies are returned unchanged.
+func RedactJsonFields(fields ...string) BodyRedactor {
+	if len(fields) == 0 {
+		return func(contentType string, body []byte) []byte {
+			return body
+		}
+	}
+
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	// Used for truncated bodies which can't be parsed.
+	fallback := regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+	return func(contentType string, body []byte) []byte {
+		if mediaType(contentType) != echo.MIMEApplicationJSON {
+			return body
+		}
+
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return fallback.ReplaceAll(body, []byte(`${1}"`+redactedValue+`"`))
+		}
+
+		out, err := json.Marshal(redactJsonValue(data, fields))
+		if err != nil {
+			return body
+		}
+		return out
+	}
+}
+
+// RedactFormFields masks the values of the provided fields in url encoded
+// form bodies. Other bodies are returned unchanged.
+func RedactFormFields(fields ...string) BodyRedactor {
+	return func(contentType string, body []byte) []byte {
+		if len(fields) == 0 || mediaType(contentType) != echo.MIMEApplicationForm {
+			return body
+		}
+
+		// The pairs are not parsed with url.ParseQuery to keep their order
+		// and to handle truncated bodies.
+		pairs := strings.Split(string(body), "&")
+		for i, pair := range pairs {
+			key, _, _ := strings.Cut(pair, "=")
+			if name, err := url.QueryUnescape(key); err == nil {
+				key = name
+			}
+			if slices.ContainsFunc(fields, func(field string) bool { return strings.EqualFold(field, key) }) {
+				pairs[i] = url.QueryEscape(key) + "=" + redactedValue
+			}
+		}
+		return []byte(strings.Join(pairs, "&"))
+	}
+}
+
+func redactJsonValue(value any, fields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if slices.ContainsFunc(fields, func(field string) bool { return strings.EqualFold(field, key) }) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJsonValue(child, fields)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactJsonValue(child, fields)
+		}
+	}
+
+	return value
+}
+
+// peekRequestBody reads at most maxSize bytes of the body and restores it
+// so that the handler can still read it entirely.
+func peekRequestBody(req *http.Request, maxSize int) ([]byte, bool) {
+	// Reading one more byte allows to know whether the body is truncated.
+	peeked, err := io.ReadAll(io.LimitReader(req.Body, int64(maxSize)+1))
+	req.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(peeked), req.Body),
+		Closer: req.Body,
+	}
+	if err != nil {
+		return nil, false
+	}
+
+	if len(peeked) > maxSize {
+		return peeked[:maxSize], true
+	}
+	return peeked, false
+}
+
+func isDumpedContentType(contentType string, dumped []string) bool {
+	if contentType == "" {
+		return false
+	}
+
+	for _, prefix := range dumped {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return parsed
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type bodyDumpWriter struct {
+	http.ResponseWriter
+	maxSize   int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *bodyDumpWriter) Write(data []byte) (int, error) {
+	remaining := max(w.maxSize-w.body.Len(), 0)
+	if remaining < len(data) {
+		w.truncated = true
+	}
+	w.body.Write(data[:min(remaining, len(data))])
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyDumpWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bodyDumpMessage struct {
+	RequestBody       string `json:"requestBody"`
+	RequestTruncated  bool   `json:"requestTruncated"`
+	ResponseBody      string `json:"responseBody"`
+	ResponseTruncated bool   `json:"responseTruncated"`
+}
+
+func TestUnit_BodyDump_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return BodyDump(BodyDumpConfig{})
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_BodyDump_LogsRequestAndResponseBodies(t *testing.T) {
+	var received string
+	next := func(c *echo.Context) error {
+		data, err := io.ReadAll(c.Request().Body)
+		require.Nil(t, err)
+		received = string(data)
+		return c.JSON(http.StatusOK, map[string]string{"name": "response"})
+	}
+
+	ctx := newBodyDumpContext(`{"name":"request"}`, echo.MIMEApplicationJSON)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(BodyDumpConfig{})(next)(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, `{"name":"request"}`, received)
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, `{"name":"request"}`, actual.RequestBody)
+	assert.False(t, actual.RequestTruncated)
+	assert.Equal(t, `{"name":"response"}`, strings.TrimSpace(actual.ResponseBody))
+	assert.False(t, actual.ResponseTruncated)
+}
+
+func TestUnit_BodyDump_RedactsSensitiveFields(t *testing.T) {
+	next := func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]any{"user": map[string]string{"token": "abc"}})
+	}
+
+	ctx := newBodyDumpContext(`{"name":"my-name","Password":"secret"}`, echo.MIMEApplicationJSON)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(BodyDumpConfig{})(next)(ctx)
+	require.Nil(t, err)
+
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, `{"Password":"***","name":"my-name"}`, actual.RequestBody)
+	assert.Equal(t, `{"user":{"token":"***"}}`, actual.ResponseBody)
+}
+
+func TestUnit_BodyDump_RedactsSensitiveFormFields(t *testing.T) {
+	next := func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	ctx := newBodyDumpContext("user=bob&password=hunter2", echo.MIMEApplicationForm)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(BodyDumpConfig{})(next)(ctx)
+	require.Nil(t, err)
+
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, "user=bob&password=***", actual.RequestBody)
+}
+
+func TestUnit_BodyDump_WhenRedactPatternsAreProvided_ExpectMatchesMasked(t *testing.T) {
+	next := func(c *echo.Context) error {
+		return c.String(http.StatusOK, "sent to jane@example.com")
+	}
+	config := BodyDumpConfig{
+		RedactPatterns: []*regexp.Regexp{logger.EmailPattern},
+	}
+
+	ctx := newBodyDumpContext(`{"email":"john@example.com","password":"secret"}`, echo.MIMEApplicationJSON)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(config)(next)(ctx)
+	require.Nil(t, err)
+
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, `{"email":"***","password":"***"}`, actual.RequestBody)
+	assert.Equal(t, "sent to ***", actual.ResponseBody)
+}
+
+func TestUnit_BodyDump_WhenBodyIsTooLarge_ExpectTruncatedAndRedacted(t *testing.T) {
+	var received string
+	next := func(c *echo.Context) error {
+		data, err := io.ReadAll(c.Request().Body)
+		require.Nil(t, err)
+		received = string(data)
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	body := `{"password":"secret","name":"a-very-long-name"}`
+	ctx := newBodyDumpContext(body, echo.MIMEApplicationJSON)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(BodyDumpConfig{MaxSize: 30})(next)(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, body, received)
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, `{"password":"***","name":"a`, actual.RequestBody)
+	assert.True(t, actual.RequestTruncated)
+}
+
+func TestUnit_BodyDump_ResponseTruncation(t *testing.T) {
+	testCases := map[string]struct {
+		size      int
+		truncated bool
+	}{
+		"smaller than max size": {size: 60, truncated: false},
+		"equal to max size":     {size: 100, truncated: false},
+		"larger than max size":  {size: 150, truncated: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			response := strings.Repeat("a", tc.size)
+			next := func(c *echo.Context) error {
+				return c.String(http.StatusOK, response)
+			}
+
+			ctx := newBodyDumpContext("request", echo.MIMETextPlain)
+			out := setTestLogger(ctx)
+
+			err := BodyDump(BodyDumpConfig{MaxSize: 100})(next)(ctx)
+			require.Nil(t, err)
+
+			actual := unmarshalBodyDump(t, out.Bytes())
+			assert.Equal(t, response[:min(tc.size, 100)], actual.ResponseBody)
+			assert.Equal(t, tc.truncated, actual.ResponseTruncated)
+		})
+	}
+}
+
+func TestUnit_BodyDump_WhenContentTypeIsBinary_ExpectBodyNotLogged(t *testing.T) {
+	next := func(c *echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", []byte{0x89, 0x50, 0x4e, 0x47})
+	}
+
+	ctx := newBodyDumpContext("binary", echo.MIMEOctetStream)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(BodyDumpConfig{})(next)(ctx)
+	require.Nil(t, err)
+
+	assert.NotContains(t, out.String(), "requestBody")
+	assert.NotContains(t, out.String(), "responseBody")
+}
+
+func TestUnit_BodyDump_WhenRedactorIsProvided_ExpectItToBeUsed(t *testing.T) {
+	next := func(c *echo.Context) error {
+		return c.String(http.StatusOK, "response")
+	}
+	config := BodyDumpConfig{
+		Redactor: func(contentType string, body []byte) []byte {
+			return []byte("redacted")
+		},
+	}
+
+	ctx := newBodyDumpContext("request", echo.MIMETextPlain)
+	out := setTestLogger(ctx)
+
+	err := BodyDump(config)(next)(ctx)
+	require.Nil(t, err)
+
+	actual := unmarshalBodyDump(t, out.Bytes())
+	assert.Equal(t, "redacted", actual.RequestBody)
+	assert.Equal(t, "redacted", actual.ResponseBody)
+}
+
+func TestUnit_RedactJsonFields_WhenContentTypeIsNotJson_ExpectBodyUnchanged(t *testing.T) {
+	redactor := RedactJsonFields("password")
+
+	actual := redactor(echo.MIMETextPlain, []byte(`{"password":"secret"}`))
+
+	assert.Equal(t, `{"password":"secret"}`, string(actual))
+}
+
+func TestUnit_RedactFormFields(t *testing.T) {
+	redactor := RedactFormFields("password", "api_key")
+
+	testCases := map[string]struct {
+		body     string
+		expected string
+	}{
+		"redacts fields":                 {body: "user=bob&password=hunter2", expected: "user=bob&password=***"},
+		"ignores case":                   {body: "PassWord=hunter2&user=bob", expected: "PassWord=***&user=bob"},
+		"handles encoded names":          {body: "api%5Fkey=abc&user=bob", expected: "api_key=***&user=bob"},
+		"handles truncated body":         {body: "user=bob&password=hun", expected: "user=bob&password=***"},
+		"handles fields without a value": {body: "password&user=bob", expected: "password=***&user=bob"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := redactor(echo.MIMEApplicationForm+"; charset=utf-8", []byte(tc.body))
+
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestUnit_RedactFormFields_WhenContentTypeIsNotForm_ExpectBodyUnchanged(t *testing.T) {
+	redactor := RedactFormFields("password")
+
+	actual := redactor(echo.MIMETextPlain, []byte("password=hunter2"))
+
+	assert.Equal(t, "password=hunter2", string(actual))
+}
+
+func newBodyDumpContext(body string, contentType string) *echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	return ctx
+}
+
+func unmarshalBodyDump(t *testing.T, out []byte) bodyDumpMessage {
+	var actual bodyDumpMessage
+	err := json.Unmarshal(out, &actual)
+	require.Nil(t, err)
+	return actual
+}
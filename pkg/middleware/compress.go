@@ -0,0 +1,272 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	gzipEncoding   = "gzip"
+	brotliEncoding = "br"
+
+	defaultCompressionMinSize = 1024
+)
+
+// defaultSkippedContentTypes lists the content types which are already
+// compressed or streamed: compressing them is either useless or breaks
+// the streaming.
+var defaultSkippedContentTypes = []string{
+	"text/event-stream",
+	"image/",
+	"video/",
+	"audio/",
+	"application/zip",
+	"application/gzip",
+	"application/octet-stream",
+}
+
+type CompressionConfig struct {
+	Enabled bool
+	// GzipLevel defaults to gzip.DefaultCompression when not set.
+	GzipLevel int
+	// EnableBrotli uses brotli for clients supporting it. BrotliLevel
+	// defaults to brotli.DefaultCompression when not set.
+	EnableBrotli bool
+	BrotliLevel  int
+	// MinSize is the size in bytes under which responses are not
+	// compressed. It defaults to 1 KB.
+	MinSize int
+	// SkipContentTypes lists the prefixes of the content types of the
+	// responses which should not be compressed. It is added to a default
+	// list containing streams and already compressed formats.
+	SkipContentTypes []string
+	SkipPaths        []string
+}
+
+// Compress compresses the responses with gzip or brotli depending on the
+// encodings accepted by the client. The decision is made when the first
+// bytes are written so that it can depend on the content type and size
+// of the response. Flushing a response which is not yet compressed sends
+// it uncompressed: this keeps streaming endpoints working.
+func Compress(config CompressionConfig) echo.MiddlewareFunc {
+	if config.GzipLevel == 0 {
+		config.GzipLevel = gzip.DefaultCompression
+	}
+	if config.BrotliLevel == 0 {
+		config.BrotliLevel = brotli.DefaultCompression
+	}
+	if config.MinSize == 0 {
+		config.MinSize = defaultCompressionMinSize
+	}
+	skippedContentTypes := append(slices.Clone(defaultSkippedContentTypes), config.SkipContentTypes...)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+			if req.Method == http.MethodHead || slices.Contains(config.SkipPaths, req.URL.Path) {
+				return next(c)
+			}
+
+			encoding := negotiateEncoding(req.Header.Get("Accept-Encoding"), config.EnableBrotli)
+			if encoding == "" {
+				return next(c)
+			}
+
+			resp, err := echo.UnwrapResponse(c.Response())
+			if err != nil {
+				return next(c)
+			}
+
+			resp.Header().Add("Vary", "Accept-Encoding")
+
+			cw := &compressWriter{
+				ResponseWriter:      resp.ResponseWriter,
+				encoding:            encoding,
+				config:              config,
+				skippedContentTypes: skippedContentTypes,
+			}
+			resp.ResponseWriter = cw
+			defer func() {
+				cw.close()
+				resp.ResponseWriter = cw.ResponseWriter
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// negotiateEncoding returns the preferred encoding accepted by the client
+// or an empty string if none is supported.
+func negotiateEncoding(acceptEncoding string, enableBrotli bool) string {
+	var gzipAccepted, brotliAccepted bool
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.ReplaceAll(strings.TrimSpace(params), " ", "") == "q=0" {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case gzipEncoding:
+			gzipAccepted = true
+		case brotliEncoding:
+			brotliAccepted = true
+		}
+	}
+
+	switch {
+	case brotliAccepted && enableBrotli:
+		return brotliEncoding
+	case gzipAccepted:
+		return gzipEncoding
+	default:
+		return ""
+	}
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	encoding            string
+	config              CompressionConfig
+	skippedContentTypes []string
+
+	code    int
+	buffer  bytes.Buffer
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.decided {
+		cw.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	// The status is only sent once we know whether the response is
+	// compressed as this changes the headers.
+	cw.code = code
+}
+
+func (cw *compressWriter) Write(data []byte) (int, error) {
+	if !cw.decided {
+		if !cw.compressible() {
+			if err := cw.decide(false); err != nil {
+				return 0, err
+			}
+		} else {
+			cw.buffer.Write(data)
+			if cw.buffer.Len() < cw.config.MinSize {
+				return len(data), nil
+			}
+
+			return len(data), cw.decide(true)
+		}
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(data)
+	}
+	return cw.ResponseWriter.Write(data)
+}
+
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		// Not enough data was written to be worth compressing: streaming
+		// responses are sent as is.
+		if err := cw.decide(false); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) compressible() bool {
+	if cw.code == http.StatusNoContent || cw.code == http.StatusNotModified {
+		return false
+	}
+
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	for _, skipped := range cw.skippedContentTypes {
+		if strings.HasPrefix(contentType, skipped) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decide sends the headers and the buffered data, compressed or not.
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+
+	if compress {
+		header := cw.Header()
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.newEncoder()
+	}
+
+	if cw.code != 0 {
+		cw.ResponseWriter.WriteHeader(cw.code)
+	}
+
+	if cw.buffer.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buffer.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buffer.Bytes())
+	}
+	cw.buffer.Reset()
+
+	return err
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == brotliEncoding {
+		return brotli.NewWriterLevel(cw.ResponseWriter, cw.config.BrotliLevel)
+	}
+
+	// An invalid level falls back to the default compression.
+	encoder, err := gzip.NewWriterLevel(cw.ResponseWriter, cw.config.GzipLevel)
+	if err != nil {
+		encoder = gzip.NewWriter(cw.ResponseWriter)
+	}
+	return encoder
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		// The response is smaller than the minimum size.
+		_ = cw.decide(false)
+	}
+
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+	}
+}
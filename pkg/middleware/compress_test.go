@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeBody = strings.Repeat("a", 2*defaultCompressionMinSize)
+
+func TestUnit_Compress_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return Compress(CompressionConfig{})
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Compress_WhenClientAcceptsGzip_ExpectCompressedResponse(t *testing.T) {
+	rw := callCompressedHandler(t, CompressionConfig{}, "gzip", textHandler(largeBody))
+
+	assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(rw.Body)
+	require.NoError(t, err, "Actual err: %v", err)
+	actual, err := io.ReadAll(reader)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, largeBody, string(actual))
+}
+
+func TestUnit_Compress_WhenBrotliEnabled_ExpectBrotliPreferred(t *testing.T) {
+	config := CompressionConfig{EnableBrotli: true}
+	rw := callCompressedHandler(t, config, "gzip, br", textHandler(largeBody))
+
+	assert.Equal(t, "br", rw.Header().Get("Content-Encoding"))
+	actual, err := io.ReadAll(brotli.NewReader(rw.Body))
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, largeBody, string(actual))
+}
+
+func TestUnit_Compress_WhenResponseIsSmall_ExpectUncompressedResponse(t *testing.T) {
+	rw := callCompressedHandler(t, CompressionConfig{}, "gzip", textHandler("small"))
+
+	assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	assert.Equal(t, "small", rw.Body.String())
+}
+
+func TestUnit_Compress_WhenClientDoesNotAcceptCompression_ExpectUncompressedResponse(t *testing.T) {
+	rw := callCompressedHandler(t, CompressionConfig{}, "gzip;q=0, deflate", textHandler(largeBody))
+
+	assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	assert.Equal(t, largeBody, rw.Body.String())
+}
+
+func TestUnit_Compress_WhenContentTypeIsSkipped_ExpectUncompressedResponse(t *testing.T) {
+	handler := func(c *echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", []byte(largeBody))
+	}
+
+	rw := callCompressedHandler(t, CompressionConfig{}, "gzip", handler)
+
+	assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	assert.Equal(t, largeBody, rw.Body.String())
+}
+
+func TestUnit_Compress_WhenResponseIsStreamed_ExpectUncompressedResponse(t *testing.T) {
+	handler := func(c *echo.Context) error {
+		c.Response().WriteHeader(http.StatusOK)
+		_, _ = c.Response().Write([]byte("data: event\n\n"))
+		http.NewResponseController(c.Response()).Flush()
+		return nil
+	}
+
+	rw := callCompressedHandler(t, CompressionConfig{}, "gzip", handler)
+
+	assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	assert.True(t, rw.Flushed)
+	assert.Equal(t, "data: event\n\n", rw.Body.String())
+}
+
+func TestUnit_Compress_WhenPathIsSkipped_ExpectUncompressedResponse(t *testing.T) {
+	config := CompressionConfig{SkipPaths: []string{"/"}}
+	rw := callCompressedHandler(t, config, "gzip", textHandler(largeBody))
+
+	assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	assert.Equal(t, largeBody, rw.Body.String())
+}
+
+func TestUnit_NegotiateEncoding(t *testing.T) {
+	assert.Equal(t, "gzip", negotiateEncoding("gzip, br", false))
+	assert.Equal(t, "br", negotiateEncoding("gzip, br", true))
+	assert.Equal(t, "gzip", negotiateEncoding("br;q=0, GZIP", true))
+	assert.Equal(t, "", negotiateEncoding("deflate", true))
+	assert.Equal(t, "", negotiateEncoding("", true))
+}
+
+func textHandler(body string) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		return c.String(http.StatusOK, body)
+	}
+}
+
+func callCompressedHandler(
+	t *testing.T,
+	config CompressionConfig,
+	acceptEncoding string,
+	handler echo.HandlerFunc,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := Compress(config)(handler)(ctx)
+	require.Nil(t, err)
+
+	return rw
+}
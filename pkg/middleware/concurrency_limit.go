@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/labstack/echo/v5"
+)
+
+const defaultConcurrencyRetryAfter = time.Second
+
+type ConcurrencyLimitConfig struct {
+	// Global caps the number of requests processed at the same time by
+	// all the routes. A value of 0 disables it.
+	Global int64
+	// PerRoute caps the number of requests processed at the same time by
+	// each route. A value of 0 disables it.
+	PerRoute int64
+	// RetryAfter is sent to the clients whose request was rejected. It
+	// defaults to 1 second.
+	RetryAfter time.Duration
+}
+
+func (c ConcurrencyLimitConfig) Enabled() bool {
+	return c.Global > 0 || c.PerRoute > 0
+}
+
+// ConcurrencyGauge describes the requests of a route (or of all of them)
+// and is meant to be exported as metrics.
+type ConcurrencyGauge struct {
+	InFlight int64
+	Rejected uint64
+}
+
+type ConcurrencyStats struct {
+	Global ConcurrencyGauge
+	// Routes are identified by their method and path, e.g. "GET /users/:id".
+	Routes map[string]ConcurrencyGauge
+}
+
+// ConcurrencyLimiter sheds load by rejecting requests with a 503 when too
+// many of them are already being processed. This protects the resources
+// shared by handlers (typically the database pool) from stampedes.
+type ConcurrencyLimiter struct {
+	config ConcurrencyLimitConfig
+	global gauge
+
+	lock   sync.RWMutex
+	routes map[string]*gauge
+}
+
+type gauge struct {
+	inFlight atomic.Int64
+	rejected atomic.Uint64
+}
+
+func NewConcurrencyLimiter(config ConcurrencyLimitConfig) *ConcurrencyLimiter {
+	if config.RetryAfter == 0 {
+		config.RetryAfter = defaultConcurrencyRetryAfter
+	}
+
+	return &ConcurrencyLimiter{
+		config: config,
+		routes: make(map[string]*gauge),
+	}
+}
+
+func (l *ConcurrencyLimiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			route := l.routeGauge(c.Request().Method + " " + c.Path())
+
+			if !route.acquire(l.config.PerRoute) {
+				return l.reject(c, route)
+			}
+			defer route.release()
+
+			if !l.global.acquire(l.config.Global) {
+				return l.reject(c, &l.global)
+			}
+			defer l.global.release()
+
+			return next(c)
+		}
+	}
+}
+
+func (l *ConcurrencyLimiter) Stats() ConcurrencyStats {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	stats := ConcurrencyStats{
+		Global: l.global.snapshot(),
+		Routes: make(map[string]ConcurrencyGauge, len(l.routes)),
+	}
+	for route, g := range l.routes {
+		stats.Routes[route] = g.snapshot()
+	}
+
+	return stats
+}
+
+func (l *ConcurrencyLimiter) routeGauge(route string) *gauge {
+	l.lock.RLock()
+	g, ok := l.routes[route]
+	l.lock.RUnlock()
+	if ok {
+		return g
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	g, ok = l.routes[route]
+	if !ok {
+		g = &gauge{}
+		l.routes[route] = g
+	}
+	return g
+}
+
+func (l *ConcurrencyLimiter) reject(c *echo.Context, g *gauge) error {
+	g.rejected.Add(1)
+
+	retryAfter := int(l.config.RetryAfter.Round(time.Second).Seconds())
+	c.Response().Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+
+	return echo.NewHTTPError(http.StatusServiceUnavailable, errors.PublicMessageOf(ErrTooManyInFlightRequests)).Wrap(ErrTooManyInFlightRequests)
+}
+
+func (g *gauge) acquire(limit int64) bool {
+	inFlight := g.inFlight.Add(1)
+	if limit > 0 && inFlight > limit {
+		g.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (g *gauge) release() {
+	g.inFlight.Add(-1)
+}
+
+func (g *gauge) snapshot() ConcurrencyGauge {
+	return ConcurrencyGauge{
+		InFlight: g.inFlight.Load(),
+		Rejected: g.rejected.Load(),
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_ConcurrencyLimiter_CallsNextMiddleware(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimitConfig{Global: 1})
+	callable, called, ctx := createCallableHandler(limiter.Middleware)
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_ConcurrencyLimiter_WhenGlobalLimitIsReached_ExpectServiceUnavailable(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimitConfig{Global: 1, RetryAfter: 3 * time.Second})
+	release := runBlockedRequest(t, limiter, "/first")
+	defer release()
+
+	callable, called, _ := createCallableHandler(limiter.Middleware)
+	ctx, rw := generateTestEchoContext()
+	ctx.SetPath("/second")
+
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, errors.PublicMessageOf(ErrTooManyInFlightRequests), http.StatusServiceUnavailable)
+	assert.False(t, *called)
+	assert.Equal(t, "3", rw.Header().Get("Retry-After"))
+}
+
+func TestUnit_ConcurrencyLimiter_WhenRouteLimitIsReached_ExpectOtherRoutesToBeServed(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimitConfig{PerRoute: 1})
+	release := runBlockedRequest(t, limiter, "/first")
+	defer release()
+
+	callable := limiter.Middleware()(func(c *echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	ctx, _ := generateTestEchoContext()
+	ctx.SetPath("/first")
+	err := callable(ctx)
+	assertIsHttpErrorWithMessageAndCode(t, err, errors.PublicMessageOf(ErrTooManyInFlightRequests), http.StatusServiceUnavailable)
+
+	ctx, _ = generateTestEchoContext()
+	ctx.SetPath("/second")
+	err = callable(ctx)
+	assert.Nil(t, err)
+}
+
+func TestUnit_ConcurrencyLimiter_Stats(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimitConfig{PerRoute: 1})
+	release := runBlockedRequest(t, limiter, "/first")
+
+	callable, _, ctx := createCallableHandler(limiter.Middleware)
+	ctx.SetPath("/first")
+	err := callable(ctx)
+	require.NotNil(t, err)
+
+	actual := limiter.Stats()
+	assert.Equal(t, ConcurrencyGauge{InFlight: 1}, actual.Global)
+	assert.Equal(t, ConcurrencyGauge{InFlight: 1, Rejected: 1}, actual.Routes["GET /first"])
+
+	release()
+
+	actual = limiter.Stats()
+	assert.Equal(t, ConcurrencyGauge{}, actual.Global)
+	assert.Equal(t, ConcurrencyGauge{Rejected: 1}, actual.Routes["GET /first"])
+}
+
+// runBlockedRequest starts a request on the provided path which does not
+// complete until the returned function is called.
+func runBlockedRequest(t *testing.T, limiter *ConcurrencyLimiter, path string) func() {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	done := make(chan struct{})
+
+	callable := limiter.Middleware()(func(c *echo.Context) error {
+		close(started)
+		<-unblock
+		return c.NoContent(http.StatusOK)
+	})
+
+	ctx, _ := generateTestEchoContext()
+	ctx.SetPath(path)
+
+	go func() {
+		defer close(done)
+		assert.Nil(t, callable(ctx))
+	}()
+	<-started
+
+	return func() {
+		close(unblock)
+		<-done
+	}
+}
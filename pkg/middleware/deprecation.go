@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+	linkHeader        = "Link"
+)
+
+type DeprecationConfig struct {
+	// Since is the date at which the routes were deprecated. When not set
+	// the routes are just flagged as deprecated.
+	Since time.Time
+	// Sunset is the date after which the routes will stop responding. It
+	// is not sent when not set.
+	Sunset time.Time
+	// Link points to the documentation explaining the deprecation, e.g.
+	// a migration guide to the newer version.
+	Link string
+}
+
+// Deprecation adds the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers to the responses so that clients know they should migrate.
+func Deprecation(config DeprecationConfig) echo.MiddlewareFunc {
+	deprecation := "true"
+	if !config.Since.IsZero() {
+		deprecation = fmt.Sprintf("@%d", config.Since.Unix())
+	}
+
+	var sunset string
+	if !config.Sunset.IsZero() {
+		sunset = config.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	var link string
+	if config.Link != "" {
+		link = fmt.Sprintf(`<%s>; rel="deprecation"`, config.Link)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			header := c.Response().Header()
+			header.Set(deprecationHeader, deprecation)
+			if sunset != "" {
+				header.Set(sunsetHeader, sunset)
+			}
+			if link != "" {
+				header.Add(linkHeader, link)
+			}
+
+			return next(c)
+		}
+	}
+}
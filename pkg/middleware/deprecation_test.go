@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Deprecation_CallsNextMiddleware(t *testing.T) {
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := Deprecation(DeprecationConfig{})(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Deprecation_WhenNoDateIsSet_ExpectOnlyDeprecationHeader(t *testing.T) {
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := Deprecation(DeprecationConfig{})(next)
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, "true", rw.Header().Get(deprecationHeader))
+	assert.Empty(t, rw.Header().Get(sunsetHeader))
+	assert.Empty(t, rw.Header().Get(linkHeader))
+}
+
+func TestUnit_Deprecation_SetsAllHeaders(t *testing.T) {
+	config := DeprecationConfig{
+		Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC),
+		Link:   "https://example.com/migration",
+	}
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := Deprecation(config)(next)
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, "@1767225600", rw.Header().Get(deprecationHeader))
+	assert.Equal(t, "Tue, 30 Jun 2026 12:00:00 GMT", rw.Header().Get(sunsetHeader))
+	assert.Equal(t, `<https://example.com/migration>; rel="deprecation"`, rw.Header().Get(linkHeader))
+}
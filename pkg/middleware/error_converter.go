@@ -1,17 +1,77 @@
 package middleware
 
 import (
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
 	"github.com/labstack/echo/v5"
 )
 
+type ErrorConverterConfig struct {
+	// ErrorHook is called for the errors resulting in a server error (5xx)
+	// so that they can be reported to an external service. Panics are not
+	// reported as they are already handled by the Recover middleware.
+	ErrorHook logger.ErrorHook
+}
+
 func ErrorConverter() echo.MiddlewareFunc {
+	return ErrorConverterWithConfig(ErrorConverterConfig{})
+}
+
+func ErrorConverterWithConfig(config ErrorConverterConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			if err := next(c); err != nil {
-				return wrapToHttpError(err)
+				converted := wrapToHttpError(err)
+				if isServerError(err, converted) {
+					logServerError(c, err, converted)
+					if config.ErrorHook != nil {
+						reportServerError(c, err, converted, config.ErrorHook)
+					}
+				}
+				return translateError(c, err, converted)
 			}
 
 			return nil
 		}
 	}
 }
+
+// isServerError returns true for the errors resulting in a server error
+// (5xx) which are not panics: those are handled by the Recover middleware.
+func isServerError(err error, converted error) bool {
+	if echo.StatusCode(converted) < http.StatusInternalServerError {
+		return false
+	}
+
+	var panicErr *PanicError
+	return !stderrors.As(err, &panicErr)
+}
+
+// logServerError logs the full chain of the error as the response only
+// contains its public message.
+func logServerError(c *echo.Context, err error, converted error) {
+	c.Logger().Error(
+		"Request failed",
+		slog.String("method", c.Request().Method),
+		slog.String("uri", pathFromRequest(c.Request())),
+		slog.Int("status", echo.StatusCode(converted)),
+		slog.Any("error", err),
+	)
+}
+
+func reportServerError(c *echo.Context, err error, converted error, hook logger.ErrorHook) {
+	hook(c.Request().Context(), logger.ErrorEvent{
+		Time:    time.Now(),
+		Message: err.Error(),
+		Attrs: []slog.Attr{
+			slog.String("method", c.Request().Method),
+			slog.String("uri", pathFromRequest(c.Request())),
+			slog.Int("status", echo.StatusCode(converted)),
+		},
+		Err: err,
+	})
+}
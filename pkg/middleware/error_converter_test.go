@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"testing"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
 	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnit_ErrorConverter_CallsNextMiddleware(t *testing.T) {
@@ -26,7 +31,7 @@ func TestUnit_ErrorConverter_WrapsUnknownErrorIntoHttpError(t *testing.T) {
 
 	err := callable(ctx)
 
-	assertIsHttpErrorWithMessageAndCode(t, err, "some error", http.StatusInternalServerError)
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
 }
 
 func TestUnit_ErrorConverter_WrapsErrorWithCodeIntoHttpError(t *testing.T) {
@@ -37,7 +42,76 @@ func TestUnit_ErrorConverter_WrapsErrorWithCodeIntoHttpError(t *testing.T) {
 
 	err := callable(ctx)
 
-	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred. Code: 400", http.StatusInternalServerError)
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
+}
+
+func TestUnit_ErrorConverter_WhenServerError_ExpectFullChainToBeLogged(t *testing.T) {
+	someErr := errors.Wrap(fmt.Errorf("connection refused"), "failed to fetch user")
+	callable := ErrorConverter()(createErrorHandler(someErr))
+	ctx, out := generateTestEchoContextWithLogger()
+
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
+	assert.Contains(t, out.String(), `"msg":"Request failed"`)
+	assert.Contains(t, out.String(), "failed to fetch user")
+	assert.Contains(t, out.String(), "connection refused")
+}
+
+func TestUnit_ErrorConverter_WhenClientError_ExpectNoLog(t *testing.T) {
+	callable := ErrorConverter()(createErrorHandler(ErrMissingApiKey))
+	ctx, out := generateTestEchoContextWithLogger()
+
+	err := callable(ctx)
+
+	require.Error(t, err)
+	assert.Empty(t, out.String())
+}
+
+func TestUnit_ErrorConverter_WhenServerError_ExpectErrorHookCalled(t *testing.T) {
+	hook, events := newErrorHookRecorder()
+	someErr := fmt.Errorf("some error")
+	callable := ErrorConverterWithConfig(ErrorConverterConfig{ErrorHook: hook})(createErrorHandler(someErr))
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
+	require.Len(t, *events, 1)
+	assert.Equal(t, "some error", (*events)[0].Message)
+	assert.Equal(t, someErr, (*events)[0].Err)
+	assert.Contains(t, (*events)[0].Attrs, slog.Int("status", http.StatusInternalServerError))
+}
+
+func TestUnit_ErrorConverter_WhenClientError_ExpectErrorHookNotCalled(t *testing.T) {
+	hook, events := newErrorHookRecorder()
+	callable := ErrorConverterWithConfig(ErrorConverterConfig{ErrorHook: hook})(createErrorHandler(echo.ErrNotFound))
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.NotNil(t, err)
+	assert.Empty(t, *events)
+}
+
+func TestUnit_ErrorConverter_WhenPanic_ExpectErrorHookNotCalled(t *testing.T) {
+	hook, events := newErrorHookRecorder()
+	next, _ := createPanicHandler()
+	callable := ErrorConverterWithConfig(ErrorConverterConfig{ErrorHook: hook})(Recover()(next))
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.NotNil(t, err)
+	assert.Empty(t, *events)
+}
+
+func newErrorHookRecorder() (logger.ErrorHook, *[]logger.ErrorEvent) {
+	var events []logger.ErrorEvent
+	hook := func(ctx context.Context, event logger.ErrorEvent) {
+		events = append(events, event)
+	}
+	return hook, &events
 }
 
 func createErrorHandler(err error) echo.HandlerFunc {
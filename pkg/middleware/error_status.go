@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
+
+type errorStatus struct {
+	status int
+	// message replaces the message of the error in the response when
+	// it is not empty. This allows to not leak internal details.
+	message string
+}
+
+var (
+	errorStatusLock sync.RWMutex
+	errorStatuses   = map[errors.ErrorCode]errorStatus{}
+)
+
+// RegisterErrorStatus defines the HTTP status returned when a handler
+// fails with an error having the provided code. When the public message
+// is not empty it is used in the response instead of the message of the
+// error. It takes precedence over the status of the code in the errors
+// catalog (see errors.Register), which defaults to 500.
+// This is typically called once when the service starts.
+func RegisterErrorStatus(code errors.ErrorCode, status int, publicMessage string) {
+	errorStatusLock.Lock()
+	defer errorStatusLock.Unlock()
+
+	errorStatuses[code] = errorStatus{
+		status:  status,
+		message: publicMessage,
+	}
+}
+
+// RegisterErrorStatusFor is similar to RegisterErrorStatus but uses the
+// code of the provided error. This is useful for errors which code is not
+// exported such as db.ErrNoMatchingRows. It does nothing if the error does
+// not have a code.
+func RegisterErrorStatusFor(err error, status int, publicMessage string) {
+	if code, ok := errors.CodeOf(err); ok {
+		RegisterErrorStatus(code, status, publicMessage)
+	}
+}
+
+func lookupErrorStatus(code errors.ErrorCode) errorStatus {
+	errorStatusLock.RLock()
+	defer errorStatusLock.RUnlock()
+
+	if status, ok := errorStatuses[code]; ok {
+		return status
+	}
+
+	return errorStatus{status: errors.HttpStatusOf(code)}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleErrorCode errors.ErrorCode = 9999
+
+func TestUnit_RegisterErrorStatus_ExpectStatusToBeUsed(t *testing.T) {
+	registerTestErrorStatus(t, sampleErrorCode, http.StatusNotFound, "")
+
+	actual := wrapToHttpError(errors.FromCode(sampleErrorCode))
+
+	assertIsHttpErrorWithMessageAndCode(
+		t,
+		actual,
+		"an unexpected error occurred",
+		http.StatusNotFound,
+	)
+}
+
+func TestUnit_RegisterErrorStatus_WhenPublicMessageIsSet_ExpectItToReplaceErrorMessage(t *testing.T) {
+	registerTestErrorStatus(t, sampleErrorCode, http.StatusBadRequest, "invalid input")
+
+	actual := wrapToHttpError(errors.FromCodeAndDetails(sampleErrorCode, "internal details"))
+
+	assertIsHttpErrorWithMessageAndCode(t, actual, "invalid input", http.StatusBadRequest)
+}
+
+func TestUnit_RegisterErrorStatus_WhenErrorIsWrapped_ExpectStatusToBeUsed(t *testing.T) {
+	registerTestErrorStatus(t, sampleErrorCode, http.StatusConflict, "conflict")
+
+	err := fmt.Errorf("failed to update: %w", errors.FromCode(sampleErrorCode))
+	actual := wrapToHttpError(err)
+
+	assertIsHttpErrorWithMessageAndCode(t, actual, "conflict", http.StatusConflict)
+}
+
+func TestUnit_RegisterErrorStatus_WhenErrorExposesItsCode_ExpectStatusToBeUsed(t *testing.T) {
+	registerTestErrorStatus(t, sampleErrorCode, http.StatusConflict, "conflict")
+
+	// Similar to db.DatabaseError which is not an ErrorWithCode.
+	err := fmt.Errorf("failed to insert: %w", &codedTestError{code: sampleErrorCode})
+	actual := wrapToHttpError(err)
+
+	assertIsHttpErrorWithMessageAndCode(t, actual, "conflict", http.StatusConflict)
+}
+
+func TestUnit_RegisterErrorStatusFor_ExpectCodeOfErrorToBeRegistered(t *testing.T) {
+	err := errors.FromCode(sampleErrorCode)
+	t.Cleanup(func() { unregisterErrorStatus(sampleErrorCode) })
+
+	RegisterErrorStatusFor(err, http.StatusNotFound, "not found")
+
+	actual := wrapToHttpError(err)
+
+	assertIsHttpErrorWithMessageAndCode(t, actual, "not found", http.StatusNotFound)
+}
+
+func TestUnit_LookupErrorStatus_WhenCodeIsNotRegistered_ExpectInternalServerError(t *testing.T) {
+	actual := lookupErrorStatus(sampleErrorCode)
+
+	assert.Equal(t, http.StatusInternalServerError, actual.status)
+	assert.Empty(t, actual.message)
+}
+
+type codedTestError struct {
+	code errors.ErrorCode
+}
+
+func (e *codedTestError) ErrorCode() errors.ErrorCode {
+	return e.code
+}
+
+func (e *codedTestError) Error() string {
+	return "coded error"
+}
+
+func registerTestErrorStatus(t *testing.T, code errors.ErrorCode, status int, message string) {
+	t.Helper()
+
+	RegisterErrorStatus(code, status, message)
+	t.Cleanup(func() { unregisterErrorStatus(code) })
+}
+
+func unregisterErrorStatus(code errors.ErrorCode) {
+	errorStatusLock.Lock()
+	defer errorStatusLock.Unlock()
+	delete(errorStatuses, code)
+}
+
+func TestUnit_LookupErrorStatus_WhenCodeIsInCatalog_ExpectCatalogStatus(t *testing.T) {
+	actual := lookupErrorStatus(errMissingToken)
+
+	assert.Equal(t, http.StatusUnauthorized, actual.status)
+	assert.Empty(t, actual.message)
+}
+
+func TestUnit_RegisterErrorStatus_WhenCodeIsInCatalog_ExpectRegisteredStatusToTakePrecedence(t *testing.T) {
+	registerTestErrorStatus(t, errMissingToken, http.StatusForbidden, "forbidden")
+
+	actual := lookupErrorStatus(errMissingToken)
+
+	assert.Equal(t, http.StatusForbidden, actual.status)
+	assert.Equal(t, "forbidden", actual.message)
+}
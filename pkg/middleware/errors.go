@@ -1,11 +1,46 @@
 package middleware
 
-import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+import (
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
 
 const (
-	errUncaughtPanic errors.ErrorCode = 400
+	errUncaughtPanic  errors.ErrorCode = 400
+	errRequestTimeout errors.ErrorCode = 401
+	errMissingToken   errors.ErrorCode = 402
+	errInvalidToken   errors.ErrorCode = 403
+	errMissingApiKey  errors.ErrorCode = 404
+	errInvalidApiKey  errors.ErrorCode = 405
+
+	errTooManyInFlightRequests errors.ErrorCode = 406
+	errUnauthenticated         errors.ErrorCode = 407
 )
 
 var (
-	ErrUncaughtPanic = errors.FromCode(errUncaughtPanic)
+	ErrUncaughtPanic  = errors.FromCode(errUncaughtPanic)
+	ErrRequestTimeout = errors.FromCode(errRequestTimeout)
+	ErrMissingToken   = errors.FromCode(errMissingToken)
+	ErrInvalidToken   = errors.FromCode(errInvalidToken)
+	ErrMissingApiKey  = errors.FromCode(errMissingApiKey)
+	ErrInvalidApiKey  = errors.FromCode(errInvalidApiKey)
+
+	ErrTooManyInFlightRequests = errors.FromCode(errTooManyInFlightRequests)
+	ErrUnauthenticated         = errors.FromCode(errUnauthenticated)
 )
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errUncaughtPanic, Name: "middleware.uncaught_panic"},
+		{Code: errRequestTimeout, Name: "middleware.request_timeout", Message: "request timeout", HttpStatus: http.StatusGatewayTimeout},
+		{Code: errMissingToken, Name: "middleware.missing_token", Message: "missing token", HttpStatus: http.StatusUnauthorized},
+		{Code: errInvalidToken, Name: "middleware.invalid_token", Message: "invalid token", HttpStatus: http.StatusUnauthorized},
+		{Code: errMissingApiKey, Name: "middleware.missing_api_key", Message: "missing api key", HttpStatus: http.StatusUnauthorized},
+		{Code: errInvalidApiKey, Name: "middleware.invalid_api_key", Message: "invalid api key", HttpStatus: http.StatusUnauthorized},
+		{Code: errTooManyInFlightRequests, Name: "middleware.too_many_in_flight_requests", Message: "too many requests", HttpStatus: http.StatusServiceUnavailable},
+		{Code: errUnauthenticated, Name: "middleware.unauthenticated", Message: "authentication required", HttpStatus: http.StatusUnauthorized},
+	} {
+		errors.Register(info)
+	}
+}
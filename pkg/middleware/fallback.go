@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+type degradable interface {
+	MarkDegraded()
+}
+
+// Fallback calls the provided handler when the next one fails because of
+// an incident, that is when it times out or reports that the service is
+// unavailable. The response is then flagged as degraded in the envelope.
+func Fallback(fallback echo.HandlerFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+
+			err := next(c)
+			if err == nil || !shouldFallback(err) || isCommitted(c) {
+				return err
+			}
+
+			c.Logger().Warn("Serving degraded response", slog.Any("error", err))
+
+			// The context of the request might have been cancelled by the
+			// failed handler: the fallback should not be impacted by it.
+			c.SetRequest(req)
+			markDegraded(c)
+
+			return fallback(c)
+		}
+	}
+}
+
+func shouldFallback(err error) bool {
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	code := echo.StatusCode(err)
+	return code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+func markDegraded(c *echo.Context) {
+	resp, err := echo.UnwrapResponse(c.Response())
+	if err != nil {
+		return
+	}
+
+	if rw, ok := resp.ResponseWriter.(degradable); ok {
+		rw.MarkDegraded()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Fallback_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return Fallback(createErrorHandler(fmt.Errorf("fallback called")))
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Fallback_WhenErrorIsNotAnIncident_ExpectErrorReturned(t *testing.T) {
+	fallback, called := createTestEchoHandlerFuncWithCalledBoolean()
+	next := createErrorHandler(fmt.Errorf("some error"))
+
+	callable := Fallback(fallback)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.Equal(t, fmt.Errorf("some error"), err)
+	assert.False(t, *called)
+}
+
+func TestUnit_Fallback_WhenIncident_ExpectFallbackCalled(t *testing.T) {
+	type testCase struct {
+		name string
+		err  error
+	}
+
+	tests := []testCase{
+		{name: "deadline exceeded", err: context.DeadlineExceeded},
+		{name: "gateway timeout", err: echo.NewHTTPError(http.StatusGatewayTimeout, "timeout")},
+		{name: "service unavailable", err: echo.ErrServiceUnavailable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fallback, called := createTestEchoHandlerFuncWithCalledBoolean()
+			next := createErrorHandler(tc.err)
+
+			callable := Fallback(fallback)(next)
+			ctx, rw := generateTestEchoContext()
+
+			err := callable(ctx)
+
+			assert.Nil(t, err)
+			assert.True(t, *called)
+			assert.Equal(t, http.StatusOK, rw.Code)
+		})
+	}
+}
+
+func TestUnit_Fallback_RestoresRequestContext(t *testing.T) {
+	var fallbackCtxErr error
+	fallback := func(c *echo.Context) error {
+		fallbackCtxErr = c.Request().Context().Err()
+		return nil
+	}
+	next := func(c *echo.Context) error {
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		cancel()
+		c.SetRequest(c.Request().WithContext(ctx))
+		return echo.ErrServiceUnavailable
+	}
+
+	callable := Fallback(fallback)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	require.Nil(t, err)
+	assert.Nil(t, fallbackCtxErr)
+}
+
+func TestUnit_Fallback_MarksEnvelopeAsDegraded(t *testing.T) {
+	fallback := func(c *echo.Context) error {
+		return c.String(http.StatusOK, "cached")
+	}
+	next := createErrorHandler(echo.ErrServiceUnavailable)
+
+	callable := ResponseEnvelope()(Fallback(fallback)(next))
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	require.Nil(t, err)
+	assert.Regexp(t, `"status":"DEGRADED","details":"cached"`, rw.Body.String())
+}
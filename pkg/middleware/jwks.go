@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultJwksRefreshInterval = time.Hour
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// jwksKeySet caches the RSA keys published at a JWKS endpoint. The keys
+// are fetched again when they are too old or when a token references an
+// unknown key, which happens when the keys are rotated. To avoid hitting
+// the endpoint for each token with a bogus key identifier, the refresh
+// happens at most once per minimum interval.
+type jwksKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	minInterval     time.Duration
+
+	lock        sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+}
+
+func newJwksKeySet(url string, client *http.Client, refreshInterval time.Duration) *jwksKeySet {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if refreshInterval == 0 {
+		refreshInterval = defaultJwksRefreshInterval
+	}
+
+	return &jwksKeySet{
+		url:             url,
+		client:          client,
+		refreshInterval: refreshInterval,
+		minInterval:     min(time.Minute, refreshInterval),
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (ks *jwksKeySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	key, ok := ks.keys[kid]
+	elapsed := time.Since(ks.lastRefresh)
+
+	needsRefresh := elapsed >= ks.refreshInterval || (!ok && elapsed >= ks.minInterval)
+	if needsRefresh {
+		if err := ks.refresh(ctx); err != nil {
+			// Keep using the cached keys if the endpoint is unavailable.
+			if !ok {
+				return nil, err
+			}
+			return key, nil
+		}
+		key, ok = ks.keys[kid]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (ks *jwksKeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch keys from %s: %s", ks.url, resp.Status)
+	}
+
+	var set jsonWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+
+		key, err := parseRsaPublicKey(jwk)
+		if err != nil {
+			return err
+		}
+		keys[jwk.Kid] = key
+	}
+
+	ks.keys = keys
+	ks.lastRefresh = time.Now()
+
+	return nil
+}
+
+func parseRsaPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus for key %q: %w", jwk.Kid, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent for key %q: %w", jwk.Kid, err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_JwksKeySet_FetchesKeys(t *testing.T) {
+	key := generateRsaKey(t)
+	server := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	ks := newJwksKeySet(server.URL, nil, 0)
+
+	actual, err := ks.key(t.Context(), "key-1")
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, key.PublicKey.Equal(actual))
+}
+
+func TestUnit_JwksKeySet_CachesKeys(t *testing.T) {
+	key := generateRsaKey(t)
+	server := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	ks := newJwksKeySet(server.URL, nil, 0)
+
+	_, err := ks.key(t.Context(), "key-1")
+	require.NoError(t, err, "Actual err: %v", err)
+	_, err = ks.key(t.Context(), "key-1")
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, int32(1), server.calls.Load())
+}
+
+func TestUnit_JwksKeySet_WhenKeyIsUnknown_ExpectNoRefreshBeforeMinInterval(t *testing.T) {
+	key := generateRsaKey(t)
+	server := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	ks := newJwksKeySet(server.URL, nil, 0)
+
+	_, err := ks.key(t.Context(), "key-1")
+	require.NoError(t, err, "Actual err: %v", err)
+	_, err = ks.key(t.Context(), "key-2")
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), server.calls.Load())
+}
+
+func TestUnit_JwksKeySet_WhenKeysAreOld_ExpectRefresh(t *testing.T) {
+	key := generateRsaKey(t)
+	server := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	ks := newJwksKeySet(server.URL, nil, 10*time.Millisecond)
+
+	_, err := ks.key(t.Context(), "key-1")
+	require.NoError(t, err, "Actual err: %v", err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = ks.key(t.Context(), "key-1")
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, int32(2), server.calls.Load())
+}
+
+type testJwksServer struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newTestJwksServer(t *testing.T, keys map[string]*rsa.PublicKey) *testJwksServer {
+	t.Helper()
+
+	var set jsonWebKeySet
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kid: kid,
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	out := &testJwksServer{}
+	out.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		out.calls.Add(1)
+		// Voluntarily ignoring errors: the test would fail anyway.
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(out.Close)
+
+	return out
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type JwtConfig struct {
+	// HmacSecret validates tokens signed with HS256, HS384 or HS512.
+	HmacSecret []byte
+	// RsaPublicKey validates tokens signed with RS256, RS384 or RS512.
+	RsaPublicKey *rsa.PublicKey
+	// JwksUrl is the endpoint publishing the RSA keys used to sign the
+	// tokens. The key is selected with the kid header of the token and
+	// takes precedence over RsaPublicKey.
+	JwksUrl             string
+	JwksRefreshInterval time.Duration
+	HttpClient          *http.Client
+
+	// Issuer and Audience are verified when they are set.
+	Issuer   string
+	Audience string
+}
+
+// JwtAuth validates the bearer token provided in the Authorization header
+// and makes its claims available through JwtClaims. Requests without a
+// valid token are rejected with a 401. It panics when no key is configured
+// as no token could be validated.
+func JwtAuth(config JwtConfig) echo.MiddlewareFunc {
+	if len(config.HmacSecret) == 0 && config.RsaPublicKey == nil && config.JwksUrl == "" {
+		panic("jwt auth requires a HMAC secret, a RSA public key or a JWKS url")
+	}
+
+	var keys *jwksKeySet
+	if config.JwksUrl != "" {
+		keys = newJwksKeySet(config.JwksUrl, config.HttpClient, config.JwksRefreshInterval)
+	}
+
+	parser := jwt.NewParser(buildParserOptions(config, keys)...)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			raw, ok := bearerToken(c.Request())
+			if !ok {
+				return ErrMissingToken
+			}
+
+			keyFunc := func(token *jwt.Token) (any, error) {
+				switch token.Method.(type) {
+				case *jwt.SigningMethodHMAC:
+					// An empty secret would accept tokens forged by anyone.
+					if len(config.HmacSecret) > 0 {
+						return config.HmacSecret, nil
+					}
+				case *jwt.SigningMethodRSA:
+					kid, _ := token.Header["kid"].(string)
+					if keys != nil && kid != "" {
+						return keys.key(c.Request().Context(), kid)
+					}
+					if config.RsaPublicKey != nil {
+						return config.RsaPublicKey, nil
+					}
+				}
+
+				return nil, fmt.Errorf("no key to verify %s token", token.Method.Alg())
+			}
+
+			claims := jwt.MapClaims{}
+			if _, err := parser.ParseWithClaims(raw, claims, keyFunc); err != nil {
+				c.Logger().Debug("Rejected token", slog.Any("error", err))
+				return ErrInvalidToken
+			}
+
+			reqctx.Set(c, reqctx.WithPrincipal, claims)
+
+			return next(c)
+		}
+	}
+}
+
+// JwtClaims returns the claims of the token validated by the JwtAuth
+// middleware for this request.
+func JwtClaims(c *echo.Context) (jwt.MapClaims, bool) {
+	return reqctx.Principal[jwt.MapClaims](c.Request().Context())
+}
+
+func buildParserOptions(config JwtConfig, keys *jwksKeySet) []jwt.ParserOption {
+	var methods []string
+	if len(config.HmacSecret) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if config.RsaPublicKey != nil || keys != nil {
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+
+	// Restricting the methods prevents algorithm confusion attacks, e.g.
+	// a token signed with HMAC using the public RSA key as secret.
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods)}
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+
+	return opts
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	header := req.Header.Get(authorizationHeader)
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return header[len(bearerPrefix):], true
+}
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHmacSecret = []byte("my-secret")
+
+func TestUnit_JwtAuth_WhenNoToken_ExpectMissingTokenError(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return JwtAuth(JwtConfig{HmacSecret: testHmacSecret})
+	})
+
+	err := callable(ctx)
+
+	assert.Equal(t, ErrMissingToken, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenHmacTokenIsValid_ExpectClaimsAvailable(t *testing.T) {
+	token := signHmacToken(t, jwt.MapClaims{"sub": "user"}, testHmacSecret)
+
+	var claims jwt.MapClaims
+	next := func(c *echo.Context) error {
+		var ok bool
+		claims, ok = JwtClaims(c)
+		assert.True(t, ok)
+		return nil
+	}
+	callable := JwtAuth(JwtConfig{HmacSecret: testHmacSecret})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	require.Nil(t, err)
+	assert.Equal(t, "user", claims["sub"])
+}
+
+func TestUnit_JwtAuth_WhenHmacSecretIsWrong_ExpectInvalidTokenError(t *testing.T) {
+	token := signHmacToken(t, jwt.MapClaims{"sub": "user"}, []byte("other-secret"))
+
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{HmacSecret: testHmacSecret})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenTokenIsExpired_ExpectInvalidTokenError(t *testing.T) {
+	claims := jwt.MapClaims{"sub": "user", "exp": time.Now().Add(-time.Minute).Unix()}
+	token := signHmacToken(t, claims, testHmacSecret)
+
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{HmacSecret: testHmacSecret})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestUnit_JwtAuth_WhenIssuerDoesNotMatch_ExpectInvalidTokenError(t *testing.T) {
+	token := signHmacToken(t, jwt.MapClaims{"iss": "someone-else"}, testHmacSecret)
+
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{HmacSecret: testHmacSecret, Issuer: "me"})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestUnit_JwtAuth_WhenRsaTokenIsValid_ExpectNextCalled(t *testing.T) {
+	key := generateRsaKey(t)
+	token := signRsaToken(t, jwt.MapClaims{"sub": "user"}, key, "")
+
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{RsaPublicKey: &key.PublicKey})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenAlgorithmIsNotConfigured_ExpectInvalidTokenError(t *testing.T) {
+	key := generateRsaKey(t)
+	token := signHmacToken(t, jwt.MapClaims{"sub": "user"}, testHmacSecret)
+
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{RsaPublicKey: &key.PublicKey})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenNoKeyIsConfigured_ExpectPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		JwtAuth(JwtConfig{Issuer: "my-issuer"})
+	})
+}
+
+func TestUnit_JwtAuth_WhenTokenIsSignedWithEmptySecret_ExpectInvalidTokenError(t *testing.T) {
+	key := generateRsaKey(t)
+	token := signHmacToken(t, jwt.MapClaims{"sub": "user"}, []byte{})
+
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{RsaPublicKey: &key.PublicKey})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenKeyIsPublishedInJwks_ExpectNextCalled(t *testing.T) {
+	key := generateRsaKey(t)
+	jwks := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	token := signRsaToken(t, jwt.MapClaims{"sub": "user"}, key, "key-1")
+
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{JwksUrl: jwks.URL})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_JwtAuth_WhenKeyIsNotPublishedInJwks_ExpectInvalidTokenError(t *testing.T) {
+	key := generateRsaKey(t)
+	jwks := newTestJwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	token := signRsaToken(t, jwt.MapClaims{"sub": "user"}, key, "key-2")
+
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := JwtAuth(JwtConfig{JwksUrl: jwks.URL})(next)
+
+	err := callable(generateTestEchoContextWithToken(token))
+
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestUnit_BearerToken(t *testing.T) {
+	type testCase struct {
+		header   string
+		expected string
+		ok       bool
+	}
+
+	tests := []testCase{
+		{header: "", ok: false},
+		{header: "Bearer ", ok: false},
+		{header: "Basic abc", ok: false},
+		{header: "Bearer abc", expected: "abc", ok: true},
+		{header: "bearer abc", expected: "abc", ok: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			req.Header.Set(authorizationHeader, tc.header)
+
+			actual, ok := bearerToken(req)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func generateTestEchoContextWithToken(token string) *echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(authorizationHeader, bearerPrefix+token)
+
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	return ctx
+}
+
+func signHmacToken(t *testing.T, claims jwt.MapClaims, secret []byte) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return token
+}
+
+func signRsaToken(t *testing.T, claims jwt.MapClaims, key *rsa.PrivateKey, kid string) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return signed
+}
+
+func generateRsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return key
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+)
+
+type LocaleConfig struct {
+	// Supported lists the locales served by the API, e.g. "en" or "fr-FR".
+	// The middleware is disabled when it is empty.
+	Supported []string
+	// Default is used when the client does not accept any of the supported
+	// locales. It defaults to the first supported locale.
+	Default string
+}
+
+func (c LocaleConfig) Enabled() bool {
+	return len(c.Supported) > 0
+}
+
+// ErrorTranslator returns the message of an error in the provided locale.
+// It receives the message which would be sent otherwise and should return
+// it unchanged when there is no translation.
+type ErrorTranslator func(locale string, err error, message string) string
+
+var (
+	errorTranslatorLock sync.RWMutex
+	errorTranslator     ErrorTranslator
+)
+
+// RegisterErrorTranslator allows the ErrorConverter to send the messages of
+// the errors in the locale resolved by the Locale middleware.
+func RegisterErrorTranslator(translator ErrorTranslator) {
+	errorTranslatorLock.Lock()
+	defer errorTranslatorLock.Unlock()
+
+	errorTranslator = translator
+}
+
+// Locale resolves the locale of the request from the Accept-Language header
+// and attaches it to the request context (see reqctx.Locale). The resolved
+// locale is also sent in the Content-Language header of the response.
+func Locale(config LocaleConfig) echo.MiddlewareFunc {
+	if config.Default == "" && len(config.Supported) > 0 {
+		config.Default = config.Supported[0]
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			acceptLanguage := c.Request().Header.Get("Accept-Language")
+			locale := negotiateLocale(acceptLanguage, config.Supported, config.Default)
+
+			reqctx.Set(c, reqctx.WithLocale, locale)
+			c.Response().Header().Set("Content-Language", locale)
+
+			return next(c)
+		}
+	}
+}
+
+type languageRange struct {
+	tag     string
+	quality float64
+}
+
+func negotiateLocale(acceptLanguage string, supported []string, fallback string) string {
+	for _, r := range parseAcceptLanguage(acceptLanguage) {
+		if r.tag == "*" {
+			return fallback
+		}
+
+		if locale, ok := matchLocale(r.tag, supported); ok {
+			return locale
+		}
+	}
+
+	return fallback
+}
+
+// parseAcceptLanguage returns the accepted language ranges sorted by
+// decreasing quality. Ranges with a quality of 0 are not accepted.
+func parseAcceptLanguage(acceptLanguage string) []languageRange {
+	var out []languageRange
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		out = append(out, languageRange{tag: tag, quality: quality})
+	}
+
+	slices.SortStableFunc(out, func(lhs languageRange, rhs languageRange) int {
+		switch {
+		case lhs.quality > rhs.quality:
+			return -1
+		case lhs.quality < rhs.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return out
+}
+
+// matchLocale looks for a supported locale matching exactly the tag or,
+// failing that, sharing its primary language (e.g. "fr-CH" matches "fr").
+func matchLocale(tag string, supported []string) (string, bool) {
+	for _, locale := range supported {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+
+	language := primaryLanguage(tag)
+	for _, locale := range supported {
+		if strings.EqualFold(primaryLanguage(locale), language) {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+func primaryLanguage(tag string) string {
+	language, _, _ := strings.Cut(tag, "-")
+	return language
+}
+
+// translateError translates the message of the converted error when a
+// translator is registered. The cause is the error returned by the handler.
+func translateError(c *echo.Context, cause error, err error) error {
+	errorTranslatorLock.RLock()
+	translator := errorTranslator
+	errorTranslatorLock.RUnlock()
+
+	var httpErr *echo.HTTPError
+	if translator == nil || !stderrors.As(err, &httpErr) {
+		return err
+	}
+
+	locale, ok := reqctx.Locale(c.Request().Context())
+	if !ok {
+		return err
+	}
+
+	message := translator(locale, cause, httpErr.Message)
+	if message == httpErr.Message {
+		return err
+	}
+
+	// The error might be shared (e.g. echo.ErrNotFound): it can't be
+	// modified in place.
+	return echo.NewHTTPError(httpErr.Code, message).Wrap(httpErr)
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleLocaleConfig = LocaleConfig{Supported: []string{"en", "fr-FR", "de"}}
+
+func TestUnit_Locale_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return Locale(sampleLocaleConfig)
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Locale_ResolvesLocale(t *testing.T) {
+	type testCase struct {
+		acceptLanguage string
+		expected       string
+	}
+
+	testCases := map[string]testCase{
+		"noHeader":        {acceptLanguage: "", expected: "en"},
+		"exactMatch":      {acceptLanguage: "de", expected: "de"},
+		"caseInsensitive": {acceptLanguage: "FR-fr", expected: "fr-FR"},
+		"sameLanguage":    {acceptLanguage: "fr-CH", expected: "fr-FR"},
+		"quality":         {acceptLanguage: "it, de;q=0.5, fr;q=0.8", expected: "fr-FR"},
+		"zeroQuality":     {acceptLanguage: "de;q=0, it", expected: "en"},
+		"wildcard":        {acceptLanguage: "it, *;q=0.5", expected: "en"},
+		"unsupported":     {acceptLanguage: "it, es", expected: "en"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var actual string
+			next := func(c *echo.Context) error {
+				actual, _ = reqctx.Locale(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			ctx, rw := generateTestEchoContextFromRequest(req)
+
+			err := Locale(sampleLocaleConfig)(next)(ctx)
+
+			require.Nil(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expected, rw.Header().Get("Content-Language"))
+		})
+	}
+}
+
+func TestUnit_Locale_WhenDefaultIsSet_ExpectItToBeUsed(t *testing.T) {
+	config := LocaleConfig{Supported: []string{"en", "fr"}, Default: "fr"}
+	var actual string
+	next := func(c *echo.Context) error {
+		actual, _ = reqctx.Locale(c.Request().Context())
+		return nil
+	}
+
+	ctx, _ := generateTestEchoContext()
+	err := Locale(config)(next)(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, "fr", actual)
+}
+
+func TestUnit_ErrorConverter_WhenTranslatorIsRegistered_ExpectLocalizedMessage(t *testing.T) {
+	RegisterErrorTranslator(func(locale string, err error, message string) string {
+		if errWithCode, ok := errors.AsErrorWithCode(err); ok && errWithCode.Code == errInvalidApiKey && locale == "fr-FR" {
+			return "Clé d'API invalide"
+		}
+		return message
+	})
+	defer RegisterErrorTranslator(nil)
+
+	next := func(c *echo.Context) error {
+		return ErrInvalidApiKey
+	}
+	callable := Locale(sampleLocaleConfig)(ErrorConverter()(next))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	err := callable(ctx)
+	assertIsHttpErrorWithMessageAndCode(t, err, "Clé d'API invalide", http.StatusUnauthorized)
+
+	ctx, _ = generateTestEchoContext()
+	err = callable(ctx)
+	assertIsHttpErrorWithMessageAndCode(t, err, errors.PublicMessageOf(ErrInvalidApiKey), http.StatusUnauthorized)
+}
+
+func TestUnit_ErrorConverter_WhenTranslatingEchoError_ExpectLocalizedMessage(t *testing.T) {
+	RegisterErrorTranslator(func(locale string, err error, message string) string {
+		return "Introuvable"
+	})
+	defer RegisterErrorTranslator(nil)
+
+	next := func(c *echo.Context) error {
+		return echo.ErrNotFound
+	}
+	callable := Locale(sampleLocaleConfig)(ErrorConverter()(next))
+
+	ctx, _ := generateTestEchoContext()
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, "Introuvable", http.StatusNotFound)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
+
+var traceContextPropagator = propagation.TraceContext{}
+
+// Otel starts a server span for each request. The W3C trace context is
+// extracted from the incoming headers so that the span is attached to
+// the caller's trace, and injected in the response headers. The trace
+// and span identifiers are also added to the logger of the request.
+func Otel(provider trace.TracerProvider) echo.MiddlewareFunc {
+	tracer := provider.Tracer(tracerName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+			ctx := traceContextPropagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := c.Path()
+			ctx, span := tracer.Start(
+				ctx,
+				fmt.Sprintf("%s %s", req.Method, route),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", req.URL.Path),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+			traceContextPropagator.Inject(ctx, propagation.HeaderCarrier(c.Response().Header()))
+
+			if sc := span.SpanContext(); sc.IsValid() {
+				reqctx.SetLogger(c, c.Logger().With("traceId", sc.TraceID().String(), "spanId", sc.SpanID().String()))
+			}
+
+			err := next(c)
+
+			status := responseStatus(c, err)
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return err
+		}
+	}
+}
+
+// responseStatus returns the status that will be sent to the client. The
+// errors are only written after the middlewares returned so their status
+// has to be derived from the error itself.
+func responseStatus(c *echo.Context, err error) int {
+	if err != nil {
+		if code := echo.StatusCode(err); code != 0 {
+			return code
+		}
+		return http.StatusInternalServerError
+	}
+
+	if resp, err := echo.UnwrapResponse(c.Response()); err == nil && resp.Committed {
+		return resp.Status
+	}
+
+	return http.StatusOK
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const sampleTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestUnit_Otel_CallsNextMiddleware(t *testing.T) {
+	provider, _ := newTestTracerProvider()
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return Otel(provider)
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Otel_RecordsSpanWithAttributes(t *testing.T) {
+	provider, recorder := newTestTracerProvider()
+	next := func(c *echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}
+
+	callable := Otel(provider)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.request.method", http.MethodGet))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusCreated))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestUnit_Otel_WhenHandlerFails_ExpectSpanInError(t *testing.T) {
+	provider, recorder := newTestTracerProvider()
+	next := func(c *echo.Context) error {
+		return echo.ErrServiceUnavailable
+	}
+
+	callable := Otel(provider)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Equal(t, echo.ErrServiceUnavailable, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusServiceUnavailable))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestUnit_Otel_ExtractsIncomingTraceContext(t *testing.T) {
+	provider, recorder := newTestTracerProvider()
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+
+	callable := Otel(provider)(next)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("traceparent", sampleTraceparent)
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Contains(t, rw.Header().Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
+
+func TestUnit_Otel_AddsTraceToLogger(t *testing.T) {
+	provider, _ := newTestTracerProvider()
+	next := func(c *echo.Context) error {
+		c.Logger().Info("hello")
+		return nil
+	}
+
+	callable := Otel(provider)(next)
+	ctx, out := generateTestEchoContextWithLogger()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Contains(t, out.String(), `"traceId":`)
+	assert.Contains(t, out.String(), `"spanId":`)
+}
+
+func newTestTracerProvider() (trace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return provider, recorder
+}
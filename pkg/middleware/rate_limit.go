@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+// RateLimitRule defines a token bucket: Rate tokens are added every second
+// up to Burst tokens. Each request consumes one token.
+type RateLimitRule struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimitRule) enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+type RateLimitConfig struct {
+	// PerIP is the limit applied by RateLimit to all the requests.
+	PerIP RateLimitRule
+	// PerApiKey is the limit applied by ApiKeyRateLimit to the requests
+	// authenticated by the ApiKeyAuth middleware.
+	PerApiKey RateLimitRule
+	// Store defaults to an in-memory store when not provided.
+	Store RateLimitStore
+}
+
+func (c RateLimitConfig) Enabled() bool {
+	return c.PerIP.enabled() || c.PerApiKey.enabled()
+}
+
+// RateLimit rejects requests exceeding the limit of their IP with a 429.
+// It should run before the authentication so that the requests with an
+// invalid key are also limited. In case the store fails the request is
+// allowed: an unavailable store should not take down the service.
+func RateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+	return rateLimit(config, config.PerIP, func(c *echo.Context) (string, bool) {
+		return "ip:" + rest.ClientIP(c), true
+	})
+}
+
+// ApiKeyRateLimit rejects requests exceeding the limit of their API key
+// with a 429. It should run after the ApiKeyAuth middleware: requests
+// without an authenticated key are not limited.
+func ApiKeyRateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+	return rateLimit(config, config.PerApiKey, func(c *echo.Context) (string, bool) {
+		principal, ok := ApiKeyPrincipal(c)
+		return "apikey:" + principal.Id, ok
+	})
+}
+
+func rateLimit(
+	config RateLimitConfig,
+	limit RateLimitRule,
+	keyOf func(c *echo.Context) (string, bool),
+) echo.MiddlewareFunc {
+	if config.Store == nil {
+		config.Store = NewInMemoryRateLimitStore()
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if !limit.enabled() {
+				return next(c)
+			}
+
+			key, ok := keyOf(c)
+			if !ok {
+				return next(c)
+			}
+
+			allowed, err := config.Store.Allow(c.Request().Context(), key, limit)
+			if err != nil {
+				c.Logger().Warn("Failed to check rate limit", slog.String("key", key), slog.Any("error", err))
+				return next(c)
+			}
+
+			if !allowed {
+				return echo.ErrTooManyRequests
+			}
+
+			return next(c)
+		}
+	}
+}
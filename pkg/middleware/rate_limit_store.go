@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitStore keeps track of the requests received for a key. It can
+// be implemented on top of a shared storage (e.g. Redis) so that limits
+// are enforced across several instances of a service.
+type RateLimitStore interface {
+	// Allow consumes a token for the key and returns whether the request
+	// is allowed given the limit.
+	Allow(ctx context.Context, key string, limit RateLimitRule) (bool, error)
+}
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+	// fullAt is the time at which the bucket is completely refilled.
+	fullAt time.Time
+}
+
+type inMemoryRateLimitStore struct {
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+const rateLimitSweepInterval = time.Minute
+
+// NewInMemoryRateLimitStore returns a store implementing a token bucket
+// for each key. It is only suitable for services running as a single
+// instance.
+func NewInMemoryRateLimitStore() RateLimitStore {
+	return newInMemoryRateLimitStore(time.Now)
+}
+
+func newInMemoryRateLimitStore(now func() time.Time) *inMemoryRateLimitStore {
+	return &inMemoryRateLimitStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: now(),
+		now:       now,
+	}
+}
+
+func (s *inMemoryRateLimitStore) Allow(ctx context.Context, key string, limit RateLimitRule) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:     float64(limit.Burst),
+			lastRefill: now,
+		}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens = min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.lastRefill = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	missing := float64(limit.Burst) - b.tokens
+	b.fullAt = now.Add(time.Duration(missing / limit.Rate * float64(time.Second)))
+
+	return allowed, nil
+}
+
+// sweep removes the buckets which had enough time to be completely
+// refilled: they are equivalent to a missing bucket. This prevents the
+// store from growing indefinitely with the number of clients.
+func (s *inMemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRule = RateLimitRule{Rate: 1, Burst: 2}
+
+func TestUnit_InMemoryRateLimitStore_AllowsUpToBurst(t *testing.T) {
+	clock := newTestClock()
+	store := newInMemoryRateLimitStore(clock.now)
+
+	assertAllowed(t, store, "key", true)
+	assertAllowed(t, store, "key", true)
+	assertAllowed(t, store, "key", false)
+}
+
+func TestUnit_InMemoryRateLimitStore_RefillsOverTime(t *testing.T) {
+	clock := newTestClock()
+	store := newInMemoryRateLimitStore(clock.now)
+
+	assertAllowed(t, store, "key", true)
+	assertAllowed(t, store, "key", true)
+	assertAllowed(t, store, "key", false)
+
+	clock.advance(time.Second)
+
+	assertAllowed(t, store, "key", true)
+	assertAllowed(t, store, "key", false)
+}
+
+func TestUnit_InMemoryRateLimitStore_KeysAreIndependent(t *testing.T) {
+	clock := newTestClock()
+	store := newInMemoryRateLimitStore(clock.now)
+
+	assertAllowed(t, store, "key1", true)
+	assertAllowed(t, store, "key1", true)
+	assertAllowed(t, store, "key1", false)
+
+	assertAllowed(t, store, "key2", true)
+}
+
+func TestUnit_InMemoryRateLimitStore_RemovesRefilledBuckets(t *testing.T) {
+	clock := newTestClock()
+	store := newInMemoryRateLimitStore(clock.now)
+
+	assertAllowed(t, store, "key1", true)
+	clock.advance(rateLimitSweepInterval)
+	assertAllowed(t, store, "key2", true)
+
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "key2")
+}
+
+type testClock struct {
+	current time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{current: time.Date(2024, 5, 12, 10, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) now() time.Time {
+	return c.current
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.current = c.current.Add(d)
+}
+
+func assertAllowed(t *testing.T, store RateLimitStore, key string, expected bool) {
+	t.Helper()
+
+	allowed, err := store.Allow(context.Background(), key, testRule)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, expected, allowed)
+}
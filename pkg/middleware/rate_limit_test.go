@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_RateLimit_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return RateLimit(RateLimitConfig{PerIP: testRule})
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_RateLimit_WhenLimitExceeded_ExpectTooManyRequests(t *testing.T) {
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := RateLimit(RateLimitConfig{PerIP: RateLimitRule{Rate: 1, Burst: 1}})(next)
+
+	ctx, _ := generateTestEchoContext()
+	err := callable(ctx)
+	assert.Nil(t, err)
+
+	ctx, _ = generateTestEchoContext()
+	err = callable(ctx)
+	assert.Equal(t, echo.ErrTooManyRequests, err)
+}
+
+func TestUnit_RateLimit_WhenAuthenticated_ExpectIpLimitUsed(t *testing.T) {
+	store := &recordingStore{}
+	config := RateLimitConfig{
+		PerIP:     testRule,
+		PerApiKey: RateLimitRule{Rate: 10, Burst: 20},
+		Store:     store,
+	}
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := RateLimit(config)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	reqctx.Set(ctx, reqctx.WithPrincipal, Principal{Id: "my-service"})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ip:192.0.2.1", store.key)
+	assert.Equal(t, testRule, store.rule)
+}
+
+func TestUnit_ApiKeyRateLimit_UsesApiKeyWhenAuthenticated(t *testing.T) {
+	store := &recordingStore{}
+	config := RateLimitConfig{
+		PerIP:     testRule,
+		PerApiKey: RateLimitRule{Rate: 10, Burst: 20},
+		Store:     store,
+	}
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := ApiKeyRateLimit(config)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	reqctx.Set(ctx, reqctx.WithPrincipal, Principal{Id: "my-service"})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "apikey:my-service", store.key)
+	assert.Equal(t, config.PerApiKey, store.rule)
+}
+
+func TestUnit_ApiKeyRateLimit_WhenApiKeyIsNotAuthenticated_ExpectNoLimit(t *testing.T) {
+	store := &recordingStore{}
+	config := RateLimitConfig{
+		PerIP:     testRule,
+		PerApiKey: RateLimitRule{Rate: 10, Burst: 20},
+		Store:     store,
+	}
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := ApiKeyRateLimit(config)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("X-Api-Key", "random-key")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+	assert.Empty(t, store.key)
+}
+
+func TestUnit_ApiKeyRateLimit_WhenNoApiKeyLimit_ExpectNoLimit(t *testing.T) {
+	store := &recordingStore{}
+	config := RateLimitConfig{
+		PerIP: testRule,
+		Store: store,
+	}
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	callable := ApiKeyRateLimit(config)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	reqctx.Set(ctx, reqctx.WithPrincipal, Principal{Id: "my-service"})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+	assert.Empty(t, store.key)
+}
+
+func TestUnit_RateLimit_WhenStoreFails_ExpectRequestAllowed(t *testing.T) {
+	store := &recordingStore{err: fmt.Errorf("store unavailable")}
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return RateLimit(RateLimitConfig{PerIP: testRule, Store: store})
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_RateLimitConfig_Enabled(t *testing.T) {
+	assert.False(t, RateLimitConfig{}.Enabled())
+	assert.True(t, RateLimitConfig{PerIP: testRule}.Enabled())
+	assert.True(t, RateLimitConfig{PerApiKey: testRule}.Enabled())
+}
+
+type recordingStore struct {
+	key  string
+	rule RateLimitRule
+	err  error
+}
+
+func (s *recordingStore) Allow(ctx context.Context, key string, rule RateLimitRule) (bool, error) {
+	s.key = key
+	s.rule = rule
+	return true, s.err
+}
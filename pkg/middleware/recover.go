@@ -1,13 +1,45 @@
 package middleware
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"runtime"
+	"runtime/debug"
+	"time"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
 	"github.com/labstack/echo/v5"
 )
 
+// PanicHandler is called with the value passed to panic and the stack
+// of the goroutine which panicked. It can be used to report the panic
+// to an external service.
+type PanicHandler func(ctx context.Context, recovered any, stack []byte)
+
+type RecoverConfig struct {
+	OnPanic PanicHandler
+	// ErrorHook, when set, also receives the panics along with their
+	// stack.
+	ErrorHook logger.ErrorHook
+}
+
+// PanicError is attached to the error returned by the Recover middleware
+// so that the stack of the panic is not lost.
+type PanicError struct {
+	Err   error
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
 type recoveredErrorData struct {
 	err   error
 	ctx   *echo.Context
@@ -16,6 +48,10 @@ type recoveredErrorData struct {
 }
 
 func Recover() echo.MiddlewareFunc {
+	return RecoverWithConfig(RecoverConfig{})
+}
+
+func RecoverWithConfig(config RecoverConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) (err error) {
 			defer func() {
@@ -25,19 +61,37 @@ func Recover() echo.MiddlewareFunc {
 						recoveredErr = fmt.Errorf("%v", r)
 					}
 
-					stack := make([]byte, 4<<10) // 4 KB
-					length := runtime.Stack(stack, false)
-
 					data := recoveredErrorData{
 						err:   recoveredErr,
 						ctx:   c,
 						req:   c.Request(),
-						stack: stack[:length],
+						stack: debug.Stack(),
 					}
 
 					c.Logger().Error(createErrorLog(data))
 
+					if config.OnPanic != nil {
+						config.OnPanic(c.Request().Context(), r, data.stack)
+					}
+					if config.ErrorHook != nil {
+						config.ErrorHook(c.Request().Context(), logger.ErrorEvent{
+							Time:    time.Now(),
+							Message: fmt.Sprintf("panic: %v", recoveredErr),
+							Attrs: []slog.Attr{
+								slog.String("method", data.req.Method),
+								slog.String("uri", pathFromRequest(data.req)),
+							},
+							Err:   recoveredErr,
+							Stack: data.stack,
+						})
+					}
+
 					err = wrapToHttpError(recoveredErr)
+
+					var httpErr *echo.HTTPError
+					if stderrors.As(err, &httpErr) {
+						err = httpErr.Wrap(&PanicError{Err: recoveredErr, Stack: data.stack})
+					}
 				}
 			}()
 			return next(c)
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -65,7 +67,73 @@ func TestUnit_Recover_SetsStatusCodeToError(t *testing.T) {
 	err := callable(ctx)
 	require.NotNil(t, err)
 
-	assertIsHttpErrorWithMessageAndCode(t, err, "some error", http.StatusInternalServerError)
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
+}
+
+func TestUnit_Recover_AttachesStackToError(t *testing.T) {
+	next, _ := createPanicHandler()
+
+	callable := Recover()(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.NotNil(t, err)
+
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "some error", panicErr.Error())
+	assert.Contains(t, string(panicErr.Stack), "createPanicHandler")
+}
+
+func TestUnit_Recover_CallsOnPanic(t *testing.T) {
+	next, _ := createPanicHandler()
+
+	var recovered any
+	var stack []byte
+	config := RecoverConfig{
+		OnPanic: func(ctx context.Context, r any, s []byte) {
+			recovered = r
+			stack = s
+		},
+	}
+
+	callable := RecoverWithConfig(config)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.NotNil(t, err)
+
+	assert.Equal(t, fmt.Errorf("some error"), recovered)
+	assert.Contains(t, string(stack), "createPanicHandler")
+}
+
+func TestUnit_Recover_CallsErrorHook(t *testing.T) {
+	next, _ := createPanicHandler()
+
+	hook, events := newErrorHookRecorder()
+	callable := RecoverWithConfig(RecoverConfig{ErrorHook: hook})(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.NotNil(t, err)
+
+	require.Len(t, *events, 1)
+	assert.Equal(t, "panic: some error", (*events)[0].Message)
+	assert.Equal(t, fmt.Errorf("some error"), (*events)[0].Err)
+	assert.Contains(t, string((*events)[0].Stack), "createPanicHandler")
+}
+
+func TestUnit_Recover_WhenPanicValueIsNotAnError_ExpectItToBeConverted(t *testing.T) {
+	next := func(c *echo.Context) error {
+		panic("not an error")
+	}
+
+	callable := Recover()(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, "an unexpected error occurred", http.StatusInternalServerError)
 }
 
 func createPanicHandler() (echo.HandlerFunc, *bool) {
@@ -3,37 +3,146 @@ package middleware
 import (
 	"fmt"
 	"log/slog"
-	"time"
+	"slices"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
 )
 
+type RequestLogField string
+
+const (
+	LogFieldMethod    RequestLogField = "method"
+	LogFieldUri       RequestLogField = "uri"
+	LogFieldStatus    RequestLogField = "status"
+	LogFieldDuration  RequestLogField = "duration"
+	LogFieldBytesIn   RequestLogField = "bytesIn"
+	LogFieldBytesOut  RequestLogField = "bytesOut"
+	LogFieldRemoteIp  RequestLogField = "remoteIp"
+	LogFieldRequestId RequestLogField = "requestId"
+	LogFieldUserAgent RequestLogField = "userAgent"
+)
+
+var allRequestLogFields = []RequestLogField{
+	LogFieldMethod,
+	LogFieldUri,
+	LogFieldStatus,
+	LogFieldDuration,
+	LogFieldBytesIn,
+	LogFieldBytesOut,
+	LogFieldRemoteIp,
+	LogFieldRequestId,
+	LogFieldUserAgent,
+}
+
+type RequestLoggerConfig struct {
+	// Fields lists the fields added to the log of each request. All the
+	// fields are logged when it is empty.
+	Fields []RequestLogField
+	// SkipPaths lists the paths of the requests which should not be
+	// logged, typically the health check.
+	SkipPaths []string
+	// Redactor, when set, masks the sensitive data of the logs of the
+	// requests (e.g. emails in the paths).
+	Redactor *logger.Redactor
+}
+
 func RequestLogger() echo.MiddlewareFunc {
-	config := middleware.RequestLoggerConfig{
-		LogHost:    true,
-		LogMethod:  true,
-		LogURIPath: true,
-		LogStatus:  true,
+	return RequestLoggerWithConfig(RequestLoggerConfig{})
+}
+
+func RequestLoggerWithConfig(config RequestLoggerConfig) echo.MiddlewareFunc {
+	fields := config.Fields
+	if len(fields) == 0 {
+		fields = allRequestLogFields
+	}
+
+	loggerConfig := middleware.RequestLoggerConfig{
+		Skipper: func(c *echo.Context) bool {
+			return slices.Contains(config.SkipPaths, c.Request().URL.Path)
+		},
+		LogLatency:      true,
+		LogRemoteIP:     true,
+		LogHost:         true,
+		LogMethod:       true,
+		LogURIPath:      true,
+		LogUserAgent:    true,
+		LogStatus:       true,
+		LogResponseSize: true,
 		LogValuesFunc: func(c *echo.Context, values middleware.RequestLoggerValues) error {
-			createRequestLog(values, c.Logger())
+			// The route middlewares enrich the logger with the request and
+			// trace identifiers: the former should not be duplicated.
+			log := c.Logger()
+			logged := fields
+			if _, ok := reqctx.RequestId(c.Request().Context()); ok {
+				logged = withoutField(fields, LogFieldRequestId)
+			}
+			if config.Redactor != nil {
+				log = logger.WithRedaction(log, config.Redactor)
+			}
+			// echo would prefer the identifier provided by the client, even
+			// when it was rejected or replaced by the server.
+			values.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+			createRequestLog(c.Request().ContentLength, values, logged, log)
 			return nil
 		},
 	}
 	// Voluntarily ignoring errors
-	logging, _ := config.ToMiddleware()
+	logging, _ := loggerConfig.ToMiddleware()
 
 	return logging
 }
 
-func createRequestLog(values middleware.RequestLoggerValues, log *slog.Logger) {
-	elapsed := time.Since(values.StartTime)
+func withoutField(fields []RequestLogField, field RequestLogField) []RequestLogField {
+	return slices.DeleteFunc(slices.Clone(fields), func(f RequestLogField) bool {
+		return f == field
+	})
+}
+
+func createRequestLog(
+	bytesIn int64,
+	values middleware.RequestLoggerValues,
+	fields []RequestLogField,
+	log *slog.Logger,
+) {
+	// The length is -1 when unknown, e.g. for chunked requests.
+	bytesIn = max(bytesIn, 0)
+
+	attrs := make([]any, 0, len(fields))
+	for _, field := range fields {
+		if attr, ok := requestLogAttr(bytesIn, values, field); ok {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	log.Info("Request processed", attrs...)
+}
+
+func requestLogAttr(bytesIn int64, values middleware.RequestLoggerValues, field RequestLogField) (slog.Attr, bool) {
+	key := string(field)
 
-	log.Info(
-		"Request processed",
-		slog.String("method", values.Method),
-		slog.String("uri", fmt.Sprintf("%s%s", values.Host, values.URIPath)),
-		slog.String("duration", fmt.Sprintf("%v", elapsed)),
-		slog.Int("status", values.Status),
-	)
+	switch field {
+	case LogFieldMethod:
+		return slog.String(key, values.Method), true
+	case LogFieldUri:
+		return slog.String(key, fmt.Sprintf("%s%s", values.Host, values.URIPath)), true
+	case LogFieldStatus:
+		return slog.Int(key, values.Status), true
+	case LogFieldDuration:
+		return slog.String(key, fmt.Sprintf("%v", values.Latency)), true
+	case LogFieldBytesIn:
+		return slog.Int64(key, bytesIn), true
+	case LogFieldBytesOut:
+		return slog.Int64(key, values.ResponseSize), true
+	case LogFieldRemoteIp:
+		return slog.String(key, values.RemoteIP), true
+	case LogFieldRequestId:
+		return slog.String(key, values.RequestID), true
+	case LogFieldUserAgent:
+		return slog.String(key, values.UserAgent), true
+	default:
+		return slog.Attr{}, false
+	}
 }
@@ -2,11 +2,18 @@ package middleware
 
 import (
 	"bytes"
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,6 +51,145 @@ func TestUnit_RequestLogger_PrintsRequestTiming(t *testing.T) {
 	assert.Equal(t, http.StatusOK, actual.Status)
 }
 
+func TestUnit_RequestLogger_PrintsAllFieldsByDefault(t *testing.T) {
+	next := func(c *echo.Context) error {
+		c.Response().Header().Set(echo.HeaderXRequestID, "my-request")
+		return c.String(http.StatusOK, "hello")
+	}
+
+	callable := RequestLogger()(next)
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/path", strings.NewReader("body"))
+	req.Header.Set("User-Agent", "my-agent")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	var actual map[string]any
+	err = json.Unmarshal(out.Bytes(), &actual)
+	require.Nil(t, err)
+
+	assert.Equal(t, "POST", actual["method"])
+	assert.Equal(t, "example.com/path", actual["uri"])
+	assert.Equal(t, float64(http.StatusOK), actual["status"])
+	assert.Equal(t, float64(4), actual["bytesIn"])
+	assert.Equal(t, float64(5), actual["bytesOut"])
+	assert.Equal(t, "192.0.2.1", actual["remoteIp"])
+	assert.Equal(t, "my-request", actual["requestId"])
+	assert.Equal(t, "my-agent", actual["userAgent"])
+	assert.Contains(t, actual, "duration")
+}
+
+func TestUnit_RequestLogger_ExpectRequestIdAssignedByServerToBeLogged(t *testing.T) {
+	next := func(c *echo.Context) error {
+		c.Response().Header().Set(echo.HeaderXRequestID, "server-request")
+		return c.NoContent(http.StatusOK)
+	}
+
+	callable := RequestLogger()(next)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "client-request")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	var actual map[string]any
+	err = json.Unmarshal(out.Bytes(), &actual)
+	require.Nil(t, err)
+
+	assert.Equal(t, "server-request", actual["requestId"])
+}
+
+func TestUnit_RequestLogger_WhenFieldsAreConfigured_ExpectOnlyThoseToBePrinted(t *testing.T) {
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	config := RequestLoggerConfig{
+		Fields: []RequestLogField{LogFieldMethod, LogFieldStatus},
+	}
+
+	callable := RequestLoggerWithConfig(config)(next)
+	ctx, _ := generateTestEchoContext()
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	var actual map[string]any
+	err = json.Unmarshal(out.Bytes(), &actual)
+	require.Nil(t, err)
+
+	assert.Equal(t, "GET", actual["method"])
+	assert.Equal(t, float64(http.StatusOK), actual["status"])
+	assert.NotContains(t, actual, "uri")
+	assert.NotContains(t, actual, "userAgent")
+}
+
+func TestUnit_RequestLogger_WhenPathIsSkipped_ExpectNoLog(t *testing.T) {
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+	config := RequestLoggerConfig{
+		SkipPaths: []string{"/healthz"},
+	}
+
+	callable := RequestLoggerWithConfig(config)(next)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/healthz", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.True(t, *called)
+	assert.Empty(t, out.String())
+}
+
+func TestUnit_RequestLogger_WhenLoggerIsEnrichedByHandler_ExpectEnrichedLoggerUsed(t *testing.T) {
+	next := func(c *echo.Context) error {
+		c.Response().Header().Set(echo.HeaderXRequestID, "my-request")
+		reqctx.Set(c, reqctx.WithRequestId, "my-request")
+		reqctx.SetLogger(c, c.Logger().With("requestId", "my-request", "traceId", "my-trace"))
+		return c.NoContent(http.StatusOK)
+	}
+
+	callable := RequestLogger()(next)
+	ctx, _ := generateTestEchoContext()
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, 1, strings.Count(out.String(), `"requestId"`))
+	assert.Contains(t, out.String(), `"traceId":"my-trace"`)
+}
+
+func TestUnit_RequestLogger_WhenRedactorIsConfigured_ExpectSensitiveDataMasked(t *testing.T) {
+	next, _ := createTestEchoHandlerFuncWithCalledBoolean()
+	config := RequestLoggerConfig{
+		Fields:   []RequestLogField{LogFieldUri},
+		Redactor: logger.NewRedactor(logger.RedactionConfig{Patterns: []*regexp.Regexp{logger.EmailPattern}}),
+	}
+
+	callable := RequestLoggerWithConfig(config)(next)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/users/john@example.com", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	out := setTestLogger(ctx)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	var actual map[string]any
+	err = json.Unmarshal(out.Bytes(), &actual)
+	require.Nil(t, err)
+	assert.Equal(t, "example.com/users/***", actual["uri"])
+}
+
+func setTestLogger(ctx *echo.Context) *bytes.Buffer {
+	var out bytes.Buffer
+	ctx.SetLogger(slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	return &out
+}
+
 func areTimeCloserThan(t1 time.Time, t2 time.Time, distance time.Duration) bool {
 	diff := t1.Sub(t2).Abs()
 	return diff <= distance
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
 	"github.com/labstack/echo/v5"
 )
 
@@ -11,7 +12,8 @@ func RequestTracer() echo.MiddlewareFunc {
 		return func(c *echo.Context) error {
 			requestId, exists := tryGetRequestIdHeader(c.Response())
 			if exists {
-				c.SetLogger(c.Logger().With("requestId", requestId))
+				reqctx.Set(c, reqctx.WithRequestId, requestId)
+				reqctx.SetLogger(c, c.Logger().With("requestId", requestId))
 			}
 
 			return next(c)
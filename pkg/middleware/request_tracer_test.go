@@ -3,6 +3,7 @@ package middleware
 import (
 	"testing"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
 	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,20 @@ func TestUnit_RequestTracer_WhenRequestIdSet_AddsRequestIdToLogger(t *testing.T)
 	assert.NotNil(t, ctx.Logger())
 }
 
+func TestUnit_RequestTracer_WhenRequestIdSet_AttachesItToRequestContext(t *testing.T) {
+	callable, _, ctx := createCallableTracerHandler()
+
+	ctx.Response().Header().Set(requestIdHeader, "my-request-id")
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	actual, ok := reqctx.RequestId(ctx.Request().Context())
+	assert.True(t, ok)
+	assert.Equal(t, "my-request-id", actual)
+	assert.Same(t, ctx.Logger(), reqctx.Logger(ctx.Request().Context()))
+}
+
 func createCallableTracerHandler() (echo.HandlerFunc, *bool, *echo.Context) {
 	generator := func() echo.MiddlewareFunc {
 		return RequestTracer()
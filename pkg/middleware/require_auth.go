@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/labstack/echo/v5"
+)
+
+// RequireAuth rejects with a 401 the requests for which no principal was
+// attached by an authentication middleware (ApiKeyAuth, JwtAuth, ...). It
+// should run after them and guarantees that a route requiring
+// authentication is not served when it was registered without any.
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if _, ok := reqctx.Principal[any](c.Request().Context()); !ok {
+				return ErrUnauthenticated
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/reqctx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_RequireAuth_WhenNoPrincipal_ExpectUnauthenticatedError(t *testing.T) {
+	callable, called, ctx := createCallableHandler(RequireAuth)
+
+	err := callable(ctx)
+
+	assert.Equal(t, ErrUnauthenticated, err)
+	assert.False(t, *called)
+}
+
+func TestUnit_RequireAuth_WhenPrincipalIsSet_ExpectNextCalled(t *testing.T) {
+	callable, called, ctx := createCallableHandler(RequireAuth)
+	reqctx.Set(ctx, reqctx.WithPrincipal, testPrincipal)
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
@@ -1,28 +1,77 @@
 package middleware
 
 import (
+	"regexp"
+
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
-	"github.com/labstack/echo/v5/middleware"
 )
 
-const requestIdHeader = "X-Request-Id"
+const (
+	requestIdHeader   = "X-Request-Id"
+	traceparentHeader = "Traceparent"
+)
+
+var (
+	// Request identifiers received from clients end up in the logs and in
+	// the responses: they are restricted to a safe set of characters.
+	validRequestIdRegex = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+	// https://www.w3.org/TR/trace-context/#traceparent-header-field-values
+	traceparentRegex = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+	invalidTraceId   = "00000000000000000000000000000000"
+)
+
+type ResponseEnvelopeConfig struct {
+	// ReuseIncomingRequestId uses the identifier provided by the caller in
+	// the X-Request-Id header or, if it is missing, the trace id of the
+	// traceparent header. A new identifier is generated when none of them
+	// is present or valid.
+	ReuseIncomingRequestId bool
+}
+
+var DefaultResponseEnvelopeConfig = ResponseEnvelopeConfig{
+	ReuseIncomingRequestId: true,
+}
 
 func ResponseEnvelope() echo.MiddlewareFunc {
-	config := middleware.RequestIDConfig{
-		Generator: func() string {
-			return uuid.New().String()
-		},
-		RequestIDHandler: func(c *echo.Context, requestId string) {
+	return ResponseEnvelopeWithConfig(DefaultResponseEnvelopeConfig)
+}
+
+func ResponseEnvelopeWithConfig(config ResponseEnvelopeConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			requestId, ok := "", false
+			if config.ReuseIncomingRequestId {
+				requestId, ok = incomingRequestId(c)
+			}
+			if !ok {
+				requestId = uuid.New().String()
+			}
+
+			c.Response().Header().Set(requestIdHeader, requestId)
+
 			echoResp, err := echo.UnwrapResponse(c.Response())
 			if err == nil {
 				rw := rest.NewResponseEnvelopeWriter(echoResp.ResponseWriter, requestId, rest.DecodeJSONOrString)
+				rw.NegotiateEncoding(c.Request().Header.Get(echo.HeaderAccept))
 				echoResp.ResponseWriter = rw
 			}
-		},
-		TargetHeader: requestIdHeader,
+
+			return next(c)
+		}
+	}
+}
+
+func incomingRequestId(c *echo.Context) (string, bool) {
+	if requestId := c.Request().Header.Get(requestIdHeader); validRequestIdRegex.MatchString(requestId) {
+		return requestId, true
+	}
+
+	matches := traceparentRegex.FindStringSubmatch(c.Request().Header.Get(traceparentHeader))
+	if matches == nil || matches[1] == invalidTraceId {
+		return "", false
 	}
 
-	return middleware.RequestIDWithConfig(config)
+	return matches[1], true
 }
@@ -3,6 +3,7 @@ package middleware
 import (
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/labstack/echo/v5"
@@ -103,6 +104,96 @@ func TestUnit_ResponseEnvelope_WhenStatusIsNot200Ok_ExpectStatusReflectsIt(t *te
 	assert.Regexp(t, expected, actual)
 }
 
+func TestUnit_ResponseEnvelope_WhenRequestIdIsProvided_ExpectItToBeReused(t *testing.T) {
+	next := createHandlerFuncWithPlainOutput(http.StatusOK, "my-output")
+	callable := ResponseEnvelope()(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(requestIdHeader, "my-request-id")
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, "my-request-id", rw.Header().Get(requestIdHeader))
+	assert.Equal(t, `{"requestId":"my-request-id","status":"SUCCESS","details":"my-output"}`, rw.Body.String())
+}
+
+func TestUnit_ResponseEnvelope_WhenRequestIdIsInvalid_ExpectNewOneGenerated(t *testing.T) {
+	next := createHandlerFuncWithPlainOutput(http.StatusOK, "my-output")
+	callable := ResponseEnvelope()(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(requestIdHeader, "not a valid\nid")
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Regexp(t, uuidRegex, rw.Header().Get(requestIdHeader))
+}
+
+func TestUnit_ResponseEnvelope_WhenTraceparentIsProvided_ExpectTraceIdUsed(t *testing.T) {
+	type testCase struct {
+		name        string
+		traceparent string
+		expected    string
+	}
+
+	tests := []testCase{
+		{
+			name:        "valid",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:        "invalid format",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:        "invalid trace id",
+			traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := createHandlerFuncWithPlainOutput(http.StatusOK, "my-output")
+			callable := ResponseEnvelope()(next)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			req.Header.Set(traceparentHeader, tc.traceparent)
+			ctx, rw := generateTestEchoContextFromRequest(req)
+
+			err := callable(ctx)
+			require.Nil(t, err)
+
+			actual := rw.Header().Get(requestIdHeader)
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.Regexp(t, uuidRegex, actual)
+			}
+		})
+	}
+}
+
+func TestUnit_ResponseEnvelopeWithConfig_WhenNotReusingRequestId_ExpectNewOneGenerated(t *testing.T) {
+	next := createHandlerFuncWithPlainOutput(http.StatusOK, "my-output")
+	callable := ResponseEnvelopeWithConfig(ResponseEnvelopeConfig{})(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(requestIdHeader, "my-request-id")
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Regexp(t, uuidRegex, rw.Header().Get(requestIdHeader))
+}
+
+const uuidRegex = `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
+
 func createHandlerFuncWithPlainOutput(httpCode int, out string) echo.HandlerFunc {
 	return func(c *echo.Context) error {
 		return c.String(httpCode, out)
@@ -114,3 +205,24 @@ func createHandlerFuncWithJsonOutput[T any](httpCode int, out T) echo.HandlerFun
 		return c.JSON(httpCode, out)
 	}
 }
+
+func TestUnit_ResponseEnvelope_WhenXmlIsAccepted_ExpectXmlResponse(t *testing.T) {
+	next := createHandlerFuncWithJsonOutput(http.StatusOK, map[string]string{"key": "value"})
+
+	middleware := ResponseEnvelope()
+	callable := middleware(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(echo.HeaderAccept, "application/xml")
+	req.Header.Set(requestIdHeader, "my-id")
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/xml", rw.Header().Get(echo.HeaderContentType))
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><details><key>value</key></details><requestId>my-id</requestId><status>SUCCESS</status></response>`
+	assert.Equal(t, expected, rw.Body.String())
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+)
+
+const (
+	defaultHstsMaxAge     = 365 * 24 * 60 * 60
+	defaultFrameOptions   = "DENY"
+	defaultReferrerPolicy = "strict-origin-when-cross-origin"
+)
+
+type SecureHeadersConfig struct {
+	Enabled bool
+	// HstsMaxAge is expressed in seconds and defaults to one year. The
+	// header is only sent for requests received over HTTPS.
+	HstsMaxAge     int
+	FrameOptions   string
+	ReferrerPolicy string
+	// ContentSecurityPolicy is not sent when empty as it depends on what
+	// the service is serving.
+	ContentSecurityPolicy string
+}
+
+// SecureHeaders adds security related headers to all responses. The
+// fields left empty in the configuration use sensible defaults.
+func SecureHeaders(config SecureHeadersConfig) echo.MiddlewareFunc {
+	if config.HstsMaxAge == 0 {
+		config.HstsMaxAge = defaultHstsMaxAge
+	}
+	if config.FrameOptions == "" {
+		config.FrameOptions = defaultFrameOptions
+	}
+	if config.ReferrerPolicy == "" {
+		config.ReferrerPolicy = defaultReferrerPolicy
+	}
+
+	secureConfig := middleware.SecureConfig{
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         config.FrameOptions,
+		HSTSMaxAge:            config.HstsMaxAge,
+		ContentSecurityPolicy: config.ContentSecurityPolicy,
+		ReferrerPolicy:        config.ReferrerPolicy,
+	}
+
+	return middleware.SecureWithConfig(secureConfig)
+}
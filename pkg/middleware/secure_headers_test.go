@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_SecureHeaders_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return SecureHeaders(SecureHeadersConfig{})
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_SecureHeaders_SetsDefaultHeaders(t *testing.T) {
+	callable, _, _ := createCallableHandler(func() echo.MiddlewareFunc {
+		return SecureHeaders(SecureHeadersConfig{})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set(echo.HeaderXForwardedProto, "https")
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, "nosniff", rw.Header().Get(echo.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", rw.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "strict-origin-when-cross-origin", rw.Header().Get(echo.HeaderReferrerPolicy))
+	assert.Equal(t, "max-age=31536000; includeSubdomains", rw.Header().Get(echo.HeaderStrictTransportSecurity))
+	assert.Empty(t, rw.Header().Get(echo.HeaderContentSecurityPolicy))
+}
+
+func TestUnit_SecureHeaders_WhenNotHttps_ExpectNoHsts(t *testing.T) {
+	callable, _, _ := createCallableHandler(func() echo.MiddlewareFunc {
+		return SecureHeaders(SecureHeadersConfig{})
+	})
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Empty(t, rw.Header().Get(echo.HeaderStrictTransportSecurity))
+}
+
+func TestUnit_SecureHeaders_UsesConfiguration(t *testing.T) {
+	config := SecureHeadersConfig{
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "no-referrer",
+		ContentSecurityPolicy: "default-src 'none'",
+	}
+	callable, _, _ := createCallableHandler(func() echo.MiddlewareFunc {
+		return SecureHeaders(config)
+	})
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Equal(t, "SAMEORIGIN", rw.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "no-referrer", rw.Header().Get(echo.HeaderReferrerPolicy))
+	assert.Equal(t, "default-src 'none'", rw.Header().Get(echo.HeaderContentSecurityPolicy))
+}
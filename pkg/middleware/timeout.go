@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/labstack/echo/v5"
+)
+
+// Timeout cancels the context of the request once the provided duration
+// has elapsed. When the handler returns after the deadline was reached
+// and did not already write a response, a 504 error is returned instead
+// of whatever the handler produced.
+func Timeout(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+
+			if !stderrors.Is(ctx.Err(), context.DeadlineExceeded) || isCommitted(c) {
+				return err
+			}
+
+			return echo.NewHTTPError(http.StatusGatewayTimeout, errors.PublicMessageOf(ErrRequestTimeout)).Wrap(ErrRequestTimeout)
+		}
+	}
+}
+
+func isCommitted(c *echo.Context) bool {
+	resp, err := echo.UnwrapResponse(c.Response())
+	return err == nil && resp.Committed
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Timeout_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(func() echo.MiddlewareFunc {
+		return Timeout(time.Second)
+	})
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Timeout_SetsDeadlineOnRequestContext(t *testing.T) {
+	var hasDeadline bool
+	next := func(c *echo.Context) error {
+		_, hasDeadline = c.Request().Context().Deadline()
+		return nil
+	}
+
+	callable := Timeout(time.Second)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, hasDeadline)
+}
+
+func TestUnit_Timeout_WhenHandlerExceedsDeadline_ExpectGatewayTimeout(t *testing.T) {
+	next := func(c *echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.Request().Context().Err()
+	}
+
+	callable := Timeout(10 * time.Millisecond)(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assertIsHttpErrorWithMessageAndCode(t, err, errors.PublicMessageOf(ErrRequestTimeout), http.StatusGatewayTimeout)
+}
+
+func TestUnit_Timeout_WhenResponseAlreadyWritten_ExpectHandlerErrorReturned(t *testing.T) {
+	next := func(c *echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.NoContent(http.StatusAccepted)
+	}
+
+	callable := Timeout(10 * time.Millisecond)(next)
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, rw.Code)
+}
+
+func TestUnit_ErrRequestTimeout_ExpectCatalogMessage(t *testing.T) {
+	assert.Equal(t, "request timeout. Code: 401", ErrRequestTimeout.Error())
+}
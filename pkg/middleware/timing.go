@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	serverTimingHeader = "Server-Timing"
+	totalSegment       = "total"
+)
+
+type TimingConfig struct {
+	// Expose sends the segments back to the client in the Server-Timing
+	// header. It reveals internal timings, such as the duration of the
+	// database queries, and should only be enabled for trusted clients.
+	Expose bool
+}
+
+func Timing() echo.MiddlewareFunc {
+	return TimingWithConfig(TimingConfig{})
+}
+
+// TimingWithConfig attaches a timing.Recorder to the context of the
+// request so that handlers and helpers can report the time spent in
+// segments such as database queries. The segments are logged at debug
+// level along with the total time and optionally sent back in the
+// Server-Timing header.
+func TimingWithConfig(config TimingConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			start := time.Now()
+			recorder := timing.NewRecorder()
+
+			c.SetRequest(c.Request().WithContext(timing.WithRecorder(c.Request().Context(), recorder)))
+
+			if resp, err := echo.UnwrapResponse(c.Response()); err == nil && config.Expose {
+				resp.Before(func() {
+					total := timing.Segment{Name: totalSegment, Duration: time.Since(start), Count: 1}
+					segments := append(recorder.Segments(), total)
+					resp.Header().Set(serverTimingHeader, timing.FormatServerTiming(segments))
+				})
+			}
+
+			err := next(c)
+
+			createTimingLog(recorder, time.Since(start), c.Logger())
+
+			return err
+		}
+	}
+}
+
+func createTimingLog(recorder *timing.Recorder, elapsed time.Duration, log *slog.Logger) {
+	attrs := []any{slog.Duration(totalSegment, elapsed)}
+	for _, segment := range recorder.Segments() {
+		attrs = append(
+			attrs,
+			slog.Group(
+				segment.Name,
+				slog.Duration("duration", segment.Duration),
+				slog.Int("count", segment.Count),
+			),
+		)
+	}
+
+	log.Debug("Request timing", attrs...)
+}
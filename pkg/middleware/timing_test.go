@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/timing"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Timing_CallsNextMiddleware(t *testing.T) {
+	callable, called, ctx := createCallableHandler(Timing)
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, *called)
+}
+
+func TestUnit_Timing_AttachesRecorderToRequestContext(t *testing.T) {
+	var recorder *timing.Recorder
+	next := func(c *echo.Context) error {
+		recorder = timing.FromContext(c.Request().Context())
+		return nil
+	}
+
+	callable := Timing()(next)
+	ctx, _ := generateTestEchoContext()
+
+	err := callable(ctx)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, recorder)
+}
+
+func TestUnit_Timing_ExpectNoServerTimingHeaderByDefault(t *testing.T) {
+	next := func(c *echo.Context) error {
+		timing.Track(c.Request().Context(), timing.SegmentDb)()
+		return c.NoContent(http.StatusOK)
+	}
+
+	callable := Timing()(next)
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	assert.Empty(t, rw.Header().Get(serverTimingHeader))
+}
+
+func TestUnit_Timing_WhenExposed_SetsServerTimingHeader(t *testing.T) {
+	next := func(c *echo.Context) error {
+		timing.Track(c.Request().Context(), timing.SegmentDb)()
+		return c.NoContent(http.StatusOK)
+	}
+
+	callable := TimingWithConfig(TimingConfig{Expose: true})(next)
+	ctx, rw := generateTestEchoContext()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	header := rw.Header().Get(serverTimingHeader)
+	assert.Regexp(t, `^db;dur=[0-9.]+, total;dur=[0-9.]+$`, header)
+}
+
+func TestUnit_Timing_LogsSegmentsAtDebugLevel(t *testing.T) {
+	next := func(c *echo.Context) error {
+		timing.Track(c.Request().Context(), timing.SegmentCache)()
+		return nil
+	}
+
+	callable := Timing()(next)
+	ctx, out := generateTestEchoContextWithLogger()
+
+	err := callable(ctx)
+	require.Nil(t, err)
+
+	actual := unmarshalLogOutput(t, *out)
+	assert.Equal(t, "DEBUG", actual.Level)
+	assert.Equal(t, "Request timing", actual.Message)
+	assert.Contains(t, out.String(), `"cache":{"duration"`)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"encoding/json"
 	stderrors "errors"
 	"net/http"
 
@@ -8,23 +9,41 @@ import (
 	"github.com/labstack/echo/v5"
 )
 
+type renderableError interface {
+	echo.HTTPStatusCoder
+	json.Marshaler
+}
+
 func wrapToHttpError(err error) error {
 	var httpErr *echo.HTTPError
 	if stderrors.As(err, &httpErr) {
 		return err
 	}
 
-	code := http.StatusInternalServerError
-	if errorWithCode, ok := err.(*errors.ErrorWithCode); ok {
-		code = errorCodeToHttpErrorCode(errorWithCode.Code)
+	// Errors providing their own status and body (e.g. validation errors
+	// with the details of the invalid fields) are rendered as is, even
+	// when wrapped: echo also looks them up in the chain.
+	var renderable renderableError
+	if stderrors.As(err, &renderable) {
+		return err
 	}
 
-	return echo.NewHTTPError(code, err.Error())
-}
-
-func errorCodeToHttpErrorCode(code errors.ErrorCode) int {
-	switch code {
-	default:
-		return http.StatusInternalServerError
+	// Only the public part of the error is returned to the client: the
+	// error is wrapped so that the full chain is still available.
+	code := http.StatusInternalServerError
+	message := errors.PublicMessageOf(err)
+	if errorCode, ok := errors.CodeOf(err); ok {
+		status := lookupErrorStatus(errorCode)
+		code = status.status
+		if status.message != "" {
+			message = status.message
+		}
+	} else if statusCode := echo.StatusCode(err); statusCode != 0 {
+		// Errors generated by echo (or its middlewares) carry their own
+		// status code which should be preserved.
+		code = statusCode
+		message = http.StatusText(statusCode)
 	}
+
+	return echo.NewHTTPError(code, message).Wrap(err)
 }
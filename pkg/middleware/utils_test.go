@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUnit_WrapToHttpError(t *testing.T) {
@@ -16,18 +19,29 @@ func TestUnit_WrapToHttpError(t *testing.T) {
 	assertIsHttpErrorWithMessageAndCode(
 		t,
 		actual,
-		"some error",
+		"an unexpected error occurred",
 		http.StatusInternalServerError,
 	)
 }
 
+func TestUnit_WrapToHttpError_ErrorWithStatusCode(t *testing.T) {
+	actual := wrapToHttpError(echo.ErrStatusRequestEntityTooLarge)
+
+	assertIsHttpErrorWithMessageAndCode(
+		t,
+		actual,
+		echo.ErrStatusRequestEntityTooLarge.Error(),
+		http.StatusRequestEntityTooLarge,
+	)
+}
+
 func TestUnit_WrapToHttpError_ErrorWithCode(t *testing.T) {
 	actual := wrapToHttpError(ErrUncaughtPanic)
 
 	assertIsHttpErrorWithMessageAndCode(
 		t,
 		actual,
-		"an unexpected error occurred. Code: 400",
+		"an unexpected error occurred",
 		http.StatusInternalServerError,
 	)
 }
@@ -40,7 +54,58 @@ func TestUnit_WrapToHttpError_ErrorWithCodeWithCause(t *testing.T) {
 	assertIsHttpErrorWithMessageAndCode(
 		t,
 		actual,
-		"an unexpected error occurred. Code: 400 (cause: some error)",
+		"an unexpected error occurred",
+		http.StatusInternalServerError,
+	)
+}
+
+func TestUnit_WrapToHttpError_ErrorWithPublicMessage(t *testing.T) {
+	err := errors.WithPublicMessage(fmt.Errorf("connection refused"), "service unavailable")
+
+	actual := wrapToHttpError(err)
+
+	assertIsHttpErrorWithMessageAndCode(
+		t,
+		actual,
+		"service unavailable",
 		http.StatusInternalServerError,
 	)
 }
+
+func TestUnit_WrapToHttpError_ExpectCauseToBePreserved(t *testing.T) {
+	err := fmt.Errorf("some error")
+
+	actual := wrapToHttpError(err)
+
+	assert.ErrorIs(t, actual, err)
+}
+
+func TestUnit_WrapToHttpError_RenderableError(t *testing.T) {
+	err := &rest.ValidationError{}
+
+	actual := wrapToHttpError(err)
+
+	assert.Equal(t, err, actual)
+}
+
+func TestUnit_WrapToHttpError_WrappedRenderableError(t *testing.T) {
+	validationErr := &rest.ValidationError{
+		Fields: []rest.FieldError{{Field: "name", Rule: "required", Message: "missing"}},
+	}
+
+	for name, err := range map[string]error{
+		"fmt":    fmt.Errorf("failed to create user: %w", validationErr),
+		"errors": errors.Wrap(validationErr, "failed to create user"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := wrapToHttpError(err)
+
+			assert.Equal(t, err, actual)
+
+			ctx, rw := generateTestEchoContext()
+			echo.DefaultHTTPErrorHandler(false)(ctx, actual)
+			assert.Equal(t, http.StatusBadRequest, rw.Code)
+			assert.Contains(t, rw.Body.String(), `"field":"name"`)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+const validatedBodyKey = "validatedBody"
+
+// ValidateBody binds the request to a value of type T and validates it
+// with rest.BindAndValidate. Invalid requests are rejected with a 400
+// listing the invalid fields, otherwise the value is available to the
+// handler with ValidatedBody.
+func ValidateBody[T any]() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			body, err := rest.BindAndValidate[T](c)
+			if err != nil {
+				return err
+			}
+
+			c.Set(validatedBodyKey, body)
+
+			return next(c)
+		}
+	}
+}
+
+// ValidatedBody returns the body validated by the ValidateBody middleware.
+// It returns false if the middleware was not used with the same type.
+func ValidatedBody[T any](c *echo.Context) (T, bool) {
+	body, ok := c.Get(validatedBodyKey).(T)
+	return body, ok
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedSample struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestUnit_ValidateBody_WhenBodyIsValid_ExpectItToBeAvailable(t *testing.T) {
+	var actual validatedSample
+	var found bool
+	next := func(c *echo.Context) error {
+		actual, found = ValidatedBody[validatedSample](c)
+		return nil
+	}
+
+	callable := ValidateBody[validatedSample]()(next)
+	ctx, _ := generateTestEchoContextFromRequest(newJsonRequest(`{"name": "foo"}`))
+
+	err := callable(ctx)
+
+	require.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, validatedSample{Name: "foo"}, actual)
+}
+
+func TestUnit_ValidateBody_WhenBodyIsInvalid_ExpectValidationError(t *testing.T) {
+	next, called := createTestEchoHandlerFuncWithCalledBoolean()
+
+	callable := ValidateBody[validatedSample]()(next)
+	ctx, _ := generateTestEchoContextFromRequest(newJsonRequest(`{}`))
+
+	err := callable(ctx)
+
+	var actual *rest.ValidationError
+	require.ErrorAs(t, err, &actual)
+	assert.False(t, *called)
+}
+
+func TestUnit_ValidatedBody_WhenMiddlewareNotUsed_ExpectNotFound(t *testing.T) {
+	ctx, _ := generateTestEchoContext()
+
+	_, found := ValidatedBody[validatedSample](ctx)
+
+	assert.False(t, found)
+}
+
+func newJsonRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return req
+}
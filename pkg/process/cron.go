@@ -0,0 +1,204 @@
+package process
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
+
+// cronSearchLimit bounds the search of the next occurrence of a schedule.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is accepted for Sunday and folded into 0.
+	{name: "day of week", min: 0, max: 7},
+}
+
+// CronSchedule is a parsed cron expression with the standard five fields
+// (minute, hour, day of month, month and day of week) supporting lists,
+// ranges, steps and the descriptors such as @daily. As with cron, when
+// both the day of month and the day of week are restricted, a day matching
+// either of them matches.
+type CronSchedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// NewCronTask creates a Runnable running the task at the times described
+// by the cron expression, in the local time zone.
+func NewCronTask(name string, expression string, task TaskFunc, opts ...TaskOption) (Runnable, error) {
+	schedule, err := ParseCron(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScheduledTask(name, schedule.Next, task, opts...), nil
+}
+
+func ParseCron(expression string) (*CronSchedule, error) {
+	if descriptor, ok := cronDescriptors[strings.TrimSpace(expression)]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, invalidCronExpression(expression, fmt.Sprintf("expected %d fields, got %d", len(cronFields), len(fields)))
+	}
+
+	var sets [5]uint64
+	for id, field := range cronFields {
+		set, err := parseCronField(fields[id], field)
+		if err != nil {
+			return nil, invalidCronExpression(expression, err.Error())
+		}
+		sets[id] = set
+	}
+
+	// Sunday can be written 0 or 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	schedule := &CronSchedule{
+		minutes:       sets[0],
+		hours:         sets[1],
+		daysOfMonth:   sets[2],
+		months:        sets[3],
+		daysOfWeek:    sets[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, invalidCronExpression(expression, "it never matches")
+	}
+
+	return schedule, nil
+}
+
+// Next returns the first time matching the schedule strictly after the
+// provided one or the zero time if there is none.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		switch {
+		case !hasBit(s.months, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !hasBit(s.hours, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !hasBit(s.minutes, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := hasBit(s.daysOfMonth, t.Day())
+	dayOfWeek := hasBit(s.daysOfWeek, int(t.Weekday()))
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// parseCronField parses a comma separated list of values, ranges (1-5),
+// steps (*/15 or 1-30/5) and wildcards into a set of bits.
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+
+	for part := range strings.SplitSeq(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q for %s", stepPart, field.name)
+			}
+		}
+
+		first, last := field.min, field.max
+		if rangePart != "*" {
+			start, end, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if first, err = parseCronValue(start, field); err != nil {
+				return 0, err
+			}
+			last = first
+			if isRange {
+				if last, err = parseCronValue(end, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				last = field.max
+			}
+
+			if first > last {
+				return 0, fmt.Errorf("invalid range %q for %s", rangePart, field.name)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	out, err := strconv.Atoi(value)
+	if err != nil || out < field.min || out > field.max {
+		return 0, fmt.Errorf("invalid value %q for %s, expected a value between %d and %d", value, field.name, field.min, field.max)
+	}
+	return out, nil
+}
+
+func hasBit(set uint64, bit int) bool {
+	return set&(1<<bit) != 0
+}
+
+func invalidCronExpression(expression string, reason string) error {
+	return errors.FromCodeAndDetails(errInvalidCronExpression, fmt.Sprintf("invalid cron expression %q: %s", expression, reason))
+}
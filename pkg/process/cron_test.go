@@ -0,0 +1,81 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_ParseCron_WhenExpressionIsInvalid_ExpectError(t *testing.T) {
+	expressions := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"0 0 30 2 *",
+	}
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseCron(expression)
+
+			assert.True(t, errors.Is(err, ErrInvalidCronExpression), "Actual err: %v", err)
+		})
+	}
+}
+
+func TestUnit_CronSchedule_Next(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, time.May, 15, 10, 42, 30, 0, time.UTC)
+
+	type testCase struct {
+		expression string
+		expected   time.Time
+	}
+
+	testCases := []testCase{
+		{expression: "* * * * *", expected: time.Date(2024, time.May, 15, 10, 43, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", expected: time.Date(2024, time.May, 15, 10, 45, 0, 0, time.UTC)},
+		{expression: "5,10 * * * *", expected: time.Date(2024, time.May, 15, 11, 5, 0, 0, time.UTC)},
+		{expression: "30 2 * * *", expected: time.Date(2024, time.May, 16, 2, 30, 0, 0, time.UTC)},
+		{expression: "0 9-17/4 * * *", expected: time.Date(2024, time.May, 15, 13, 0, 0, 0, time.UTC)},
+		{expression: "0 0 1 * *", expected: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * 0", expected: time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * 7", expected: time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * 1-5", expected: time.Date(2024, time.May, 16, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 29 2 *", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week.
+		{expression: "0 0 20 * 5", expected: time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC)},
+		{expression: "@hourly", expected: time.Date(2024, time.May, 15, 11, 0, 0, 0, time.UTC)},
+		{expression: "@yearly", expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expression)
+			require.NoError(t, err, "Actual err: %v", err)
+
+			actual := schedule.Next(now)
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUnit_NewCronTask_WhenExpressionIsInvalid_ExpectError(t *testing.T) {
+	_, err := NewCronTask("cleanup", "not a cron", func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.True(t, errors.Is(err, ErrInvalidCronExpression), "Actual err: %v", err)
+}
@@ -1,11 +1,35 @@
 package process
 
-import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+import (
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
 
 const (
-	errInvalidProcess errors.ErrorCode = 200
+	errInvalidProcess        errors.ErrorCode = 200
+	errShutdownTimeout       errors.ErrorCode = 201
+	errInvalidCronExpression errors.ErrorCode = 202
+	errWorkerPoolFull        errors.ErrorCode = 203
+	errWorkerPoolStopped     errors.ErrorCode = 204
 )
 
 var (
-	ErrInvalidProcess = errors.FromCode(errInvalidProcess)
+	ErrInvalidProcess        = errors.FromCode(errInvalidProcess)
+	ErrShutdownTimeout       = errors.FromCode(errShutdownTimeout)
+	ErrInvalidCronExpression = errors.FromCode(errInvalidCronExpression)
+	ErrWorkerPoolFull        = errors.FromCode(errWorkerPoolFull)
+	ErrWorkerPoolStopped     = errors.FromCode(errWorkerPoolStopped)
 )
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errInvalidProcess, Name: "process.invalid_process"},
+		{Code: errShutdownTimeout, Name: "process.shutdown_timeout", Message: "shutdown timed out"},
+		{Code: errInvalidCronExpression, Name: "process.invalid_cron_expression", Message: "invalid cron expression"},
+		{Code: errWorkerPoolFull, Name: "process.worker_pool_full", Message: "too many pending tasks", HttpStatus: http.StatusServiceUnavailable},
+		{Code: errWorkerPoolStopped, Name: "process.worker_pool_stopped", Message: "service is shutting down", HttpStatus: http.StatusServiceUnavailable},
+	} {
+		errors.Register(info)
+	}
+}
@@ -0,0 +1,137 @@
+package process
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateStarting State = "starting"
+	StateRunning  State = "running"
+	// StateDegraded is reported by a component still running but not
+	// fully functional, e.g. a consumer whose broker is unreachable.
+	StateDegraded State = "degraded"
+	StateStopped  State = "stopped"
+)
+
+type ComponentHealth struct {
+	Name  string    `json:"name"`
+	State State     `json:"state"`
+	Since time.Time `json:"since"`
+	// LastError is the last error reported by the component, even if it
+	// recovered since then.
+	LastError string `json:"lastError,omitempty"`
+
+	// failed is set when the component stopped with an error.
+	failed bool
+}
+
+// Health gathers the state of the components of a service (servers,
+// consumers, workers...) so that the readiness of the service reflects all
+// of them and not only the HTTP listener.
+type Health struct {
+	lock       sync.RWMutex
+	components map[string]ComponentHealth
+}
+
+func NewHealth() *Health {
+	return &Health{
+		components: make(map[string]ComponentHealth),
+	}
+}
+
+// Report updates the state of the component, registering it if needed.
+// The error, if any, is kept as the last error of the component.
+func (h *Health) Report(name string, state State, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	component, ok := h.components[name]
+	if !ok || component.State != state {
+		component.Since = time.Now()
+	}
+
+	component.Name = name
+	component.State = state
+	component.failed = state == StateStopped && err != nil
+	if err != nil {
+		component.LastError = err.Error()
+	}
+
+	h.components[name] = component
+}
+
+// Components returns the state of the components ordered by name.
+func (h *Health) Components() []ComponentHealth {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	out := make([]ComponentHealth, 0, len(h.components))
+	for _, name := range slices.Sorted(maps.Keys(h.components)) {
+		out = append(out, h.components[name])
+	}
+	return out
+}
+
+// Ready returns true when all the components are running, possibly in a
+// degraded state.
+func (h *Health) Ready() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, component := range h.components {
+		if component.State != StateRunning && component.State != StateDegraded {
+			return false
+		}
+	}
+	return true
+}
+
+// Live returns false when one of the components stopped with an error.
+func (h *Health) Live() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, component := range h.components {
+		if component.failed {
+			return false
+		}
+	}
+	return true
+}
+
+// Monitor registers the runnable as a starting component and returns a
+// Runnable reporting its state: running once started and stopped, with
+// its error, when Start returns or panics.
+func (h *Health) Monitor(name string, runnable Runnable) Runnable {
+	h.Report(name, StateStarting, nil)
+
+	return &monitoredRunnable{
+		name:     name,
+		runnable: runnable,
+		health:   h,
+	}
+}
+
+type monitoredRunnable struct {
+	name     string
+	runnable Runnable
+	health   *Health
+}
+
+func (m *monitoredRunnable) Start() error {
+	m.health.Report(m.name, StateRunning, nil)
+	// A panic is reported as an error: the component would otherwise stay
+	// running.
+	err := SafeRunSync(m.runnable.Start)
+	m.health.Report(m.name, StateStopped, err)
+	return err
+}
+
+func (m *monitoredRunnable) Stop() error {
+	return m.runnable.Stop()
+}
@@ -0,0 +1,114 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Health_WhenEmpty_ExpectReadyAndLive(t *testing.T) {
+	health := NewHealth()
+
+	assert.True(t, health.Ready())
+	assert.True(t, health.Live())
+	assert.Empty(t, health.Components())
+}
+
+func TestUnit_Health_Report(t *testing.T) {
+	health := NewHealth()
+
+	health.Report("consumer", StateRunning, nil)
+	health.Report("consumer", StateDegraded, errSample)
+	health.Report("consumer", StateRunning, nil)
+
+	actual := health.Components()
+	require.Len(t, actual, 1)
+	assert.Equal(t, "consumer", actual[0].Name)
+	assert.Equal(t, StateRunning, actual[0].State)
+	assert.Equal(t, errSample.Error(), actual[0].LastError)
+	assert.False(t, actual[0].Since.IsZero())
+}
+
+func TestUnit_Health_Ready(t *testing.T) {
+	type testCase struct {
+		state    State
+		expected bool
+	}
+
+	testCases := []testCase{
+		{state: StateStarting, expected: false},
+		{state: StateRunning, expected: true},
+		{state: StateDegraded, expected: true},
+		{state: StateStopped, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.state), func(t *testing.T) {
+			health := NewHealth()
+			health.Report("server", StateRunning, nil)
+			health.Report("consumer", tc.state, nil)
+
+			assert.Equal(t, tc.expected, health.Ready())
+		})
+	}
+}
+
+func TestUnit_Health_Live(t *testing.T) {
+	health := NewHealth()
+	health.Report("server", StateStopped, nil)
+	assert.True(t, health.Live())
+
+	health.Report("consumer", StateStopped, errSample)
+	assert.False(t, health.Live())
+
+	health.Report("consumer", StateRunning, nil)
+	assert.True(t, health.Live())
+}
+
+func TestUnit_Health_Monitor(t *testing.T) {
+	health := NewHealth()
+	runnable := newFlakyRunnable(1)
+
+	monitored := health.Monitor("consumer", runnable)
+	assert.Equal(t, StateStarting, health.Components()[0].State)
+
+	err := monitored.Start()
+
+	assert.Equal(t, errTransient, err)
+	actual := health.Components()[0]
+	assert.Equal(t, StateStopped, actual.State)
+	assert.Equal(t, errTransient.Error(), actual.LastError)
+	assert.False(t, health.Live())
+}
+
+func TestUnit_Health_Monitor_ExpectRunningWhileStarted(t *testing.T) {
+	health := NewHealth()
+	runnable := newFlakyRunnable(0)
+	monitored := health.Monitor("consumer", runnable)
+
+	done := SafeRunAsync(monitored.Start)
+	assert.Eventually(t, health.Ready, time.Second, time.Millisecond)
+
+	err := monitored.Stop()
+	require.NoError(t, err, "Actual err: %v", err)
+	<-done
+
+	assert.Equal(t, StateStopped, health.Components()[0].State)
+	assert.True(t, health.Live())
+}
+
+func TestUnit_Health_Monitor_WhenStartPanics_ExpectStoppedWithError(t *testing.T) {
+	health := NewHealth()
+	monitored := health.Monitor("consumer", panickingRunnable{err: errSample})
+
+	err := SafeRunSync(monitored.Start)
+
+	assert.Equal(t, errSample, err)
+	actual := health.Components()[0]
+	assert.Equal(t, StateStopped, actual.State)
+	assert.Equal(t, errSample.Error(), actual.LastError)
+	assert.False(t, health.Ready())
+	assert.False(t, health.Live())
+}
@@ -8,10 +8,14 @@ type Runnable interface {
 }
 
 func StartWithSignalHandler(ctx context.Context, runnable Runnable) (WaitFunc, error) {
+	return StartWithSignalConfig(ctx, runnable, SignalConfig{})
+}
+
+func StartWithSignalConfig(ctx context.Context, runnable Runnable, config SignalConfig) (WaitFunc, error) {
 	process := Process{
 		Run:       runnable.Start,
 		Interrupt: runnable.Stop,
 	}
 
-	return AsyncStartWithSignalHandler(ctx, process)
+	return AsyncStartWithSignalConfig(ctx, process, config)
 }
@@ -0,0 +1,135 @@
+package process
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
+
+// ShutdownPhase orders the shutdown hooks: the hooks of a phase run once
+// all the hooks of the previous phases are done.
+type ShutdownPhase int
+
+const (
+	PhaseStopAcceptingTraffic ShutdownPhase = iota
+	PhaseDrainRequests
+	PhaseStopWorkers
+	PhaseCloseResources
+)
+
+type ShutdownHook struct {
+	// Name identifies the hook in the errors, e.g. "close db".
+	Name  string
+	Phase ShutdownPhase
+	// Timeout bounds the duration of the hook: the context it receives is
+	// cancelled once it is elapsed and the shutdown moves on to the other
+	// hooks. No bound when it is 0.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// ShutdownCoordinator runs the shutdown hooks registered by the components
+// of a service phase after phase, so that e.g. the database is closed once
+// the requests are drained. The hooks of a phase run concurrently.
+type ShutdownCoordinator struct {
+	lock  sync.Mutex
+	hooks []ShutdownHook
+
+	once sync.Once
+	err  error
+}
+
+func NewShutdownCoordinator() *ShutdownCoordinator {
+	return &ShutdownCoordinator{}
+}
+
+func (c *ShutdownCoordinator) Register(hook ShutdownHook) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+}
+
+// Shutdown runs the hooks and returns their errors aggregated with
+// errors.Join. A failing hook does not prevent the next ones from running.
+// The hooks are only run once: subsequent calls return the same errors.
+func (c *ShutdownCoordinator) Shutdown(ctx context.Context) error {
+	c.once.Do(func() {
+		c.err = c.runPhases(ctx)
+	})
+	return c.err
+}
+
+// Interrupt runs the shutdown. It allows the coordinator to be used as the
+// Interrupt of a Process started with AsyncStartWithSignalHandler.
+func (c *ShutdownCoordinator) Interrupt() error {
+	return c.Shutdown(context.Background())
+}
+
+func (c *ShutdownCoordinator) runPhases(ctx context.Context) error {
+	c.lock.Lock()
+	hooks := slices.Clone(c.hooks)
+	c.lock.Unlock()
+
+	slices.SortStableFunc(hooks, func(lhs ShutdownHook, rhs ShutdownHook) int {
+		return int(lhs.Phase) - int(rhs.Phase)
+	})
+
+	var errs []error
+	for start := 0; start < len(hooks); {
+		end := start + 1
+		for end < len(hooks) && hooks[end].Phase == hooks[start].Phase {
+			end++
+		}
+
+		errs = append(errs, runPhase(ctx, hooks[start:end])...)
+		start = end
+	}
+
+	return errors.Join(errs...)
+}
+
+func runPhase(ctx context.Context, hooks []ShutdownHook) []error {
+	errs := make([]error, len(hooks))
+
+	var wg sync.WaitGroup
+	for id, hook := range hooks {
+		wg.Go(func() {
+			if err := runHook(ctx, hook); err != nil {
+				errs[id] = errors.Wrapf(err, "shutdown hook %q failed", hook.Name)
+			}
+		})
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func runHook(ctx context.Context, hook ShutdownHook) error {
+	if hook.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hook.Timeout)
+		defer cancel()
+	}
+
+	done := SafeRunAsync(func() error {
+		return hook.Run(ctx)
+	})
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	// The hook might have completed right when the timeout elapsed.
+	select {
+	case err := <-done:
+		return err
+	default:
+		return ErrShutdownTimeout
+	}
+}
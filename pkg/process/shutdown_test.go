@@ -0,0 +1,127 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordingHook(name string, phase ShutdownPhase, events *eventRecorder) ShutdownHook {
+	return ShutdownHook{
+		Name:  name,
+		Phase: phase,
+		Run: func(ctx context.Context) error {
+			events.record(name)
+			return nil
+		},
+	}
+}
+
+func TestUnit_ShutdownCoordinator_ExpectPhasesToRunInOrder(t *testing.T) {
+	events := &eventRecorder{}
+	c := NewShutdownCoordinator()
+	c.Register(newRecordingHook("close db", PhaseCloseResources, events))
+	c.Register(newRecordingHook("drain requests", PhaseDrainRequests, events))
+	c.Register(newRecordingHook("stop accepting traffic", PhaseStopAcceptingTraffic, events))
+
+	err := c.Shutdown(context.Background())
+
+	require.NoError(t, err, "Actual err: %v", err)
+	expected := []string{"stop accepting traffic", "drain requests", "close db"}
+	assert.Equal(t, expected, events.recorded())
+}
+
+func TestUnit_ShutdownCoordinator_ExpectHooksOfPhaseToRunConcurrently(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(2)
+	hook := func(ctx context.Context) error {
+		started.Done()
+		// Only returns once both hooks are running.
+		started.Wait()
+		return nil
+	}
+
+	c := NewShutdownCoordinator()
+	c.Register(ShutdownHook{Name: "outbox", Phase: PhaseStopWorkers, Timeout: time.Second, Run: hook})
+	c.Register(ShutdownHook{Name: "listener", Phase: PhaseStopWorkers, Timeout: time.Second, Run: hook})
+
+	err := c.Shutdown(context.Background())
+
+	assert.NoError(t, err, "Actual err: %v", err)
+}
+
+func TestUnit_ShutdownCoordinator_WhenHookFails_ExpectNextPhasesToRun(t *testing.T) {
+	events := &eventRecorder{}
+	hookErr := fmt.Errorf("some error")
+	c := NewShutdownCoordinator()
+	c.Register(ShutdownHook{
+		Name:  "drain requests",
+		Phase: PhaseDrainRequests,
+		Run: func(ctx context.Context) error {
+			return hookErr
+		},
+	})
+	c.Register(newRecordingHook("close db", PhaseCloseResources, events))
+
+	err := c.Shutdown(context.Background())
+
+	assert.ErrorIs(t, err, hookErr)
+	assert.ErrorContains(t, err, `shutdown hook "drain requests" failed`)
+	assert.Equal(t, []string{"close db"}, events.recorded())
+}
+
+func TestUnit_ShutdownCoordinator_WhenHookExceedsTimeout_ExpectTimeoutError(t *testing.T) {
+	events := &eventRecorder{}
+	c := NewShutdownCoordinator()
+	c.Register(ShutdownHook{
+		Name:    "drain requests",
+		Phase:   PhaseDrainRequests,
+		Timeout: 20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			// Voluntarily ignoring the context.
+			time.Sleep(time.Second)
+			return nil
+		},
+	})
+	c.Register(newRecordingHook("close db", PhaseCloseResources, events))
+
+	start := time.Now()
+	err := c.Shutdown(context.Background())
+
+	assert.True(t, errors.IsErrorWithCode(err, errShutdownTimeout), "Actual err: %v", err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, []string{"close db"}, events.recorded())
+}
+
+func TestUnit_ShutdownCoordinator_WhenHookPanics_ExpectError(t *testing.T) {
+	c := NewShutdownCoordinator()
+	c.Register(ShutdownHook{
+		Name: "close db",
+		Run: func(ctx context.Context) error {
+			panic(errSample)
+		},
+	})
+
+	err := c.Shutdown(context.Background())
+
+	assert.ErrorIs(t, err, errSample)
+}
+
+func TestUnit_ShutdownCoordinator_WhenCalledTwice_ExpectHooksToRunOnce(t *testing.T) {
+	events := &eventRecorder{}
+	c := NewShutdownCoordinator()
+	c.Register(newRecordingHook("close db", PhaseCloseResources, events))
+
+	err := c.Interrupt()
+	require.NoError(t, err, "Actual err: %v", err)
+	err = c.Interrupt()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, []string{"close db"}, events.recorded())
+}
@@ -0,0 +1,109 @@
+package process
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordExit replaces the exit of the program and returns a channel
+// receiving the exit codes.
+func recordExit(t *testing.T) <-chan int {
+	codes := make(chan int, 1)
+	exit = func(code int) {
+		codes <- code
+	}
+	t.Cleanup(func() {
+		exit = os.Exit
+	})
+
+	return codes
+}
+
+func sendSignal(t *testing.T, sig syscall.Signal) {
+	err := syscall.Kill(os.Getpid(), sig)
+	require.NoError(t, err, "Actual err: %v", err)
+}
+
+func TestUnit_NotifyShutdown_WhenSignalIsReceived_ExpectContextDone(t *testing.T) {
+	ctx, stop := notifyShutdown(context.Background(), SignalConfig{Signals: []os.Signal{syscall.SIGUSR1}})
+	defer stop()
+
+	sendSignal(t, syscall.SIGUSR1)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		assert.Fail(t, "Context should be done after the signal")
+	}
+}
+
+func TestUnit_NotifyShutdown_WhenSecondSignalIsReceived_ExpectForcedExit(t *testing.T) {
+	codes := recordExit(t)
+	ctx, stop := notifyShutdown(context.Background(), SignalConfig{Signals: []os.Signal{syscall.SIGUSR1}})
+	defer stop()
+
+	sendSignal(t, syscall.SIGUSR1)
+	<-ctx.Done()
+	sendSignal(t, syscall.SIGUSR1)
+
+	select {
+	case code := <-codes:
+		assert.Equal(t, 1, code)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Program should exit after the second signal")
+	}
+}
+
+func TestUnit_NotifyShutdown_WhenForceQuitTimeoutIsElapsed_ExpectForcedExit(t *testing.T) {
+	codes := recordExit(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	_, stop := notifyShutdown(ctx, SignalConfig{ForceQuitTimeout: 20 * time.Millisecond})
+	defer stop()
+
+	cancel()
+
+	select {
+	case code := <-codes:
+		assert.Equal(t, 1, code)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Program should exit after the timeout")
+	}
+}
+
+func TestUnit_AsyncStartWithSignalConfig_WhenShutdownIsGraceful_ExpectNoForcedExit(t *testing.T) {
+	codes := recordExit(t)
+	stopChan := make(chan struct{}, 1)
+	process := Process{
+		Run: func() error {
+			<-stopChan
+			return nil
+		},
+		Interrupt: func() error {
+			stopChan <- struct{}{}
+			return nil
+		},
+	}
+	config := SignalConfig{
+		Signals:          []os.Signal{syscall.SIGUSR1},
+		ForceQuitTimeout: 50 * time.Millisecond,
+	}
+
+	wait, err := AsyncStartWithSignalConfig(context.Background(), process, config)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	sendSignal(t, syscall.SIGUSR1)
+	err = wait()
+
+	require.NoError(t, err, "Actual err: %v", err)
+	select {
+	case <-codes:
+		assert.Fail(t, "Program should not exit after a graceful shutdown")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -2,14 +2,34 @@ package process
 
 import (
 	"context"
+	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 )
 
+// defaultSignals are the signals requesting a graceful shutdown: SIGTERM
+// is sent by Kubernetes and SIGINT by a Ctrl+C in the terminal.
 var defaultSignals = []os.Signal{
 	syscall.SIGINT,
-	os.Interrupt,
+	syscall.SIGTERM,
+}
+
+// exit terminates the program when the shutdown is forced. It is replaced
+// in the tests.
+var exit = os.Exit
+
+// SignalConfig defines how the interruption signals are handled.
+type SignalConfig struct {
+	// Signals requesting a graceful shutdown. Defaults to SIGINT and
+	// SIGTERM. Receiving one of them a second time while the shutdown is
+	// in progress forces an immediate exit.
+	Signals []os.Signal
+	// ForceQuitTimeout bounds the duration of the graceful shutdown: the
+	// program exits immediately when it is elapsed. No bound when it is 0.
+	ForceQuitTimeout time.Duration
 }
 
 type WaitFunc func() error
@@ -17,12 +37,20 @@ type WaitFunc func() error
 func AsyncStartWithSignalHandler(
 	ctx context.Context,
 	process Process,
+) (WaitFunc, error) {
+	return AsyncStartWithSignalConfig(ctx, process, SignalConfig{})
+}
+
+func AsyncStartWithSignalConfig(
+	ctx context.Context,
+	process Process,
+	config SignalConfig,
 ) (WaitFunc, error) {
 	if !process.Valid() {
 		return nil, ErrInvalidProcess
 	}
 
-	sCtx, stop := signal.NotifyContext(ctx, defaultSignals...)
+	sCtx, stop := notifyShutdown(ctx, config)
 
 	done := make(chan error, 1)
 
@@ -62,3 +90,58 @@ func AsyncStartWithSignalHandler(
 
 	return waitFunc, nil
 }
+
+// notifyShutdown returns a context which is done when the parent one is or
+// when one of the signals of the configuration is received. From then on,
+// a new signal or the force quit timeout elapsing exits the program until
+// the returned function is called, typically when the graceful shutdown
+// is complete.
+func notifyShutdown(ctx context.Context, config SignalConfig) (context.Context, func()) {
+	signals := config.Signals
+	if len(signals) == 0 {
+		signals = defaultSignals
+	}
+
+	sCtx, cancel := context.WithCancel(ctx)
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-received:
+			cancel()
+		case <-sCtx.Done():
+		case <-done:
+			return
+		}
+
+		var timeout <-chan time.Time
+		if config.ForceQuitTimeout > 0 {
+			timer := time.NewTimer(config.ForceQuitTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case sig := <-received:
+			slog.Error("Forcing exit", slog.String("signal", sig.String()))
+			exit(1)
+		case <-timeout:
+			slog.Error("Forcing exit", slog.Duration("timeout", config.ForceQuitTimeout))
+			exit(1)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+			cancel()
+		})
+	}
+
+	return sCtx, stop
+}
@@ -0,0 +1,69 @@
+package process
+
+import (
+	"context"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
+
+// StartAllWithSignalHandler starts the runnables (e.g. a server and the
+// workers of a service) together. As soon as one of them stops, whether
+// it failed or not, the context is done or an interruption signal is
+// received, the runnables still running are stopped in the reverse order
+// of registration: each one is stopped once the next one terminated.
+// The returned function waits for all of them and returns the errors of
+// all the runnables aggregated with errors.Join.
+func StartAllWithSignalHandler(ctx context.Context, runnables ...Runnable) (WaitFunc, error) {
+	return StartAllWithSignalConfig(ctx, SignalConfig{}, runnables...)
+}
+
+func StartAllWithSignalConfig(ctx context.Context, config SignalConfig, runnables ...Runnable) (WaitFunc, error) {
+	if len(runnables) == 0 {
+		return nil, ErrInvalidProcess
+	}
+	for _, runnable := range runnables {
+		if runnable == nil {
+			return nil, ErrInvalidProcess
+		}
+	}
+
+	sCtx, stop := notifyShutdown(ctx, config)
+
+	stopped := make(chan struct{}, len(runnables))
+	done := make([]chan struct{}, len(runnables))
+	startErrs := make([]error, len(runnables))
+
+	for id, runnable := range runnables {
+		done[id] = make(chan struct{})
+
+		go func() {
+			startErrs[id] = SafeRunSync(runnable.Start)
+			close(done[id])
+			stopped <- struct{}{}
+		}()
+	}
+
+	waitFunc := func() error {
+		defer stop()
+
+		select {
+		case <-sCtx.Done():
+		case <-stopped:
+		}
+
+		var stopErrs []error
+		for id := len(runnables) - 1; id >= 0; id-- {
+			select {
+			case <-done[id]:
+				// Already terminated, there's nothing to stop.
+			default:
+				stopErrs = append(stopErrs, SafeRunSync(runnables[id].Stop))
+				<-done[id]
+			}
+		}
+
+		return errors.Join(append(startErrs, stopErrs...)...)
+	}
+
+	return waitFunc, nil
+}
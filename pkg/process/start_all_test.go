@@ -0,0 +1,150 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRunnable struct {
+	name     string
+	startErr error
+	stopErr  error
+	// exitOnItsOwn makes Start return straight away instead of waiting
+	// for Stop to be called.
+	exitOnItsOwn bool
+
+	stopChan chan struct{}
+	events   *eventRecorder
+}
+
+type eventRecorder struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (r *eventRecorder) record(event string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) recorded() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.events...)
+}
+
+func newMockRunnable(name string, events *eventRecorder) *mockRunnable {
+	return &mockRunnable{
+		name:     name,
+		stopChan: make(chan struct{}, 1),
+		events:   events,
+	}
+}
+
+func (m *mockRunnable) Start() error {
+	if !m.exitOnItsOwn {
+		<-m.stopChan
+	}
+	m.events.record(m.name + " terminated")
+	return m.startErr
+}
+
+func (m *mockRunnable) Stop() error {
+	m.events.record(m.name + " stopped")
+	m.stopChan <- struct{}{}
+	return m.stopErr
+}
+
+func TestUnit_StartAllWithSignalHandler_WhenRunnablesAreInvalid_ExpectError(t *testing.T) {
+	_, err := StartAllWithSignalHandler(context.Background())
+	assert.Equal(t, ErrInvalidProcess, err, "Actual err: %v", err)
+
+	_, err = StartAllWithSignalHandler(context.Background(), newMockRunnable("foo", &eventRecorder{}), nil)
+	assert.Equal(t, ErrInvalidProcess, err, "Actual err: %v", err)
+}
+
+func TestUnit_StartAllWithSignalHandler_WhenContextIsCancelled_ExpectStopInReverseOrder(t *testing.T) {
+	events := &eventRecorder{}
+	first, second, third := newMockRunnable("first", events), newMockRunnable("second", events), newMockRunnable("third", events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	wait, err := StartAllWithSignalHandler(ctx, first, second, third)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = wait()
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	expected := []string{
+		"third stopped", "third terminated",
+		"second stopped", "second terminated",
+		"first stopped", "first terminated",
+	}
+	assert.Equal(t, expected, events.recorded())
+}
+
+func TestUnit_StartAllWithSignalHandler_WhenRunnableFails_ExpectOthersToBeStopped(t *testing.T) {
+	events := &eventRecorder{}
+	first, second := newMockRunnable("first", events), newMockRunnable("second", events)
+	second.exitOnItsOwn = true
+	second.startErr = fmt.Errorf("listener failed")
+
+	wait, err := StartAllWithSignalHandler(context.Background(), first, second)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = wait()
+
+	assert.ErrorIs(t, err, second.startErr)
+	expected := []string{"second terminated", "first stopped", "first terminated"}
+	assert.Equal(t, expected, events.recorded())
+}
+
+func TestUnit_StartAllWithSignalHandler_ExpectErrorsToBeAggregated(t *testing.T) {
+	events := &eventRecorder{}
+	first, second := newMockRunnable("first", events), newMockRunnable("second", events)
+	first.stopErr = fmt.Errorf("stop failed")
+	second.exitOnItsOwn = true
+	second.startErr = fmt.Errorf("start failed")
+
+	wait, err := StartAllWithSignalHandler(context.Background(), first, second)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = wait()
+
+	assert.ErrorIs(t, err, first.stopErr)
+	assert.ErrorIs(t, err, second.startErr)
+}
+
+func TestUnit_StartAllWithSignalHandler_WhenRunnablePanics_ExpectErrorAndOthersToBeStopped(t *testing.T) {
+	events := &eventRecorder{}
+	first := newMockRunnable("first", events)
+	panicErr := fmt.Errorf("poller panicked")
+
+	wait, err := StartAllWithSignalHandler(context.Background(), first, panickingRunnable{err: panicErr})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = wait()
+
+	assert.ErrorIs(t, err, panicErr)
+	assert.Equal(t, []string{"first stopped", "first terminated"}, events.recorded())
+}
+
+type panickingRunnable struct {
+	err error
+}
+
+func (p panickingRunnable) Start() error {
+	panic(p.err)
+}
+
+func (p panickingRunnable) Stop() error {
+	return nil
+}
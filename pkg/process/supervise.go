@@ -0,0 +1,139 @@
+package process
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+)
+
+const (
+	defaultRestartInitialInterval = 500 * time.Millisecond
+	defaultRestartMaxInterval     = 30 * time.Second
+	defaultRestartMultiplier      = 2
+	defaultRestartResetAfter      = time.Minute
+)
+
+// RestartFunc is called before restarting a runnable with the number of
+// the restart, the error which stopped it and the wait before the restart.
+// It can be used to count the restarts in a metric.
+type RestartFunc func(attempt int, err error, wait time.Duration)
+
+// RestartPolicy defines how a supervised runnable is restarted. Zero values
+// use the defaults: 500ms initially, doubling up to 30s, without limit on
+// the number of restarts.
+type RestartPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// MaxAttempts bounds the number of consecutive restarts. No limit
+	// when it is 0.
+	MaxAttempts int
+	// ResetAfter is the duration after which a runnable is considered
+	// healthy: when it fails after running for this long, the restarts
+	// start again from the initial interval. Defaults to 1 min.
+	ResetAfter time.Duration
+	OnRestart  RestartFunc
+	// Logger receives a log for each restart. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// Supervise runs the runnable and restarts it with an exponential backoff
+// when it fails or panics, e.g. for a consumer which should survive the
+// outages of its broker. It returns nil when the runnable terminates without
+// error and the last error when the maximum number of restarts is reached.
+// When the context is done, the runnable is stopped and Supervise returns
+// once it terminated.
+func Supervise(ctx context.Context, runnable Runnable, policy RestartPolicy) error {
+	if runnable == nil {
+		return ErrInvalidProcess
+	}
+
+	policy = policy.withDefaults()
+
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+
+		err := runSupervised(ctx, runnable)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		if time.Since(started) >= policy.ResetAfter {
+			attempt = 1
+		}
+
+		if policy.MaxAttempts > 0 && attempt > policy.MaxAttempts {
+			policy.Logger.ErrorContext(ctx, "Giving up restarting process", slog.Int("attempt", attempt), slog.Any("error", err))
+			return err
+		}
+
+		wait := policy.delay(attempt)
+		policy.Logger.WarnContext(
+			ctx,
+			"Process failed",
+			slog.Int("attempt", attempt),
+			slog.Duration("restartIn", wait),
+			slog.Any("error", err),
+		)
+		if policy.OnRestart != nil {
+			policy.OnRestart(attempt, err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func runSupervised(ctx context.Context, runnable Runnable) error {
+	done := SafeRunAsync(runnable.Start)
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		stopErr := SafeRunSync(runnable.Stop)
+		return errors.Join(<-done, stopErr)
+	}
+}
+
+func (p RestartPolicy) withDefaults() RestartPolicy {
+	if p.InitialInterval == 0 {
+		p.InitialInterval = defaultRestartInitialInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = defaultRestartMaxInterval
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultRestartMultiplier
+	}
+	if p.ResetAfter == 0 {
+		p.ResetAfter = defaultRestartResetAfter
+	}
+	if p.Logger == nil {
+		p.Logger = slog.Default()
+	}
+	return p
+}
+
+// delay returns the wait before the restart. The interval grows
+// exponentially and is randomized by +/- 50% so that several instances
+// do not restart in lockstep.
+func (p RestartPolicy) delay(attempt int) time.Duration {
+	interval := float64(p.InitialInterval)
+	for range attempt - 1 {
+		interval *= p.Multiplier
+		if interval >= float64(p.MaxInterval) {
+			interval = float64(p.MaxInterval)
+			break
+		}
+	}
+
+	jitter := 0.5 + rand.Float64()
+	return time.Duration(interval * jitter)
+}
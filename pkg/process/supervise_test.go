@@ -0,0 +1,167 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = fmt.Errorf("broker unavailable")
+
+// flakyRunnable fails the first runs and then runs until stopped.
+type flakyRunnable struct {
+	failures int
+	panics   bool
+	runs     atomic.Int32
+	stopChan chan struct{}
+}
+
+func newFlakyRunnable(failures int) *flakyRunnable {
+	return &flakyRunnable{
+		failures: failures,
+		stopChan: make(chan struct{}, 1),
+	}
+}
+
+func (f *flakyRunnable) Start() error {
+	if int(f.runs.Add(1)) <= f.failures {
+		if f.panics {
+			panic(errTransient)
+		}
+		return errTransient
+	}
+
+	<-f.stopChan
+	return nil
+}
+
+func (f *flakyRunnable) Stop() error {
+	f.stopChan <- struct{}{}
+	return nil
+}
+
+func newTestRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestUnit_RestartPolicy_Delay(t *testing.T) {
+	policy := RestartPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+	}.withDefaults()
+
+	type testCase struct {
+		attempt  int
+		interval time.Duration
+	}
+
+	testCases := []testCase{
+		{attempt: 1, interval: time.Second},
+		{attempt: 2, interval: 2 * time.Second},
+		{attempt: 3, interval: 4 * time.Second},
+		{attempt: 4, interval: 5 * time.Second},
+		{attempt: 50, interval: 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		actual := policy.delay(tc.attempt)
+
+		assert.GreaterOrEqual(t, actual, tc.interval/2, "Attempt %d", tc.attempt)
+		assert.LessOrEqual(t, actual, tc.interval*3/2, "Attempt %d", tc.attempt)
+	}
+}
+
+func TestUnit_Supervise_WhenRunnableIsNil_ExpectError(t *testing.T) {
+	err := Supervise(context.Background(), nil, RestartPolicy{})
+
+	assert.Equal(t, ErrInvalidProcess, err, "Actual err: %v", err)
+}
+
+func TestUnit_Supervise_WhenRunnableFails_ExpectRestart(t *testing.T) {
+	runnable := newFlakyRunnable(2)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var restarts []int
+	policy := newTestRestartPolicy()
+	policy.OnRestart = func(attempt int, err error, wait time.Duration) {
+		assert.Equal(t, errTransient, err)
+		restarts = append(restarts, attempt)
+	}
+
+	err := Supervise(ctx, runnable, policy)
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int32(3), runnable.runs.Load())
+	assert.Equal(t, []int{1, 2}, restarts)
+}
+
+func TestUnit_Supervise_WhenRunnablePanics_ExpectRestart(t *testing.T) {
+	runnable := newFlakyRunnable(1)
+	runnable.panics = true
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := Supervise(ctx, runnable, newTestRestartPolicy())
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int32(2), runnable.runs.Load())
+}
+
+func TestUnit_Supervise_WhenMaxAttemptsIsReached_ExpectLastError(t *testing.T) {
+	runnable := newFlakyRunnable(10)
+	policy := newTestRestartPolicy()
+	policy.MaxAttempts = 3
+
+	err := Supervise(context.Background(), runnable, policy)
+
+	assert.Equal(t, errTransient, err, "Actual err: %v", err)
+	assert.Equal(t, int32(4), runnable.runs.Load())
+}
+
+func TestUnit_Supervise_WhenRunnableTerminates_ExpectNoRestart(t *testing.T) {
+	var runs int
+
+	err := Supervise(context.Background(), terminatingRunnable{runs: &runs}, newTestRestartPolicy())
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, 1, runs)
+}
+
+func TestUnit_Supervise_WhenContextIsCancelledDuringBackoff_ExpectNoError(t *testing.T) {
+	runnable := newFlakyRunnable(10)
+	policy := newTestRestartPolicy()
+	policy.InitialInterval = time.Hour
+	policy.MaxInterval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := Supervise(ctx, runnable, policy)
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int32(1), runnable.runs.Load())
+}
+
+type terminatingRunnable struct {
+	runs *int
+}
+
+func (r terminatingRunnable) Start() error {
+	*r.runs++
+	return nil
+}
+
+func (r terminatingRunnable) Stop() error {
+	return nil
+}
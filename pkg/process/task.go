@@ -0,0 +1,118 @@
+package process
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// TaskFunc is a periodic task. The context is cancelled when the task is
+// stopped.
+type TaskFunc func(ctx context.Context) error
+
+// TaskRun describes an execution of a periodic task, e.g. to report its
+// duration in a metric.
+type TaskRun struct {
+	Name     string
+	Started  time.Time
+	Duration time.Duration
+	Err      error
+}
+
+type TaskOption func(*taskOptions)
+
+type taskOptions struct {
+	log   *slog.Logger
+	onRun func(TaskRun)
+}
+
+// WithTaskLogger defines the logger receiving a log for each run of the
+// task. Defaults to slog.Default().
+func WithTaskLogger(log *slog.Logger) TaskOption {
+	return func(o *taskOptions) {
+		o.log = log
+	}
+}
+
+// WithTaskObserver registers a function called after each run of the task.
+func WithTaskObserver(onRun func(TaskRun)) TaskOption {
+	return func(o *taskOptions) {
+		o.onRun = onRun
+	}
+}
+
+// scheduledTask runs a task at the times returned by next until it is
+// stopped. The runs never overlap: the next run is scheduled once the
+// current one is complete, skipping the occurrences missed meanwhile.
+type scheduledTask struct {
+	name string
+	next func(now time.Time) time.Time
+	task TaskFunc
+	opts taskOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newScheduledTask(name string, next func(time.Time) time.Time, task TaskFunc, opts ...TaskOption) *scheduledTask {
+	t := &scheduledTask{
+		name: name,
+		next: next,
+		task: task,
+		opts: taskOptions{log: slog.Default()},
+	}
+	for _, opt := range opts {
+		opt(&t.opts)
+	}
+
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+
+	return t
+}
+
+func (t *scheduledTask) Start() error {
+	for {
+		timer := time.NewTimer(time.Until(t.next(time.Now())))
+
+		select {
+		case <-t.ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		t.run()
+	}
+}
+
+func (t *scheduledTask) Stop() error {
+	t.cancel()
+	return nil
+}
+
+func (t *scheduledTask) run() {
+	run := TaskRun{
+		Name:    t.name,
+		Started: time.Now(),
+	}
+
+	run.Err = SafeRunSync(func() error {
+		return t.task(t.ctx)
+	})
+	run.Duration = time.Since(run.Started)
+
+	if run.Err != nil {
+		t.opts.log.Error(
+			"Task failed",
+			slog.String("task", t.name),
+			slog.Duration("duration", run.Duration),
+			slog.Any("error", run.Err),
+		)
+	} else {
+		t.opts.log.Debug("Task completed", slog.String("task", t.name), slog.Duration("duration", run.Duration))
+	}
+
+	if t.opts.onRun != nil {
+		t.opts.onRun(run)
+	}
+}
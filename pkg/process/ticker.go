@@ -0,0 +1,22 @@
+package process
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// NewTicker creates a Runnable running the task every interval, e.g. to
+// clean up expired data. Each wait is randomized by up to jitter so that
+// several instances of a service do not run the task in lockstep. The
+// interval is counted from the end of the previous run.
+func NewTicker(name string, interval time.Duration, jitter time.Duration, task TaskFunc, opts ...TaskOption) Runnable {
+	next := func(now time.Time) time.Time {
+		wait := interval
+		if jitter > 0 {
+			wait += rand.N(jitter)
+		}
+		return now.Add(wait)
+	}
+
+	return newScheduledTask(name, next, task, opts...)
+}
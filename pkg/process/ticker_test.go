@@ -0,0 +1,98 @@
+package process
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestUnit_Ticker_ExpectTaskToRunPeriodically(t *testing.T) {
+	var runs atomic.Int32
+	ticker := NewTicker("cleanup", 10*time.Millisecond, 0, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}, WithTaskLogger(discardLogger))
+
+	done := SafeRunAsync(ticker.Start)
+	time.Sleep(100 * time.Millisecond)
+	err := ticker.Stop()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = <-done
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.GreaterOrEqual(t, runs.Load(), int32(3))
+}
+
+func TestUnit_Ticker_WhenTaskFailsOrPanics_ExpectTickerToContinue(t *testing.T) {
+	runs := make(chan TaskRun, 10)
+	var calls atomic.Int32
+	ticker := NewTicker("cleanup", 5*time.Millisecond, 5*time.Millisecond, func(ctx context.Context) error {
+		switch calls.Add(1) {
+		case 1:
+			return errSample
+		case 2:
+			panic(errSample)
+		default:
+			return nil
+		}
+	}, WithTaskLogger(discardLogger), WithTaskObserver(func(run TaskRun) {
+		runs <- run
+	}))
+
+	done := SafeRunAsync(ticker.Start)
+	first, second, third := <-runs, <-runs, <-runs
+	ticker.Stop()
+	<-done
+
+	assert.Equal(t, "cleanup", first.Name)
+	assert.Equal(t, errSample, first.Err)
+	assert.Equal(t, errSample, second.Err)
+	assert.NoError(t, third.Err)
+}
+
+func TestUnit_Ticker_ExpectRunsNotToOverlap(t *testing.T) {
+	var running, overlaps atomic.Int32
+	ticker := NewTicker("cleanup", time.Millisecond, 0, func(ctx context.Context) error {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer running.Add(-1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}, WithTaskLogger(discardLogger))
+
+	done := SafeRunAsync(ticker.Start)
+	time.Sleep(50 * time.Millisecond)
+	ticker.Stop()
+	<-done
+
+	assert.Equal(t, int32(0), overlaps.Load())
+}
+
+func TestUnit_Ticker_WhenStopped_ExpectTaskContextCancelled(t *testing.T) {
+	started := make(chan struct{})
+	ticker := NewTicker("cleanup", time.Millisecond, 0, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithTaskLogger(discardLogger))
+
+	done := SafeRunAsync(ticker.Start)
+	<-started
+	ticker.Stop()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "Actual err: %v", err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Ticker should stop when its task is cancelled")
+	}
+}
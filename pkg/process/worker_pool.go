@@ -0,0 +1,133 @@
+package process
+
+import (
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type WorkerPoolStats struct {
+	Workers       int
+	QueueCapacity int
+	// Queued is the number of tasks waiting for a worker.
+	Queued    int
+	Active    int64
+	Completed uint64
+	Failed    uint64
+	// Rejected counts the tasks submitted while the queue was full or the
+	// pool stopped.
+	Rejected uint64
+}
+
+// WorkerPool runs the submitted tasks with a bounded number of workers, e.g.
+// to fan out work from request handlers without spawning goroutines without
+// limit. It implements Runnable: the workers run until the pool is stopped,
+// at which point the queued tasks are drained before Start returns.
+type WorkerPool struct {
+	size int
+	opts taskOptions
+
+	lock    sync.RWMutex
+	stopped bool
+	queue   chan RunFunc
+
+	active    atomic.Int64
+	completed atomic.Uint64
+	failed    atomic.Uint64
+	rejected  atomic.Uint64
+}
+
+func NewWorkerPool(size int, queueCapacity int, opts ...TaskOption) *WorkerPool {
+	p := &WorkerPool{
+		size:  max(size, 1),
+		opts:  taskOptions{log: slog.Default()},
+		queue: make(chan RunFunc, max(queueCapacity, 0)),
+	}
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+
+	return p
+}
+
+// Submit queues the task without blocking. It returns ErrWorkerPoolFull
+// when the queue is full and ErrWorkerPoolStopped when the pool is stopped.
+// A panic in the task is recovered and only fails the task.
+func (p *WorkerPool) Submit(task RunFunc) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.stopped {
+		p.rejected.Add(1)
+		return ErrWorkerPoolStopped
+	}
+
+	select {
+	case p.queue <- task:
+		return nil
+	default:
+		p.rejected.Add(1)
+		return ErrWorkerPoolFull
+	}
+}
+
+func (p *WorkerPool) Start() error {
+	var wg sync.WaitGroup
+	for range p.size {
+		wg.Go(func() {
+			for task := range p.queue {
+				p.run(task)
+			}
+		})
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// Stop rejects the new tasks. The queued ones are still processed: Start
+// returns once they are all done.
+func (p *WorkerPool) Stop() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.stopped {
+		p.stopped = true
+		close(p.queue)
+	}
+
+	return nil
+}
+
+func (p *WorkerPool) Stats() WorkerPoolStats {
+	return WorkerPoolStats{
+		Workers:       p.size,
+		QueueCapacity: cap(p.queue),
+		Queued:        len(p.queue),
+		Active:        p.active.Load(),
+		Completed:     p.completed.Load(),
+		Failed:        p.failed.Load(),
+		Rejected:      p.rejected.Load(),
+	}
+}
+
+func (p *WorkerPool) run(task RunFunc) {
+	p.active.Add(1)
+	defer p.active.Add(-1)
+
+	run := TaskRun{Started: time.Now()}
+	run.Err = SafeRunSync(task)
+	run.Duration = time.Since(run.Started)
+
+	if run.Err != nil {
+		p.failed.Add(1)
+		p.opts.log.Error("Task failed", slog.Duration("duration", run.Duration), slog.Any("error", run.Err))
+	} else {
+		p.completed.Add(1)
+	}
+
+	if p.opts.onRun != nil {
+		p.opts.onRun(run)
+	}
+}
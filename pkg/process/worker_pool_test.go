@@ -0,0 +1,123 @@
+package process
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_WorkerPool_ExpectTasksToBeRun(t *testing.T) {
+	pool := NewWorkerPool(2, 10, WithTaskLogger(discardLogger))
+	done := SafeRunAsync(pool.Start)
+
+	var runs atomic.Int32
+	for range 5 {
+		err := pool.Submit(func() error {
+			runs.Add(1)
+			return nil
+		})
+		require.NoError(t, err, "Actual err: %v", err)
+	}
+
+	pool.Stop()
+	err := <-done
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int32(5), runs.Load())
+	assert.Equal(t, uint64(5), pool.Stats().Completed)
+}
+
+func TestUnit_WorkerPool_ExpectConcurrencyToBeBounded(t *testing.T) {
+	pool := NewWorkerPool(2, 10, WithTaskLogger(discardLogger))
+	done := SafeRunAsync(pool.Start)
+
+	var running, peak atomic.Int32
+	for range 6 {
+		pool.Submit(func() error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}
+
+	pool.Stop()
+	<-done
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestUnit_WorkerPool_WhenQueueIsFull_ExpectRejection(t *testing.T) {
+	pool := NewWorkerPool(1, 1, WithTaskLogger(discardLogger))
+
+	// The pool is not started: the first task stays in the queue.
+	err := pool.Submit(func() error { return nil })
+	require.NoError(t, err, "Actual err: %v", err)
+	err = pool.Submit(func() error { return nil })
+
+	assert.Equal(t, ErrWorkerPoolFull, err, "Actual err: %v", err)
+	expected := WorkerPoolStats{
+		Workers:       1,
+		QueueCapacity: 1,
+		Queued:        1,
+		Rejected:      1,
+	}
+	assert.Equal(t, expected, pool.Stats())
+}
+
+func TestUnit_WorkerPool_WhenStopped_ExpectQueuedTasksToBeDrained(t *testing.T) {
+	pool := NewWorkerPool(1, 5, WithTaskLogger(discardLogger))
+
+	var runs atomic.Int32
+	for range 5 {
+		pool.Submit(func() error {
+			runs.Add(1)
+			return nil
+		})
+	}
+	pool.Stop()
+
+	err := pool.Submit(func() error { return nil })
+	assert.Equal(t, ErrWorkerPoolStopped, err, "Actual err: %v", err)
+
+	err = pool.Start()
+
+	assert.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int32(5), runs.Load())
+}
+
+func TestUnit_WorkerPool_WhenTaskFailsOrPanics_ExpectOtherTasksToRun(t *testing.T) {
+	runs := make(chan TaskRun, 3)
+	pool := NewWorkerPool(1, 3, WithTaskLogger(discardLogger), WithTaskObserver(func(run TaskRun) {
+		runs <- run
+	}))
+
+	pool.Submit(func() error { return errSample })
+	pool.Submit(func() error { panic(errSample) })
+	pool.Submit(func() error { return nil })
+	pool.Stop()
+	err := pool.Start()
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, errSample, (<-runs).Err)
+	assert.Equal(t, errSample, (<-runs).Err)
+	assert.NoError(t, (<-runs).Err)
+	assert.Equal(t, uint64(2), pool.Stats().Failed)
+	assert.Equal(t, uint64(1), pool.Stats().Completed)
+}
+
+func TestUnit_WorkerPool_WhenStoppedTwice_ExpectNoError(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+
+	assert.NoError(t, pool.Stop())
+	assert.NoError(t, pool.Stop())
+}
@@ -0,0 +1,65 @@
+package reqctx
+
+import (
+	"context"
+	"log/slog"
+)
+
+type requestIdKey struct{}
+type principalKey struct{}
+type tenantIdKey struct{}
+type loggerKey struct{}
+type localeKey struct{}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+func RequestId(ctx context.Context) (string, bool) {
+	requestId, ok := ctx.Value(requestIdKey{}).(string)
+	return requestId, ok
+}
+
+// WithPrincipal attaches the authenticated caller to the context. Its type
+// depends on the authentication (API key, JWT claims, ...) and should be
+// the same when retrieving it with Principal.
+func WithPrincipal[T any](ctx context.Context, principal T) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func Principal[T any](ctx context.Context) (T, bool) {
+	principal, ok := ctx.Value(principalKey{}).(T)
+	return principal, ok
+}
+
+func WithTenantId(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, tenantIdKey{}, tenantId)
+}
+
+func TenantId(ctx context.Context) (string, bool) {
+	tenantId, ok := ctx.Value(tenantIdKey{}).(string)
+	return tenantId, ok
+}
+
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// Logger returns the logger attached to the context or the default logger
+// if there is none so that it can be used unconditionally.
+func Logger(ctx context.Context) *slog.Logger {
+	log, ok := ctx.Value(loggerKey{}).(*slog.Logger)
+	if !ok || log == nil {
+		return slog.Default()
+	}
+	return log
+}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func Locale(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
@@ -0,0 +1,61 @@
+package reqctx
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type samplePrincipal struct {
+	Id string
+}
+
+func TestUnit_RequestId(t *testing.T) {
+	_, ok := RequestId(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithRequestId(context.Background(), "my-request")
+
+	actual, ok := RequestId(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "my-request", actual)
+}
+
+func TestUnit_Principal(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), samplePrincipal{Id: "user"})
+
+	actual, ok := Principal[samplePrincipal](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, samplePrincipal{Id: "user"}, actual)
+}
+
+func TestUnit_Principal_WhenTypeDiffers_ExpectNotFound(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), samplePrincipal{Id: "user"})
+
+	_, ok := Principal[string](ctx)
+	assert.False(t, ok)
+}
+
+func TestUnit_TenantId(t *testing.T) {
+	_, ok := TenantId(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithTenantId(context.Background(), "my-tenant")
+
+	actual, ok := TenantId(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "my-tenant", actual)
+}
+
+func TestUnit_Logger_WhenNoLogger_ExpectDefault(t *testing.T) {
+	assert.Same(t, slog.Default(), Logger(context.Background()))
+}
+
+func TestUnit_Logger_ReturnsAttachedLogger(t *testing.T) {
+	log := slog.New(slog.DiscardHandler)
+	ctx := WithLogger(context.Background(), log)
+
+	assert.Same(t, log, Logger(ctx))
+}
@@ -0,0 +1,25 @@
+package reqctx
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+)
+
+// Set attaches a value to the context of the request so that it is
+// available to the next handlers and to any function receiving the
+// request context:
+//
+//	reqctx.Set(c, reqctx.WithTenantId, tenantId)
+func Set[T any](c *echo.Context, with func(context.Context, T) context.Context, value T) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(with(req.Context(), value)))
+}
+
+// SetLogger replaces the logger of the echo context and attaches it to the
+// context of the request.
+func SetLogger(c *echo.Context, log *slog.Logger) {
+	c.SetLogger(log)
+	Set(c, WithLogger, log)
+}
@@ -0,0 +1,40 @@
+package reqctx
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Set_AttachesValueToRequestContext(t *testing.T) {
+	c := newTestEchoContext()
+
+	Set(c, WithTenantId, "my-tenant")
+	Set(c, WithPrincipal, samplePrincipal{Id: "user"})
+
+	tenantId, ok := TenantId(c.Request().Context())
+	assert.True(t, ok)
+	assert.Equal(t, "my-tenant", tenantId)
+	principal, ok := Principal[samplePrincipal](c.Request().Context())
+	assert.True(t, ok)
+	assert.Equal(t, samplePrincipal{Id: "user"}, principal)
+}
+
+func TestUnit_SetLogger(t *testing.T) {
+	c := newTestEchoContext()
+	log := slog.New(slog.DiscardHandler)
+
+	SetLogger(c, log)
+
+	assert.Same(t, log, c.Logger())
+	assert.Same(t, log, Logger(c.Request().Context()))
+}
+
+func newTestEchoContext() *echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
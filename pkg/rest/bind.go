@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+const DefaultMaxBodySize = 1024 * 1024
+
+type BindOption func(*bindOptions)
+
+type bindOptions struct {
+	maxSize              int64
+	disallowUnknownField bool
+}
+
+// WithMaxBodySize overrides the DefaultMaxBodySize. Larger bodies are
+// rejected with a 413.
+func WithMaxBodySize(size int64) BindOption {
+	return func(o *bindOptions) {
+		o.maxSize = size
+	}
+}
+
+// WithUnknownFieldsRejected makes the binding fail when the body contains
+// fields which do not exist in the target type.
+func WithUnknownFieldsRejected() BindOption {
+	return func(o *bindOptions) {
+		o.disallowUnknownField = true
+	}
+}
+
+// BindJson decodes the JSON body of the request to a value of type T and
+// validates it against its `validate` tags. Requests which do not have a
+// JSON content type are rejected with a 415 and bodies larger than the
+// maximum size with a 413. Malformed bodies and invalid values result in
+// a ValidationError describing the faulty fields.
+func BindJson[T any](c *echo.Context, opts ...BindOption) (T, error) {
+	options := bindOptions{maxSize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var out T
+
+	if !isJsonContentType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return out, echo.ErrUnsupportedMediaType
+	}
+
+	reader := NewBodyReader(c, StreamOptions{MaxSize: options.maxSize})
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return out, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if options.disallowUnknownField {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(&out); err != nil {
+		return out, newBindError(err)
+	}
+
+	return out, Validate(out)
+}
+
+func isJsonContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == echo.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+func newBindError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		return newValidationError(
+			typeErr.Field,
+			"type",
+			fmt.Sprintf("expected a value of type %s", typeErr.Type),
+		)
+	}
+
+	// The json package does not expose a dedicated error for unknown fields.
+	if field, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+		return newValidationError(strings.Trim(field, `"`), "unknown", "unknown field")
+	}
+
+	if stderrors.Is(err, io.EOF) {
+		return newValidationError("", "required", "the body is empty")
+	}
+
+	return newValidationError("", "json", err.Error())
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindSample struct {
+	Name  string `json:"name" validate:"required"`
+	Count int    `json:"count"`
+}
+
+func TestUnit_BindJson_WhenBodyIsValid_ExpectValue(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"foo","count":2}`)
+
+	actual, err := BindJson[bindSample](ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, bindSample{Name: "foo", Count: 2}, actual)
+}
+
+func TestUnit_BindJson_AcceptsJsonContentTypeVariants(t *testing.T) {
+	for _, contentType := range []string{"application/json; charset=utf-8", "application/merge-patch+json"} {
+		ctx := generateTestEchoContextWithJsonBody(`{"name":"foo"}`)
+		ctx.Request().Header.Set(echo.HeaderContentType, contentType)
+
+		_, err := BindJson[bindSample](ctx)
+
+		assert.NoError(t, err, "Content type: %s", contentType)
+	}
+}
+
+func TestUnit_BindJson_WhenContentTypeIsNotJson_ExpectUnsupportedMediaType(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"foo"}`)
+	ctx.Request().Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+
+	_, err := BindJson[bindSample](ctx)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, echo.StatusCode(err))
+}
+
+func TestUnit_BindJson_WhenBodyIsTooLarge_ExpectRequestEntityTooLarge(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"` + strings.Repeat("a", 32) + `"}`)
+
+	_, err := BindJson[bindSample](ctx, WithMaxBodySize(16))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, echo.StatusCode(err))
+}
+
+func TestUnit_BindJson_WhenFieldHasWrongType_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"foo","count":"two"}`)
+
+	_, err := BindJson[bindSample](ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{{Field: "count", Rule: "type", Message: "expected a value of type int"}}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_BindJson_WhenUnknownField_ExpectIgnoredByDefault(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"foo","other":1}`)
+
+	_, err := BindJson[bindSample](ctx)
+
+	assert.NoError(t, err)
+}
+
+func TestUnit_BindJson_WhenUnknownFieldsAreRejected_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"name":"foo","other":1}`)
+
+	_, err := BindJson[bindSample](ctx, WithUnknownFieldsRejected())
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{{Field: "other", Rule: "unknown", Message: "unknown field"}}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_BindJson_WhenBodyIsMalformedOrEmpty_ExpectValidationError(t *testing.T) {
+	for body, rule := range map[string]string{`{"name":`: "json", "": "required"} {
+		ctx := generateTestEchoContextWithJsonBody(body)
+
+		_, err := BindJson[bindSample](ctx)
+
+		var actual *ValidationError
+		require.ErrorAs(t, err, &actual)
+		assert.Equal(t, rule, actual.Fields[0].Rule)
+	}
+}
+
+func TestUnit_BindJson_WhenValueIsInvalid_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`{"count":2}`)
+
+	_, err := BindJson[bindSample](ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	assert.Equal(t, "name", actual.Fields[0].Field)
+}
+
+func TestUnit_BindJson_WhenValueIsAnArray_ExpectValue(t *testing.T) {
+	ctx := generateTestEchoContextWithJsonBody(`[{"a":1},{"b":2}]`)
+
+	actual, err := BindJson[[]map[string]int](ctx)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, []map[string]int{{"a": 1}, {"b": 2}}, actual)
+}
+
+func generateTestEchoContextWithJsonBody(body string) *echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	return ctx
+}
@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	MIMEApplicationXML     = "application/xml"
+	MIMEApplicationMsgpack = "application/msgpack"
+)
+
+// ResponseEncoder serializes the response envelope. It receives the
+// envelope with the typed details.
+type ResponseEncoder func(v any) ([]byte, error)
+
+var (
+	encodersLock sync.RWMutex
+	encoders     = map[string]ResponseEncoder{
+		"application/json":        json.Marshal,
+		MIMEApplicationXML:        encodeXml,
+		"text/xml":                encodeXml,
+		MIMEApplicationMsgpack:    encodeMsgpack,
+		"application/x-msgpack":   encodeMsgpack,
+		"application/vnd.msgpack": encodeMsgpack,
+	}
+)
+
+// RegisterResponseEncoder makes the media type available to clients
+// through the Accept header. This is typically called once when the
+// service starts.
+func RegisterResponseEncoder(mediaType string, encoder ResponseEncoder) {
+	encodersLock.Lock()
+	defer encodersLock.Unlock()
+
+	encoders[mediaType] = encoder
+}
+
+type acceptedType struct {
+	mediaType string
+	quality   float64
+}
+
+// negotiateEncoder picks the encoder matching the Accept header with the
+// highest quality. It returns false when the client accepts JSON or when
+// none of the accepted types is supported.
+func negotiateEncoder(accept string) (string, ResponseEncoder, bool) {
+	var accepted []acceptedType
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		if quality > 0 {
+			accepted = append(accepted, acceptedType{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	// Preserve the order of the client for types of the same quality.
+	sort.SliceStable(accepted, func(i int, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	encodersLock.RLock()
+	defer encodersLock.RUnlock()
+
+	for _, candidate := range accepted {
+		if candidate.mediaType == "application/json" || strings.HasSuffix(candidate.mediaType, "/*") {
+			return "", nil, false
+		}
+		if encoder, ok := encoders[candidate.mediaType]; ok {
+			return candidate.mediaType, encoder, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// toJsonModel converts the value to the generic representation produced
+// by the json package so that the encoders respect the json tags.
+func toJsonModel(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var out any
+	err = decoder.Decode(&out)
+	return out, err
+}
+
+func sortedKeys(in map[string]any) []string {
+	keys := make([]string, 0, len(in))
+	for key := range in {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+var xmlNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*$`)
+
+// encodeXml produces a document where objects are elements named after
+// their keys and arrays are repeated `item` elements. Keys which are not
+// valid element names are written as `entry` elements with a key
+// attribute.
+func encodeXml(v any) ([]byte, error) {
+	model, err := toJsonModel(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString(xml.Header)
+
+	encoder := xml.NewEncoder(&out)
+	if err := writeXmlElement(encoder, "response", model); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+func writeXmlElement(encoder *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !xmlNameRegex.MatchString(name) {
+		start = xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(typed) {
+			if err := writeXmlElement(encoder, key, typed[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range typed {
+			if err := writeXmlElement(encoder, "item", item); err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		if err := encoder.EncodeToken(xml.CharData(fmtScalar(typed))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+func fmtScalar(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case json.Number:
+		return typed.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestUnit_NegotiateEncoder(t *testing.T) {
+	type testCase struct {
+		accept   string
+		expected string
+	}
+
+	testCases := []testCase{
+		{accept: "", expected: ""},
+		{accept: "*/*", expected: ""},
+		{accept: "application/json", expected: ""},
+		{accept: "text/html", expected: ""},
+		{accept: "application/xml", expected: MIMEApplicationXML},
+		{accept: "text/xml; charset=utf-8", expected: "text/xml"},
+		{accept: "application/msgpack", expected: MIMEApplicationMsgpack},
+		{accept: "application/json;q=0.5, application/xml", expected: MIMEApplicationXML},
+		{accept: "application/xml;q=0.5, application/json", expected: ""},
+		{accept: "application/xml;q=0, */*", expected: ""},
+		{accept: "text/html, application/xml;q=0.9, */*;q=0.8", expected: MIMEApplicationXML},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.accept, func(t *testing.T) {
+			actual, _, _ := negotiateEncoder(testCase.accept)
+
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestUnit_RegisterResponseEncoder_MakesMediaTypeAvailable(t *testing.T) {
+	RegisterResponseEncoder("application/vnd.test", json.Marshal)
+	t.Cleanup(func() {
+		encodersLock.Lock()
+		defer encodersLock.Unlock()
+		delete(encoders, "application/vnd.test")
+	})
+
+	actual, _, ok := negotiateEncoder("application/vnd.test")
+
+	assert.True(t, ok)
+	assert.Equal(t, "application/vnd.test", actual)
+}
+
+func TestUnit_EncodeXml(t *testing.T) {
+	in := map[string]any{
+		"name":    "a < b",
+		"values":  []int{1, 2},
+		"enabled": true,
+		"ratio":   1.5,
+		"missing": nil,
+		"1st":     "invalid name",
+	}
+
+	actual, err := encodeXml(in)
+
+	require.NoError(t, err)
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response>` +
+		`<entry key="1st">invalid name</entry>` +
+		`<enabled>true</enabled>` +
+		`<missing></missing>` +
+		`<name>a &lt; b</name>` +
+		`<ratio>1.5</ratio>` +
+		`<values><item>1</item><item>2</item></values>` +
+		`</response>`
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestUnit_EncodeMsgpack(t *testing.T) {
+	type testCase struct {
+		name     string
+		in       any
+		expected any
+	}
+
+	testCases := []testCase{
+		{name: "nil", in: nil, expected: nil},
+		{name: "bool", in: true, expected: true},
+		{name: "int", in: 256, expected: uint64(256)},
+		{name: "negativeInt", in: -1, expected: int64(-1)},
+		{name: "float", in: 1.5, expected: 1.5},
+		{name: "string", in: "ab", expected: "ab"},
+		{name: "array", in: []string{"a"}, expected: []any{"a"}},
+		{
+			name:     "map",
+			in:       map[string]any{"b": "2", "a": true},
+			expected: map[string]any{"a": true, "b": "2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := encodeMsgpack(testCase.in)
+			require.NoError(t, err)
+
+			decoder := msgpack.NewDecoder(bytes.NewReader(data))
+			actual, err := decoder.DecodeInterfaceLoose()
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestUnit_EncodeMsgpack_ExpectJsonTagsToBeUsed(t *testing.T) {
+	in := struct {
+		Name    string `json:"name"`
+		Ignored string `json:"-"`
+	}{Name: "my-name", Ignored: "ignored"}
+
+	data, err := encodeMsgpack(in)
+	require.NoError(t, err)
+
+	var actual map[string]any
+	err = msgpack.Unmarshal(data, &actual)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "my-name"}, actual)
+}
+
+func TestUnit_EncodeMsgpack_ExpectKeysToBeSorted(t *testing.T) {
+	actual, err := encodeMsgpack(map[string]int{"b": 2, "a": 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}, actual)
+}
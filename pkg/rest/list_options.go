@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ListOptions describes how a list of items should be returned. The
+// fields used for sorting and filtering are guaranteed to be part of the
+// allowed fields so they can safely be used to build a query.
+type ListOptions struct {
+	Limit   int
+	Cursor  string
+	Sort    []SortField
+	Filters map[string]string
+}
+
+// ParseListOptions reads the `limit` and `cursor` query parameters (see
+// ParsePaginationParams), the `sort` parameter as a comma separated list
+// of fields each optionally prefixed with '-' for a descending order and
+// considers all the other query parameters as filters. Sorting on or
+// filtering by a field which is not allowed results in a ValidationError.
+func ParseListOptions(c *echo.Context, allowedFields []string) (ListOptions, error) {
+	out := ListOptions{
+		Cursor:  c.QueryParam("cursor"),
+		Filters: make(map[string]string),
+	}
+
+	var fields []FieldError
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		fields = append(fields, *err)
+	}
+	out.Limit = limit
+
+	if err := validateCursor(out.Cursor); err != nil {
+		fields = append(fields, *err)
+	}
+
+	allowed := fmt.Sprintf("failed on the 'oneof=%s' rule", strings.Join(allowedFields, " "))
+
+	if maybeSort := c.QueryParam("sort"); maybeSort != "" {
+		for sort := range strings.SplitSeq(maybeSort, ",") {
+			field, descending := strings.CutPrefix(sort, "-")
+			if !slices.Contains(allowedFields, field) {
+				fields = append(fields, FieldError{Field: "sort", Rule: "oneof", Message: allowed})
+				continue
+			}
+
+			out.Sort = append(out.Sort, SortField{Field: field, Descending: descending})
+		}
+	}
+
+	for key, values := range c.QueryParams() {
+		if key == "limit" || key == "cursor" || key == "sort" {
+			continue
+		}
+		if !slices.Contains(allowedFields, key) {
+			fields = append(fields, FieldError{Field: key, Rule: "oneof", Message: allowed})
+			continue
+		}
+
+		out.Filters[key] = values[0]
+	}
+
+	if len(fields) > 0 {
+		// Filters are iterated in a random order.
+		slices.SortStableFunc(fields, func(lhs FieldError, rhs FieldError) int {
+			return strings.Compare(lhs.Field, rhs.Field)
+		})
+		return ListOptions{}, &ValidationError{Fields: fields}
+	}
+
+	return out, nil
+}
+
+// OrderBy returns the sort fields as an ORDER BY clause (without the
+// keyword), e.g. `created_at DESC, name ASC`. It is empty when no sort
+// was requested.
+func (o ListOptions) OrderBy() string {
+	clauses := make([]string, 0, len(o.Sort))
+	for _, sort := range o.Sort {
+		direction := "ASC"
+		if sort.Descending {
+			direction = "DESC"
+		}
+		clauses = append(clauses, fmt.Sprintf("%s %s", sort.Field, direction))
+	}
+
+	return strings.Join(clauses, ", ")
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allowedListFields = []string{"name", "created_at"}
+
+func TestUnit_ParseListOptions_WhenNoParams_ExpectDefaults(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual, err := ParseListOptions(ctx, allowedListFields)
+
+	require.NoError(t, err)
+	expected := ListOptions{Limit: DefaultPageLimit, Filters: map[string]string{}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_ParseListOptions_ParsesSortFiltersAndLimit(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?sort=-created_at,name&name=foo&limit=50&cursor=abc", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual, err := ParseListOptions(ctx, allowedListFields)
+
+	require.NoError(t, err)
+	expected := ListOptions{
+		Limit:  50,
+		Cursor: "abc",
+		Sort: []SortField{
+			{Field: "created_at", Descending: true},
+			{Field: "name"},
+		},
+		Filters: map[string]string{"name": "foo"},
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "created_at DESC, name ASC", actual.OrderBy())
+}
+
+func TestUnit_ParseListOptions_WhenFieldsAreNotAllowed_ExpectValidationError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?sort=password&email=foo&limit=-1", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	_, err := ParseListOptions(ctx, allowedListFields)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	message := "failed on the 'oneof=name created_at' rule"
+	expected := []FieldError{
+		{Field: "email", Rule: "oneof", Message: message},
+		{Field: "limit", Rule: "gte", Message: "failed on the 'gte=1' rule"},
+		{Field: "sort", Rule: "oneof", Message: message},
+	}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_ListOptions_OrderBy_WhenNoSort_ExpectEmpty(t *testing.T) {
+	assert.Equal(t, "", ListOptions{}.OrderBy())
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// encodeMsgpack serializes the value with the MessagePack format. The value
+// goes through the json model first so that the json tags and marshalers
+// apply as with the other formats.
+func encodeMsgpack(v any) ([]byte, error) {
+	model, err := toJsonModel(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	encoder := msgpack.NewEncoder(&out)
+	encoder.SetSortMapKeys(true)
+	encoder.UseCompactInts(true)
+	encoder.UseCompactFloats(true)
+
+	if err := encoder.Encode(fromJsonNumbers(model)); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// fromJsonNumbers replaces the numbers of the json model with integers when
+// possible and floats otherwise.
+func fromJsonNumbers(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i
+		}
+		f, _ := typed.Float64()
+		return f
+	case []any:
+		for i, item := range typed {
+			typed[i] = fromJsonNumbers(item)
+		}
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = fromJsonNumbers(item)
+		}
+	}
+
+	return value
+}
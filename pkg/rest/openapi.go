@@ -0,0 +1,239 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const openApiVersion = "3.0.3"
+
+// defaultSecuritySchemeName is the scheme declared for the routes requiring
+// authentication when the document does not declare any. It matches the
+// bearer tokens expected by the JWT middleware.
+const defaultSecuritySchemeName = "bearerAuth"
+
+var pathParamRegex = regexp.MustCompile(`:([^/]+)`)
+
+type OpenApiInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenApiDocument struct {
+	OpenApi    string                     `json:"openapi"`
+	Info       OpenApiInfo                `json:"info"`
+	Paths      map[string]OpenApiPathItem `json:"paths"`
+	Components *OpenApiComponents         `json:"components,omitempty"`
+}
+
+type OpenApiComponents struct {
+	SecuritySchemes map[string]OpenApiSecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type OpenApiSecurityScheme struct {
+	// Type is one of `http` or `apiKey`.
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	// In and Name locate the key for the `apiKey` schemes, e.g. `header`
+	// and `X-Api-Key`.
+	In   string `json:"in,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// OpenApiPathItem maps the lower case HTTP methods to the operations.
+type OpenApiPathItem map[string]OpenApiOperation
+
+type OpenApiOperation struct {
+	OperationId string                     `json:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []OpenApiParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenApiRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenApiResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	// Security lists the alternative schemes accepted by the operation.
+	Security []map[string][]string `json:"security,omitempty"`
+}
+
+type OpenApiParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required"`
+	Schema      *JsonSchema `json:"schema"`
+}
+
+type OpenApiRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenApiMediaType `json:"content"`
+}
+
+type OpenApiResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenApiMediaType `json:"content,omitempty"`
+}
+
+type OpenApiMediaType struct {
+	Schema *JsonSchema `json:"schema"`
+}
+
+func NewOpenApiDocument(info OpenApiInfo) *OpenApiDocument {
+	return &OpenApiDocument{
+		OpenApi: openApiVersion,
+		Info:    info,
+		Paths:   make(map[string]OpenApiPathItem),
+	}
+}
+
+// AddRoute documents the route under the provided path, which should be
+// the full path of the route including the base path of the server. The
+// routes without documentation are still listed with a minimal operation.
+func (d *OpenApiDocument) AddRoute(path string, route Route) {
+	openApiPath := pathParamRegex.ReplaceAllString(path, "{$1}")
+
+	item, ok := d.Paths[openApiPath]
+	if !ok {
+		item = make(OpenApiPathItem)
+		d.Paths[openApiPath] = item
+	}
+
+	op := newOpenApiOperation(path, route)
+	if route.Metadata().RequiresAuth {
+		op.Security = d.securityRequirements()
+	}
+
+	item[strings.ToLower(route.Method())] = op
+}
+
+// AddSecurityScheme declares a scheme accepted by the routes requiring
+// authentication. The schemes should be declared before adding the routes.
+func (d *OpenApiDocument) AddSecurityScheme(name string, scheme OpenApiSecurityScheme) {
+	if d.Components == nil {
+		d.Components = &OpenApiComponents{}
+	}
+	if d.Components.SecuritySchemes == nil {
+		d.Components.SecuritySchemes = make(map[string]OpenApiSecurityScheme)
+	}
+
+	d.Components.SecuritySchemes[name] = scheme
+}
+
+func (d *OpenApiDocument) securityRequirements() []map[string][]string {
+	if d.Components == nil || len(d.Components.SecuritySchemes) == 0 {
+		d.AddSecurityScheme(defaultSecuritySchemeName, OpenApiSecurityScheme{
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+		})
+	}
+
+	var names []string
+	for name := range d.Components.SecuritySchemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var out []map[string][]string
+	for _, name := range names {
+		out = append(out, map[string][]string{name: {}})
+	}
+
+	return out
+}
+
+func newOpenApiOperation(path string, route Route) OpenApiOperation {
+	doc := route.Doc()
+	if doc == nil {
+		doc = &RouteDoc{}
+	}
+
+	metadata := route.Metadata()
+
+	op := OpenApiOperation{
+		OperationId: metadata.Name,
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		Tags:        doc.Tags,
+		Parameters:  openApiParameters(path, doc.Params),
+		Responses:   make(map[string]OpenApiResponse),
+		Deprecated:  metadata.Deprecated,
+	}
+
+	// The documentation takes precedence over the metadata as it is more
+	// specific.
+	if op.Description == "" {
+		op.Description = metadata.Description
+	}
+	if len(op.Tags) == 0 {
+		op.Tags = metadata.Tags
+	}
+
+	if doc.Request != nil {
+		op.RequestBody = &OpenApiRequestBody{
+			Required: true,
+			Content:  jsonContent(SchemaOf(doc.Request)),
+		}
+	}
+
+	response := OpenApiResponse{Description: http.StatusText(http.StatusOK)}
+	switch {
+	case route.UseResponseEnvelope():
+		response.Content = jsonContent(envelopeSchema(SchemaOf(doc.Response)))
+	case doc.Response != nil:
+		response.Content = jsonContent(SchemaOf(doc.Response))
+	}
+	op.Responses[fmt.Sprintf("%d", http.StatusOK)] = response
+
+	return op
+}
+
+func openApiParameters(path string, params []RouteParam) []OpenApiParameter {
+	var out []OpenApiParameter
+	declared := make(map[string]bool)
+
+	for _, param := range params {
+		declared[param.Name] = true
+		out = append(out, OpenApiParameter{
+			Name:        param.Name,
+			In:          string(param.In),
+			Description: param.Description,
+			// Path parameters are always required.
+			Required: param.Required || param.In == ParamInPath,
+			Schema:   &JsonSchema{Type: "string"},
+		})
+	}
+
+	for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		if declared[name] {
+			continue
+		}
+
+		out = append(out, OpenApiParameter{
+			Name:     name,
+			In:       string(ParamInPath),
+			Required: true,
+			Schema:   &JsonSchema{Type: "string"},
+		})
+	}
+
+	return out
+}
+
+func envelopeSchema(details *JsonSchema) *JsonSchema {
+	schema := SchemaOf(ResponseEnvelope[any]{})
+	schema.Properties["details"] = details
+	return schema
+}
+
+func jsonContent(schema *JsonSchema) map[string]OpenApiMediaType {
+	return map[string]OpenApiMediaType{
+		"application/json": {Schema: schema},
+	}
+}
@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openApiSample struct {
+	Name string `json:"name"`
+}
+
+func TestUnit_OpenApiDocument_WhenRouteIsNotDocumented_ExpectMinimalOperation(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+
+	doc.AddRoute("/raw", NewRawRoute(http.MethodGet, "/raw", testHandler))
+
+	out, err := json.Marshal(doc)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	expectedJson := `
+	{
+		"openapi": "3.0.3",
+		"info": {"title": "my-service", "version": "v1"},
+		"paths": {
+			"/raw": {
+				"get": {
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+	assert.JSONEq(t, expectedJson, string(out))
+}
+
+func TestUnit_OpenApiDocument_DocumentsRoute(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+	route := WithDoc(
+		NewRawRoute(http.MethodPost, "/samples/:id", testHandler),
+		RouteDoc{
+			Summary:  "Update a sample",
+			Tags:     []string{"samples"},
+			Request:  openApiSample{},
+			Response: openApiSample{},
+			Params: []RouteParam{
+				{Name: "dryRun", In: ParamInQuery, Description: "Validate only"},
+			},
+		},
+	)
+
+	doc.AddRoute("/v1/samples/:id", route)
+
+	require.Contains(t, doc.Paths, "/v1/samples/{id}")
+	op := doc.Paths["/v1/samples/{id}"]["post"]
+	assert.Equal(t, "Update a sample", op.Summary)
+	assert.Equal(t, []string{"samples"}, op.Tags)
+
+	expectedParams := []OpenApiParameter{
+		{Name: "dryRun", In: "query", Description: "Validate only", Schema: &JsonSchema{Type: "string"}},
+		{Name: "id", In: "path", Required: true, Schema: &JsonSchema{Type: "string"}},
+	}
+	assert.Equal(t, expectedParams, op.Parameters)
+
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, SchemaOf(openApiSample{}), op.RequestBody.Content["application/json"].Schema)
+	assert.Equal(t, SchemaOf(openApiSample{}), op.Responses["200"].Content["application/json"].Schema)
+}
+
+func TestUnit_OpenApiDocument_WhenRouteUsesEnvelope_ExpectResponseToBeWrapped(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{})
+	route := WithDoc(NewRoute(http.MethodGet, "/sample", testHandler), RouteDoc{Response: openApiSample{}})
+
+	doc.AddRoute("/sample", route)
+
+	schema := doc.Paths["/sample"]["get"].Responses["200"].Content["application/json"].Schema
+	require.NotNil(t, schema)
+	assert.Equal(t, []string{"requestId", "status", "details"}, schema.Required)
+	assert.Equal(t, &JsonSchema{Type: "string", Format: "uuid", Example: "669cd40f-ea15-40a8-ab03-81e704a3ecf9"}, schema.Properties["requestId"])
+	assert.Equal(t, SchemaOf(openApiSample{}), schema.Properties["details"])
+}
+
+func TestUnit_OpenApiDocument_WhenRouteDefinesMetadata_ExpectOperationToUseIt(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+	route := WithMetadata(
+		NewRawRoute(http.MethodGet, "/samples", testHandler),
+		RouteMetadata{
+			Name:        "list-samples",
+			Description: "List the samples",
+			Tags:        []string{"samples"},
+			Deprecated:  true,
+		},
+	)
+
+	doc.AddRoute("/samples", route)
+
+	op := doc.Paths["/samples"]["get"]
+	assert.Equal(t, "list-samples", op.OperationId)
+	assert.Equal(t, "List the samples", op.Description)
+	assert.Equal(t, []string{"samples"}, op.Tags)
+	assert.True(t, op.Deprecated)
+}
+
+func TestUnit_OpenApiDocument_WhenRouteRequiresAuth_ExpectBearerSecurityByDefault(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+	route := WithMetadata(NewRawRoute(http.MethodGet, "/samples", testHandler), RouteMetadata{RequiresAuth: true})
+
+	doc.AddRoute("/samples", route)
+	doc.AddRoute("/public", NewRawRoute(http.MethodGet, "/public", testHandler))
+
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, doc.Paths["/samples"]["get"].Security)
+	assert.Nil(t, doc.Paths["/public"]["get"].Security)
+	require.NotNil(t, doc.Components)
+	expected := OpenApiSecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	assert.Equal(t, expected, doc.Components.SecuritySchemes["bearerAuth"])
+}
+
+func TestUnit_OpenApiDocument_WhenSecuritySchemesAreDeclared_ExpectRoutesRequiringAuthToAcceptThem(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+	doc.AddSecurityScheme("jwt", OpenApiSecurityScheme{Type: "http", Scheme: "bearer"})
+	doc.AddSecurityScheme("apiKey", OpenApiSecurityScheme{Type: "apiKey", In: "header", Name: "X-Api-Key"})
+	route := WithMetadata(NewRawRoute(http.MethodGet, "/samples", testHandler), RouteMetadata{RequiresAuth: true})
+
+	doc.AddRoute("/samples", route)
+
+	expected := []map[string][]string{{"apiKey": {}}, {"jwt": {}}}
+	assert.Equal(t, expected, doc.Paths["/samples"]["get"].Security)
+	assert.Len(t, doc.Components.SecuritySchemes, 2)
+}
+
+func TestUnit_OpenApiDocument_WhenNoRouteRequiresAuth_ExpectNoComponents(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+
+	doc.AddRoute("/samples", NewRawRoute(http.MethodGet, "/samples", testHandler))
+
+	out, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "components")
+}
+
+func TestUnit_OpenApiDocument_WhenRouteDefinesDocAndMetadata_ExpectDocToTakePrecedence(t *testing.T) {
+	doc := NewOpenApiDocument(OpenApiInfo{Title: "my-service", Version: "v1"})
+	route := WithMetadata(
+		WithDoc(NewRawRoute(http.MethodGet, "/samples", testHandler), RouteDoc{Description: "doc", Tags: []string{"doc"}}),
+		RouteMetadata{Description: "metadata", Tags: []string{"metadata"}},
+	)
+
+	doc.AddRoute("/samples", route)
+
+	op := doc.Paths["/samples"]["get"]
+	assert.Equal(t, "doc", op.Description)
+	assert.Equal(t, []string{"doc"}, op.Tags)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	maxCursorLength = 512
+)
+
+// A sort is a field name optionally prefixed with '-' for a descending
+// order, e.g. `createdAt` or `-createdAt`.
+var sortRegex = regexp.MustCompile(`^-?[a-zA-Z_][a-zA-Z0-9_.]*$`)
+
+type PaginationParams struct {
+	Limit int
+	// Cursor is the opaque value returned by the previous page. It is
+	// empty when requesting the first page.
+	Cursor string
+	Sort   string
+}
+
+type Pagination struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+// PaginatedResponse is the shape of the details of the response envelope
+// for routes returning a page of items.
+type PaginatedResponse[T any] struct {
+	Items      []T        `json:"items" binding:"required"`
+	Pagination Pagination `json:"pagination" binding:"required"`
+}
+
+// NewPaginatedResponse wraps a page of items. An empty next cursor means
+// this is the last page. A nil slice is returned as an empty list.
+func NewPaginatedResponse[T any](items []T, nextCursor string, total int) PaginatedResponse[T] {
+	if items == nil {
+		items = make([]T, 0)
+	}
+
+	return PaginatedResponse[T]{
+		Items: items,
+		Pagination: Pagination{
+			NextCursor: nextCursor,
+			Total:      total,
+		},
+	}
+}
+
+// ParsePaginationParams reads the `limit`, `cursor` and `sort` query
+// parameters. The limit defaults to DefaultPageLimit and is capped to
+// MaxPageLimit. Invalid values result in a ValidationError.
+func ParsePaginationParams(c *echo.Context) (PaginationParams, error) {
+	out := PaginationParams{
+		Cursor: c.QueryParam("cursor"),
+		Sort:   c.QueryParam("sort"),
+	}
+
+	var fields []FieldError
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		fields = append(fields, *err)
+	}
+	out.Limit = limit
+
+	if err := validateCursor(out.Cursor); err != nil {
+		fields = append(fields, *err)
+	}
+
+	if out.Sort != "" && !sortRegex.MatchString(out.Sort) {
+		fields = append(fields, FieldError{
+			Field:   "sort",
+			Rule:    "sort",
+			Message: "failed on the 'sort' rule",
+		})
+	}
+
+	if len(fields) > 0 {
+		return PaginationParams{}, &ValidationError{Fields: fields}
+	}
+
+	return out, nil
+}
+
+func parseLimit(c *echo.Context) (int, *FieldError) {
+	maybeLimit := c.QueryParam("limit")
+	if maybeLimit == "" {
+		return DefaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(maybeLimit)
+	if err != nil || limit < 1 {
+		return 0, &FieldError{
+			Field:   "limit",
+			Rule:    "gte",
+			Message: "failed on the 'gte=1' rule",
+		}
+	}
+
+	return min(limit, MaxPageLimit), nil
+}
+
+func validateCursor(cursor string) *FieldError {
+	if len(cursor) <= maxCursorLength {
+		return nil
+	}
+
+	return &FieldError{
+		Field:   "cursor",
+		Rule:    "max",
+		Message: fmt.Sprintf("failed on the 'max=%d' rule", maxCursorLength),
+	}
+}
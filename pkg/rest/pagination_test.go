@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_NewPaginatedResponse_MarshalsItemsAndPagination(t *testing.T) {
+	in := NewPaginatedResponse([]int{1, 2}, "abc", 10)
+
+	out, err := json.Marshal(in)
+
+	require.NoError(t, err)
+	expected := `{"items":[1,2],"pagination":{"nextCursor":"abc","total":10}}`
+	assert.JSONEq(t, expected, string(out))
+}
+
+func TestUnit_NewPaginatedResponse_WhenLastPageWithNoItems_ExpectEmptyListAndNoCursor(t *testing.T) {
+	in := NewPaginatedResponse[int](nil, "", 0)
+
+	out, err := json.Marshal(in)
+
+	require.NoError(t, err)
+	expected := `{"items":[],"pagination":{"total":0}}`
+	assert.JSONEq(t, expected, string(out))
+}
+
+func TestUnit_ParsePaginationParams_WhenNoParams_ExpectDefaults(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual, err := ParsePaginationParams(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, PaginationParams{Limit: DefaultPageLimit}, actual)
+}
+
+func TestUnit_ParsePaginationParams_WhenParamsAreSet_ExpectThemToBeReturned(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?limit=5&cursor=abc&sort=-createdAt", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual, err := ParsePaginationParams(ctx)
+
+	require.NoError(t, err)
+	expected := PaginationParams{Limit: 5, Cursor: "abc", Sort: "-createdAt"}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_ParsePaginationParams_WhenLimitIsTooLarge_ExpectCapped(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?limit=1000", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual, err := ParsePaginationParams(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageLimit, actual.Limit)
+}
+
+func TestUnit_ParsePaginationParams_WhenParamsAreInvalid_ExpectValidationError(t *testing.T) {
+	longCursor := strings.Repeat("a", maxCursorLength+1)
+	req := httptest.NewRequest(http.MethodGet, "/?limit=0&sort=name%20desc&cursor="+longCursor, nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	_, err := ParsePaginationParams(ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{
+		{Field: "limit", Rule: "gte", Message: "failed on the 'gte=1' rule"},
+		{Field: "cursor", Rule: "max", Message: "failed on the 'max=512' rule"},
+		{Field: "sort", Rule: "sort", Message: "failed on the 'sort' rule"},
+	}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_ParsePaginationParams_WhenLimitIsNotANumber_ExpectValidationError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?limit=abc", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	_, err := ParsePaginationParams(ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	assert.Equal(t, "limit", actual.Fields[0].Field)
+}
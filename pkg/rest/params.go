@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+)
+
+// PathParam parses the path parameter to a value of type T. Supported
+// types are the ones implementing encoding.TextUnmarshaler (such as
+// time.Time, uuid.UUID or enums validating their values) and the types
+// based on strings, integers, floats and booleans. A missing or invalid
+// parameter results in a ValidationError.
+func PathParam[T any](key string, c *echo.Context) (T, error) {
+	var out T
+
+	maybeValue := c.Param(key)
+	if maybeValue == "" {
+		return out, newValidationError(key, "required", "failed on the 'required' rule")
+	}
+
+	err := parseParam(key, maybeValue, &out)
+	return out, err
+}
+
+// QueryParam parses the query parameter to a value of type T, see
+// PathParam for the supported types. A missing parameter is not an error
+// and is reported with exists set to false.
+func QueryParam[T any](key string, c *echo.Context) (exists bool, value T, err error) {
+	maybeValue := c.QueryParam(key)
+	exists = (maybeValue != "")
+	if !exists {
+		return exists, value, nil
+	}
+
+	err = parseParam(key, maybeValue, &value)
+	return exists, value, err
+}
+
+func parseParam(key string, in string, out any) error {
+	if unmarshaler, ok := out.(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(in)); err != nil {
+			return newValidationError(key, "format", err.Error())
+		}
+		return nil
+	}
+
+	value := reflect.ValueOf(out).Elem()
+
+	var err error
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(in)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var parsed int64
+		parsed, err = strconv.ParseInt(in, 10, value.Type().Bits())
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var parsed uint64
+		parsed, err = strconv.ParseUint(in, 10, value.Type().Bits())
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		var parsed float64
+		parsed, err = strconv.ParseFloat(in, value.Type().Bits())
+		value.SetFloat(parsed)
+	case reflect.Bool:
+		var parsed bool
+		parsed, err = strconv.ParseBool(in)
+		value.SetBool(parsed)
+	default:
+		return fmt.Errorf("unsupported parameter type %s", value.Type())
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("expected a value of type %s", value.Kind())
+		return newValidationError(key, value.Kind().String(), message)
+	}
+
+	return nil
+}
+
+func newValidationError(key string, rule string, message string) error {
+	return &ValidationError{
+		Fields: []FieldError{{Field: key, Rule: rule, Message: message}},
+	}
+}
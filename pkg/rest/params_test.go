@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paramColor string
+
+const (
+	paramRed  paramColor = "red"
+	paramBlue paramColor = "blue"
+)
+
+func (c *paramColor) UnmarshalText(text []byte) error {
+	switch color := paramColor(text); color {
+	case paramRed, paramBlue:
+		*c = color
+		return nil
+	default:
+		return fmt.Errorf("unknown color %q", text)
+	}
+}
+
+type paramCount int
+
+func TestUnit_PathParam_ParsesSupportedTypes(t *testing.T) {
+	ctx := generateTestEchoContextWithPathParam("value", "12")
+	actualInt, err := PathParam[int]("value", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 12, actualInt)
+
+	actualCount, err := PathParam[paramCount]("value", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, paramCount(12), actualCount)
+
+	actualFloat, err := PathParam[float64]("value", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, actualFloat)
+
+	ctx = generateTestEchoContextWithPathParam("value", "true")
+	actualBool, err := PathParam[bool]("value", ctx)
+	require.NoError(t, err)
+	assert.True(t, actualBool)
+
+	ctx = generateTestEchoContextWithPathParam("value", "2024-05-06T07:08:09Z")
+	actualTime, err := PathParam[time.Time]("value", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), actualTime)
+
+	ctx = generateTestEchoContextWithPathParam("value", "blue")
+	actualColor, err := PathParam[paramColor]("value", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, paramBlue, actualColor)
+}
+
+func TestUnit_PathParam_WhenMissing_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithPathParam("other", "12")
+
+	_, err := PathParam[int]("value", ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{{Field: "value", Rule: "required", Message: "failed on the 'required' rule"}}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_PathParam_WhenInvalidNumber_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithPathParam("value", "abc")
+
+	_, err := PathParam[int]("value", ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{{Field: "value", Rule: "int", Message: "expected a value of type int"}}
+	assert.Equal(t, expected, actual.Fields)
+	assert.Equal(t, http.StatusBadRequest, actual.StatusCode())
+}
+
+func TestUnit_PathParam_WhenInvalidEnum_ExpectValidationError(t *testing.T) {
+	ctx := generateTestEchoContextWithPathParam("value", "green")
+
+	_, err := PathParam[paramColor]("value", ctx)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{{Field: "value", Rule: "format", Message: `unknown color "green"`}}
+	assert.Equal(t, expected, actual.Fields)
+}
+
+func TestUnit_PathParam_WhenTypeIsNotSupported_ExpectError(t *testing.T) {
+	ctx := generateTestEchoContextWithPathParam("value", "12")
+
+	_, err := PathParam[[]int]("value", ctx)
+
+	assert.Equal(t, "unsupported parameter type []int", err.Error())
+}
+
+func TestUnit_QueryParam_WhenMissing_ExpectNotExistAndNoError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	exists, _, err := QueryParam[int](defaultKey, ctx)
+
+	assert.False(t, exists)
+	assert.Nil(t, err)
+}
+
+func TestUnit_QueryParam_WhenSet_ExpectExistAndValue(t *testing.T) {
+	req := generateRequestWithQueryParams(defaultKey, "false")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	exists, actual, err := QueryParam[bool](defaultKey, ctx)
+
+	assert.True(t, exists)
+	assert.False(t, actual)
+	assert.Nil(t, err)
+}
+
+func TestUnit_QueryParam_WhenInvalid_ExpectExistAndValidationError(t *testing.T) {
+	req := generateRequestWithQueryParams(defaultKey, "maybe")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	exists, _, err := QueryParam[bool](defaultKey, ctx)
+
+	assert.True(t, exists)
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	assert.Equal(t, "bool", actual.Fields[0].Rule)
+}
+
+func generateTestEchoContextWithPathParam(key string, value string) *echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	ctx.SetPathValues(echo.PathValues{{Name: key, Value: value}})
+
+	return ctx
+}
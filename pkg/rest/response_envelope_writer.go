@@ -12,6 +12,13 @@ type envelopeResponseWriter[T any] struct {
 	response ResponseEnvelope[T]
 	writer   http.ResponseWriter
 	decoder  ResponseEnvelopeDecoder[T]
+	degraded bool
+	// noBody is set when the status code does not allow a body.
+	noBody bool
+
+	// mediaType is empty when the response uses the default JSON encoding.
+	mediaType string
+	encoder   ResponseEncoder
 }
 
 func NewResponseEnvelopeWriter[T any](w http.ResponseWriter, requestId string, decoder ResponseEnvelopeDecoder[T]) *envelopeResponseWriter[T] {
@@ -22,6 +29,19 @@ func NewResponseEnvelopeWriter[T any](w http.ResponseWriter, requestId string, d
 		},
 		writer:  w,
 		decoder: decoder,
+		encoder: json.Marshal,
+	}
+}
+
+// NegotiateEncoding selects the encoding of the response from the Accept
+// header of the request. JSON is used when the client does not accept any
+// of the registered encodings.
+func (erw *envelopeResponseWriter[T]) NegotiateEncoding(accept string) {
+	erw.writer.Header().Add("Vary", "Accept")
+
+	if mediaType, encoder, ok := negotiateEncoder(accept); ok {
+		erw.mediaType = mediaType
+		erw.encoder = encoder
 	}
 }
 
@@ -30,6 +50,10 @@ func (erw *envelopeResponseWriter[T]) Header() http.Header {
 }
 
 func (erw *envelopeResponseWriter[T]) Write(data []byte) (int, error) {
+	if erw.noBody {
+		return len(data), nil
+	}
+
 	details, err := erw.decoder(data)
 	if err != nil {
 		return 0, err
@@ -39,8 +63,12 @@ func (erw *envelopeResponseWriter[T]) Write(data []byte) (int, error) {
 }
 
 func (erw *envelopeResponseWriter[T]) WriteTyped(data T) (int, error) {
+	if erw.noBody {
+		return 0, nil
+	}
+
 	erw.response.Details = data
-	out, err := json.Marshal(erw.response)
+	out, err := erw.encoder(erw.response)
 	if err != nil {
 		return 0, err
 	}
@@ -51,11 +79,31 @@ func (erw *envelopeResponseWriter[T]) WriteTyped(data T) (int, error) {
 	return erw.writer.Write(out)
 }
 
+// MarkDegraded indicates that the response is produced by a fallback:
+// successful responses then use the degraded status.
+func (erw *envelopeResponseWriter[T]) MarkDegraded() {
+	erw.degraded = true
+	if erw.response.Status == StatusSuccess {
+		erw.response.Status = StatusDegraded
+	}
+}
+
 func (erw *envelopeResponseWriter[T]) WriteHeader(statusCode int) {
 	if statusCode < 200 || statusCode > 299 {
 		erw.response.Status = StatusError
+	} else if erw.degraded {
+		erw.response.Status = StatusDegraded
 	} else {
 		erw.response.Status = StatusSuccess
 	}
+
+	erw.noBody = (statusCode == http.StatusNoContent || statusCode == http.StatusNotModified)
+
+	// Handlers set the content type of the body they produce which is not
+	// the one sent to the client when another encoding was negotiated.
+	if erw.mediaType != "" {
+		erw.writer.Header().Set("Content-Type", erw.mediaType)
+	}
+
 	erw.writer.WriteHeader(statusCode)
 }
@@ -226,3 +226,84 @@ func TestUnit_EnvelopeResponseWriter_DecodesStringWhenWritingBytes(t *testing.T)
 	actual := out.Body.String()
 	assert.JSONEq(t, expectedJson, actual)
 }
+
+func TestUnit_EnvelopeResponseWriter_WhenMarkedDegraded_ExpectDegradedStatus(t *testing.T) {
+	out := httptest.NewRecorder()
+
+	rw := NewResponseEnvelopeWriter(out, sampleRequestId, DecodeJSONTo[details])
+	rw.MarkDegraded()
+
+	rw.WriteHeader(http.StatusOK)
+	_, err := rw.WriteTyped(sampleJsonData)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	expectedJson := `
+	{
+		"requestId": "b8e9de68-3d49-4d40-a9a6-f8f3d3eab8f1",
+		"status": "DEGRADED",
+		"details": {
+			"value": 12
+		}
+	}`
+	assert.JSONEq(t, expectedJson, out.Body.String())
+}
+
+func TestUnit_EnvelopeResponseWriter_WhenMarkedDegradedAndError_ExpectErrorStatus(t *testing.T) {
+	out := httptest.NewRecorder()
+
+	rw := NewResponseEnvelopeWriter(out, sampleRequestId, DecodeJSONTo[details])
+	rw.MarkDegraded()
+
+	rw.WriteHeader(http.StatusInternalServerError)
+	_, err := rw.WriteTyped(sampleJsonData)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	var actual ResponseEnvelope[details]
+	err = json.Unmarshal(out.Body.Bytes(), &actual)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, StatusError, actual.Status)
+}
+
+func TestUnit_EnvelopeResponseWriter_WhenXmlIsNegotiated_ExpectXmlBodyAndContentType(t *testing.T) {
+	out := httptest.NewRecorder()
+	out.Header().Set("Content-Type", "application/json")
+
+	rw := NewResponseEnvelopeWriter(out, sampleRequestId, DecodeJSONTo[details])
+	rw.NegotiateEncoding("application/xml")
+
+	rw.WriteHeader(http.StatusOK)
+	_, err := rw.WriteTyped(sampleJsonData)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, MIMEApplicationXML, out.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", out.Header().Get("Vary"))
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><details><value>12</value></details><requestId>b8e9de68-3d49-4d40-a9a6-f8f3d3eab8f1</requestId><status>SUCCESS</status></response>`
+	assert.Equal(t, expected, out.Body.String())
+}
+
+func TestUnit_EnvelopeResponseWriter_WhenNoEncodingIsAccepted_ExpectJson(t *testing.T) {
+	out := httptest.NewRecorder()
+
+	rw := NewResponseEnvelopeWriter(out, sampleRequestId, DecodeJSONTo[details])
+	rw.NegotiateEncoding("text/html")
+
+	_, err := rw.WriteTyped(sampleJsonData)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.JSONEq(t, `{"requestId":"b8e9de68-3d49-4d40-a9a6-f8f3d3eab8f1","status":"SUCCESS","details":{"value":12}}`, out.Body.String())
+}
+
+func TestUnit_EnvelopeResponseWriter_WhenStatusDoesNotAllowBody_ExpectNoEnvelope(t *testing.T) {
+	out := httptest.NewRecorder()
+
+	rw := NewResponseEnvelopeWriter(out, sampleRequestId, DecodeJSONOrString)
+
+	rw.WriteHeader(http.StatusNoContent)
+	n, err := rw.Write([]byte("null"))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, 4, n)
+	assert.Equal(t, http.StatusNoContent, out.Code)
+	assert.Empty(t, out.Body.String())
+}
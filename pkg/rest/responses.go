@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+type AcceptedResponse struct {
+	StatusUrl string `json:"statusUrl" binding:"required"`
+}
+
+// NoContent answers with a 204 without body. The response envelope is not
+// used as the status does not allow a body.
+func NoContent(c *echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Created answers with a 201 pointing to the created resource with the
+// Location header. When the details are nil the response has no body
+// rather than an envelope without details.
+func Created(c *echo.Context, location string, details any) error {
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	if details == nil {
+		return c.NoContent(http.StatusCreated)
+	}
+
+	return c.JSON(http.StatusCreated, details)
+}
+
+// Accepted answers with a 202 for requests processed asynchronously. The
+// URL where the client can follow the processing is returned both in the
+// Location header and in the body.
+func Accepted(c *echo.Context, statusUrl string) error {
+	c.Response().Header().Set(echo.HeaderLocation, statusUrl)
+	return c.JSON(http.StatusAccepted, AcceptedResponse{StatusUrl: statusUrl})
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_NoContent_ExpectNoBody(t *testing.T) {
+	ctx, rw := generateTestEchoContextWithEnvelope()
+
+	err := NoContent(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Empty(t, rw.Body.String())
+}
+
+func TestUnit_Created_WhenNoDetails_ExpectLocationAndNoBody(t *testing.T) {
+	ctx, rw := generateTestEchoContextWithEnvelope()
+
+	err := Created(ctx, "/samples/1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "/samples/1", rw.Header().Get(echo.HeaderLocation))
+	assert.Empty(t, rw.Body.String())
+}
+
+func TestUnit_Created_WhenDetails_ExpectLocationAndEnvelope(t *testing.T) {
+	ctx, rw := generateTestEchoContextWithEnvelope()
+
+	err := Created(ctx, "/samples/1", map[string]int{"id": 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "/samples/1", rw.Header().Get(echo.HeaderLocation))
+	expected := `{"requestId":"b8e9de68-3d49-4d40-a9a6-f8f3d3eab8f1","status":"SUCCESS","details":{"id":1}}`
+	assert.JSONEq(t, expected, rw.Body.String())
+}
+
+func TestUnit_Accepted_ExpectStatusUrlInHeaderAndBody(t *testing.T) {
+	ctx, rw := generateTestEchoContextWithEnvelope()
+
+	err := Accepted(ctx, "/jobs/1")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rw.Code)
+	assert.Equal(t, "/jobs/1", rw.Header().Get(echo.HeaderLocation))
+	expected := `{"requestId":"b8e9de68-3d49-4d40-a9a6-f8f3d3eab8f1","status":"SUCCESS","details":{"statusUrl":"/jobs/1"}}`
+	assert.JSONEq(t, expected, rw.Body.String())
+}
+
+func generateTestEchoContextWithEnvelope() (*echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	ctx, rw := generateTestEchoContextFromRequest(req)
+
+	echoResp, _ := echo.UnwrapResponse(ctx.Response())
+	echoResp.ResponseWriter = NewResponseEnvelopeWriter(echoResp.ResponseWriter, sampleRequestId, DecodeJSONOrString)
+
+	return ctx, rw
+}
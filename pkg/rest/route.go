@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v5"
 )
 
@@ -9,6 +11,22 @@ type Route interface {
 	Handler() echo.HandlerFunc
 	Path() string
 	UseResponseEnvelope() bool
+	// Timeout returns the maximum duration allowed for the handler of
+	// this route. A value of 0 means the server's default applies.
+	Timeout() time.Duration
+	// Fallback returns the handler to use when the main handler is not
+	// able to serve the request because of an incident (timeout, service
+	// unavailable). It is nil when the route does not define a fallback.
+	Fallback() echo.HandlerFunc
+	// Middlewares returns the middlewares specific to this route. They
+	// are called after the ones defined by the server.
+	Middlewares() []echo.MiddlewareFunc
+	// Doc returns the metadata used to document the route in the OpenAPI
+	// specification. It is nil when the route is not documented.
+	Doc() *RouteDoc
+	// Metadata returns the declaration of the route shared by the
+	// documentation, the metrics and the middlewares.
+	Metadata() RouteMetadata
 }
 
 type Routes []Route
@@ -18,6 +36,7 @@ type routeImpl struct {
 	path                string
 	handler             echo.HandlerFunc
 	useResponseEnvelope bool
+	timeout             time.Duration
 }
 
 func NewRoute(method string, path string, handler echo.HandlerFunc) Route {
@@ -53,3 +72,97 @@ func (r *routeImpl) Path() string {
 func (r *routeImpl) UseResponseEnvelope() bool {
 	return r.useResponseEnvelope
 }
+
+func (r *routeImpl) Timeout() time.Duration {
+	return r.timeout
+}
+
+func (r *routeImpl) Fallback() echo.HandlerFunc {
+	return nil
+}
+
+func (r *routeImpl) Middlewares() []echo.MiddlewareFunc {
+	return nil
+}
+
+func (r *routeImpl) Doc() *RouteDoc {
+	return nil
+}
+
+func (r *routeImpl) Metadata() RouteMetadata {
+	return RouteMetadata{}
+}
+
+// WithTimeout returns a copy of the route which overrides the request
+// timeout configured for the server.
+func WithTimeout(route Route, timeout time.Duration) Route {
+	return &timeoutRoute{
+		Route:   route,
+		timeout: timeout,
+	}
+}
+
+type timeoutRoute struct {
+	Route
+	timeout time.Duration
+}
+
+func (r *timeoutRoute) Timeout() time.Duration {
+	return r.timeout
+}
+
+// WithFallback returns a copy of the route which uses the provided
+// handler to serve degraded responses, typically with cached or static
+// data, when the main handler fails because of an incident.
+func WithFallback(route Route, fallback echo.HandlerFunc) Route {
+	return &fallbackRoute{
+		Route:    route,
+		fallback: fallback,
+	}
+}
+
+type fallbackRoute struct {
+	Route
+	fallback echo.HandlerFunc
+}
+
+func (r *fallbackRoute) Fallback() echo.HandlerFunc {
+	return r.fallback
+}
+
+// WithMiddlewares returns a copy of the route which additionally uses
+// the provided middlewares. This allows for example to only require
+// authentication on some routes.
+func WithMiddlewares(route Route, middlewares ...echo.MiddlewareFunc) Route {
+	return &middlewaresRoute{
+		Route:       route,
+		middlewares: middlewares,
+	}
+}
+
+type middlewaresRoute struct {
+	Route
+	middlewares []echo.MiddlewareFunc
+}
+
+func (r *middlewaresRoute) Middlewares() []echo.MiddlewareFunc {
+	return append(r.Route.Middlewares(), r.middlewares...)
+}
+
+// WithDoc returns a copy of the route documented with the provided
+// metadata in the OpenAPI specification.
+func WithDoc(route Route, doc RouteDoc) Route {
+	return &docRoute{
+		Route: route,
+		doc:   doc,
+	}
+}
+
+type docRoute struct {
+	Route
+	doc RouteDoc
+}
+
+func (r *docRoute) Doc() *RouteDoc {
+	return &r.doc
+}
@@ -0,0 +1,30 @@
+package rest
+
+type ParamLocation string
+
+const (
+	ParamInPath   ParamLocation = "path"
+	ParamInQuery  ParamLocation = "query"
+	ParamInHeader ParamLocation = "header"
+)
+
+type RouteParam struct {
+	Name        string
+	In          ParamLocation
+	Description string
+	Required    bool
+}
+
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Request and Response are values of the types of the bodies, e.g.
+	// MyDto{}. They are only used to generate the schemas. The response
+	// is wrapped in the response envelope when the route uses it.
+	Request  any
+	Response any
+	// Params documents the query and header parameters. The parameters
+	// of the path are documented automatically when not listed here.
+	Params []RouteParam
+}
@@ -0,0 +1,48 @@
+package rest
+
+import "github.com/labstack/echo/v5"
+
+const routeMetadataKey = "route-metadata"
+
+type RouteMetadata struct {
+	// Name identifies the route, e.g. in the metrics or as the operation
+	// id in the OpenAPI specification.
+	Name        string
+	Description string
+	Tags        []string
+	// RequiresAuth indicates that the caller should be authenticated.
+	RequiresAuth bool
+	Deprecated   bool
+}
+
+// WithMetadata returns a copy of the route declaring the provided
+// metadata.
+func WithMetadata(route Route, metadata RouteMetadata) Route {
+	return &metadataRoute{
+		Route:    route,
+		metadata: metadata,
+	}
+}
+
+type metadataRoute struct {
+	Route
+	metadata RouteMetadata
+}
+
+func (r *metadataRoute) Metadata() RouteMetadata {
+	return r.metadata
+}
+
+// SetRouteMetadata attaches the metadata of the route serving the request
+// to the context. This is done by the server for all the routes.
+func SetRouteMetadata(c *echo.Context, metadata RouteMetadata) {
+	c.Set(routeMetadataKey, metadata)
+}
+
+// GetRouteMetadata returns the metadata of the route serving the request.
+// This allows middlewares registered for all the routes to adapt their
+// behavior, e.g. to only require authentication for some of them.
+func GetRouteMetadata(c *echo.Context) (RouteMetadata, bool) {
+	metadata, ok := c.Get(routeMetadataKey).(RouteMetadata)
+	return metadata, ok
+}
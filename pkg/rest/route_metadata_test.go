@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Route_WhenNoMetadata_ExpectZeroValue(t *testing.T) {
+	route := NewRoute(http.MethodGet, "/path", testHandler)
+
+	assert.Equal(t, RouteMetadata{}, route.Metadata())
+}
+
+func TestUnit_WithMetadata_ExpectMetadataToBeReturned(t *testing.T) {
+	metadata := RouteMetadata{
+		Name:         "get-path",
+		Description:  "Get the path",
+		Tags:         []string{"paths"},
+		RequiresAuth: true,
+		Deprecated:   true,
+	}
+
+	route := WithMetadata(WithTimeout(NewRoute(http.MethodGet, "/path", testHandler), 0), metadata)
+
+	assert.Equal(t, metadata, route.Metadata())
+	assert.Equal(t, "/path", route.Path())
+}
+
+func TestUnit_GetRouteMetadata_WhenNotSet_ExpectNotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	_, ok := GetRouteMetadata(ctx)
+
+	assert.False(t, ok)
+}
+
+func TestUnit_GetRouteMetadata_WhenSet_ExpectMetadata(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	metadata := RouteMetadata{Name: "get-path", RequiresAuth: true}
+
+	SetRouteMetadata(ctx, metadata)
+	actual, ok := GetRouteMetadata(ctx)
+
+	assert.True(t, ok)
+	assert.Equal(t, metadata, actual)
+}
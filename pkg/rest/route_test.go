@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,71 @@ func TestUnit_Route_UseResponseEnvelope(t *testing.T) {
 	assert.False(t, r.UseResponseEnvelope())
 }
 
+func TestUnit_Route_Timeout(t *testing.T) {
+	r := NewRoute(http.MethodGet, "/path", testHandler)
+	assert.Equal(t, time.Duration(0), r.Timeout())
+}
+
+func TestUnit_WithTimeout_OverridesTimeout(t *testing.T) {
+	r := WithTimeout(NewRawRoute(http.MethodPost, "/path", testHandler), 2*time.Second)
+
+	assert.Equal(t, 2*time.Second, r.Timeout())
+	assert.Equal(t, http.MethodPost, r.Method())
+	assert.Equal(t, "/path", r.Path())
+	assert.False(t, r.UseResponseEnvelope())
+}
+
+func TestUnit_Route_Fallback(t *testing.T) {
+	r := NewRoute(http.MethodGet, "/path", testHandler)
+	assert.Nil(t, r.Fallback())
+}
+
+func TestUnit_WithFallback_DefinesFallback(t *testing.T) {
+	fallbackCalled := false
+	fallback := func(c *echo.Context) error {
+		fallbackCalled = true
+		return nil
+	}
+
+	r := WithFallback(WithTimeout(NewRoute(http.MethodGet, "/path", testHandler), time.Second), fallback)
+
+	require.NotNil(t, r.Fallback())
+	err := r.Fallback()(dummyEchoContext())
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, fallbackCalled)
+	assert.Equal(t, time.Second, r.Timeout())
+}
+
+func TestUnit_Route_Middlewares(t *testing.T) {
+	r := NewRoute(http.MethodGet, "/path", testHandler)
+	assert.Empty(t, r.Middlewares())
+}
+
+func TestUnit_WithMiddlewares_AppendsMiddlewares(t *testing.T) {
+	noop := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+	r := WithMiddlewares(NewRoute(http.MethodGet, "/path", testHandler), noop)
+	r = WithMiddlewares(WithTimeout(r, time.Second), noop, noop)
+
+	assert.Len(t, r.Middlewares(), 3)
+	assert.Equal(t, time.Second, r.Timeout())
+}
+
+func TestUnit_Route_Doc(t *testing.T) {
+	r := NewRoute(http.MethodGet, "/path", testHandler)
+	assert.Nil(t, r.Doc())
+}
+
+func TestUnit_WithDoc_DefinesDoc(t *testing.T) {
+	doc := RouteDoc{Summary: "my route"}
+
+	r := WithDoc(WithTimeout(NewRoute(http.MethodGet, "/path", testHandler), time.Second), doc)
+
+	require.NotNil(t, r.Doc())
+	assert.Equal(t, doc, *r.Doc())
+	assert.Equal(t, time.Second, r.Timeout())
+}
+
 func dummyEchoContext() *echo.Context {
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
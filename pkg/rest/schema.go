@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JsonSchema is the subset of the OpenAPI schema object generated from
+// the Go types.
+type JsonSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Example              any                    `json:"example,omitempty"`
+	Properties           map[string]*JsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *JsonSchema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	uuidType          = reflect.TypeFor[uuid.UUID]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// SchemaOf generates the schema of the type of the provided value. The
+// fields of structs are named after their json tag and the format,
+// example and description tags are reported in the schema. The fields
+// with a `required` rule in their `validate` or `binding` tag are marked
+// as required, consistently with what Validate enforces.
+func SchemaOf(v any) *JsonSchema {
+	if v == nil {
+		return &JsonSchema{}
+	}
+
+	return schemaForType(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) *JsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &JsonSchema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &JsonSchema{Type: "string", Format: "uuid"}
+	case t.Implements(textMarshalerType):
+		return &JsonSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &JsonSchema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &JsonSchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &JsonSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &JsonSchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &JsonSchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &JsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JsonSchema{Type: "string", Format: "byte"}
+		}
+		return &JsonSchema{Type: "array", Items: schemaForType(t.Elem(), visiting)}
+	case reflect.Map:
+		return &JsonSchema{Type: "object", AdditionalProperties: schemaForType(t.Elem(), visiting)}
+	case reflect.Struct:
+		return schemaForStruct(t, visiting)
+	default:
+		// Interfaces and other types can hold anything.
+		return &JsonSchema{}
+	}
+}
+
+func schemaForStruct(t reflect.Type, visiting map[reflect.Type]bool) *JsonSchema {
+	schema := &JsonSchema{Type: "object", Properties: make(map[string]*JsonSchema)}
+
+	// Recursive types are not expanded further.
+	if visiting[t] {
+		return schema
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		// The exported fields of embedded structs are promoted even when
+		// the struct itself is not exported.
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		fieldSchema := schemaForType(field.Type, visiting)
+
+		// Embedded structs without a json name are flattened as done by
+		// the json package.
+		if field.Anonymous && name == "" && fieldSchema.Properties != nil {
+			for key, property := range fieldSchema.Properties {
+				schema.Properties[key] = property
+			}
+			schema.Required = append(schema.Required, fieldSchema.Required...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		applySchemaTags(fieldSchema, field)
+		schema.Properties[name] = fieldSchema
+
+		if isRequiredField(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+func isRequiredField(field reflect.StructField) bool {
+	return hasRequiredRule(field.Tag.Get("validate")) ||
+		hasRequiredRule(field.Tag.Get("binding"))
+}
+
+func hasRequiredRule(tag string) bool {
+	for rule := range strings.SplitSeq(tag, ",") {
+		// The rules after dive apply to the elements of the field.
+		if rule == "dive" {
+			return false
+		}
+		if rule == "required" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonFieldName returns the name of the field in the json tag. It returns
+// false if the field is ignored by the json package.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	return name, true
+}
+
+func applySchemaTags(schema *JsonSchema, field reflect.StructField) {
+	if format := field.Tag.Get("format"); format != "" {
+		schema.Format = format
+	}
+	if description := field.Tag.Get("description"); description != "" {
+		schema.Description = description
+	}
+	if example, ok := field.Tag.Lookup("example"); ok {
+		schema.Example = parseExample(example, schema.Type)
+	}
+}
+
+func parseExample(example string, schemaType string) any {
+	if schemaType == "string" {
+		return example
+	}
+
+	var out any
+	if err := json.Unmarshal([]byte(example), &out); err != nil {
+		return example
+	}
+
+	return out
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaBase struct {
+	Id uuid.UUID `json:"id" binding:"required"`
+}
+
+type schemaSample struct {
+	schemaBase
+	Name      string         `json:"name" binding:"required" description:"Name of the sample" example:"foo"`
+	Count     int64          `json:"count,omitempty" example:"12"`
+	Ratio     *float64       `json:"ratio"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Tags      []string       `json:"tags"`
+	Labels    map[string]int `json:"labels"`
+	Data      []byte         `json:"data"`
+	Children  []schemaSample `json:"children"`
+	Ignored   string         `json:"-"`
+	NoTag     bool
+	private   string
+	Extra     map[string]string `json:"extra,omitempty" format:"custom"`
+}
+
+func TestUnit_SchemaOf_Primitives(t *testing.T) {
+	assert.Equal(t, &JsonSchema{Type: "string"}, SchemaOf(""))
+	assert.Equal(t, &JsonSchema{Type: "boolean"}, SchemaOf(true))
+	assert.Equal(t, &JsonSchema{Type: "integer", Format: "int32"}, SchemaOf(int32(1)))
+	assert.Equal(t, &JsonSchema{Type: "number", Format: "double"}, SchemaOf(1.0))
+	assert.Equal(t, &JsonSchema{}, SchemaOf(nil))
+}
+
+func TestUnit_SchemaOf_Struct(t *testing.T) {
+	actual := SchemaOf(schemaSample{private: "unused"})
+
+	out, err := json.Marshal(actual)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	expectedJson := `
+	{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"name": {"type": "string", "description": "Name of the sample", "example": "foo"},
+			"count": {"type": "integer", "format": "int64", "example": 12},
+			"ratio": {"type": "number", "format": "double"},
+			"createdAt": {"type": "string", "format": "date-time"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"data": {"type": "string", "format": "byte"},
+			"children": {"type": "array", "items": {"type": "object"}},
+			"NoTag": {"type": "boolean"},
+			"extra": {"type": "object", "format": "custom", "additionalProperties": {"type": "string"}}
+		},
+		"required": ["id", "name"]
+	}`
+	assert.JSONEq(t, expectedJson, string(out))
+}
+
+func TestUnit_SchemaOf_WhenFieldsUseValidateTag_ExpectRequiredFields(t *testing.T) {
+	type sample struct {
+		Id    string   `json:"id" validate:"required"`
+		Name  string   `json:"name" validate:"required,min=3"`
+		Email string   `json:"email" validate:"omitempty,email"`
+		Tags  []string `json:"tags" validate:"dive,required"`
+		Kind  string   `json:"kind" binding:"required"`
+	}
+
+	actual := SchemaOf(sample{})
+
+	assert.Equal(t, []string{"id", "name", "kind"}, actual.Required)
+}
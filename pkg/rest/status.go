@@ -10,10 +10,13 @@ type Status string
 const (
 	StatusSuccess Status = "SUCCESS"
 	StatusError   Status = "ERROR"
+	// StatusDegraded indicates a successful response which was served by
+	// a fallback handler and might be stale or incomplete.
+	StatusDegraded Status = "DEGRADED"
 )
 
 func (s Status) String() string {
-	if s == StatusSuccess || s == StatusError {
+	if s == StatusSuccess || s == StatusError || s == StatusDegraded {
 		return string(s)
 	}
 
@@ -36,6 +39,8 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 		*s = StatusSuccess
 	case "ERROR":
 		*s = StatusError
+	case "DEGRADED":
+		*s = StatusDegraded
 	default:
 		return fmt.Errorf("invalid status: %q", str)
 	}
@@ -24,6 +24,11 @@ func TestUnit_Status_StringerInterface(t *testing.T) {
 			status:   StatusError,
 			expected: "ERROR",
 		},
+		{
+			name:     "StatusDegraded returns DEGRADED",
+			status:   StatusDegraded,
+			expected: "DEGRADED",
+		},
 	}
 
 	for _, tc := range tests {
@@ -76,6 +81,11 @@ func TestUnit_Status_UnmarshalJSON(t *testing.T) {
 			input:    `"ERROR"`,
 			expected: StatusError,
 		},
+		{
+			name:     "\"DEGRADED\" unmarshals to StatusDegraded",
+			input:    `"DEGRADED"`,
+			expected: StatusDegraded,
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+const defaultChunkSize = 32 * 1024
+
+type StreamOptions struct {
+	// MaxSize is the maximum number of bytes which can be read from the
+	// body. Reading more fails with a 413. A value of 0 means no limit.
+	MaxSize int64
+	// OnProgress is called after each read with the total number of bytes
+	// read so far.
+	OnProgress func(read int64)
+}
+
+// BodyReader reads the body of a request while keeping track of the
+// number of bytes read and enforcing the maximum size. It is meant to be
+// used by raw routes processing large bodies without buffering them.
+type BodyReader struct {
+	body     io.ReadCloser
+	options  StreamOptions
+	read     int64
+	exceeded bool
+}
+
+func NewBodyReader(c *echo.Context, options StreamOptions) *BodyReader {
+	return &BodyReader{
+		body:    c.Request().Body,
+		options: options,
+	}
+}
+
+func (r *BodyReader) Read(p []byte) (int, error) {
+	if r.options.MaxSize > 0 {
+		// Reading one more byte than allowed allows to detect bodies which
+		// are too large without consuming them entirely.
+		remaining := r.options.MaxSize - r.read + 1
+		if int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+
+	if r.options.MaxSize > 0 && r.read > r.options.MaxSize {
+		r.read = r.options.MaxSize
+		r.exceeded = true
+		return n - 1, echo.ErrStatusRequestEntityTooLarge
+	}
+
+	if n > 0 && r.options.OnProgress != nil {
+		r.options.OnProgress(r.read)
+	}
+
+	return n, err
+}
+
+func (r *BodyReader) Close() error {
+	return r.body.Close()
+}
+
+// BytesRead returns the number of bytes read from the body so far.
+func (r *BodyReader) BytesRead() int64 {
+	return r.read
+}
+
+// StreamChunks reads the body of the request by chunks of at most the
+// provided size and calls the process function for each of them. The
+// chunk is only valid until the function returns.
+func StreamChunks(c *echo.Context, options StreamOptions, chunkSize int, process func(chunk []byte) error) (int64, error) {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	reader := NewBodyReader(c, options)
+	chunk := make([]byte, chunkSize)
+
+	for {
+		n, err := io.ReadFull(reader, chunk)
+		if n > 0 {
+			if processErr := process(chunk[:n]); processErr != nil {
+				return reader.BytesRead(), processErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return reader.BytesRead(), nil
+		}
+		if err != nil {
+			return reader.BytesRead(), err
+		}
+	}
+}
+
+// StreamNDJSON decodes the body of the request as newline delimited JSON
+// and calls the process function for each value. Empty lines are skipped.
+// It returns the number of values processed. A malformed line results in
+// a 400 error.
+func StreamNDJSON[T any](c *echo.Context, options StreamOptions, process func(value T) error) (int, error) {
+	reader := NewBodyReader(c, options)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, defaultChunkSize), bufio.MaxScanTokenSize*16)
+
+	count := 0
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		// The scanner returns the partial last line when the body is too
+		// large: it should not be processed.
+		if reader.exceeded {
+			return count, echo.ErrStatusRequestEntityTooLarge
+		}
+
+		data := scanner.Bytes()
+		if len(data) == 0 {
+			continue
+		}
+
+		var value T
+		if err := json.Unmarshal(data, &value); err != nil {
+			return count, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid value at line %d: %v", line, err))
+		}
+
+		if err := process(value); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, scanner.Err()
+}
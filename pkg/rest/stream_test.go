@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_BodyReader_CountsBytesRead(t *testing.T) {
+	var progress []int64
+	options := StreamOptions{
+		OnProgress: func(read int64) {
+			progress = append(progress, read)
+		},
+	}
+	reader := NewBodyReader(echoContextWithBody("0123456789"), options)
+
+	data, err := io.ReadAll(io.LimitReader(reader, 4))
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "0123", string(data))
+
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, int64(10), reader.BytesRead())
+	assert.Equal(t, int64(10), progress[len(progress)-1])
+}
+
+func TestUnit_BodyReader_WhenBodyIsWithinLimit_ExpectNoError(t *testing.T) {
+	reader := NewBodyReader(echoContextWithBody("0123456789"), StreamOptions{MaxSize: 10})
+
+	data, err := io.ReadAll(reader)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "0123456789", string(data))
+}
+
+func TestUnit_BodyReader_WhenBodyExceedsLimit_ExpectRequestEntityTooLarge(t *testing.T) {
+	reader := NewBodyReader(echoContextWithBody("0123456789"), StreamOptions{MaxSize: 5})
+
+	data, err := io.ReadAll(reader)
+
+	assert.Equal(t, echo.ErrStatusRequestEntityTooLarge, err)
+	assert.Equal(t, "01234", string(data))
+	assert.Equal(t, int64(5), reader.BytesRead())
+}
+
+func TestUnit_StreamChunks(t *testing.T) {
+	var chunks []string
+	process := func(chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}
+
+	read, err := StreamChunks(echoContextWithBody("0123456789"), StreamOptions{}, 4, process)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, int64(10), read)
+	assert.Equal(t, []string{"0123", "4567", "89"}, chunks)
+}
+
+func TestUnit_StreamChunks_WhenProcessFails_ExpectError(t *testing.T) {
+	process := func(chunk []byte) error {
+		return fmt.Errorf("process failed")
+	}
+
+	_, err := StreamChunks(echoContextWithBody("0123456789"), StreamOptions{}, 4, process)
+
+	assert.Equal(t, fmt.Errorf("process failed"), err)
+}
+
+func TestUnit_StreamNDJSON(t *testing.T) {
+	type element struct {
+		Id int `json:"id"`
+	}
+	body := "{\"id\":1}\n\n{\"id\":2}\n{\"id\":3}"
+
+	var actual []element
+	process := func(value element) error {
+		actual = append(actual, value)
+		return nil
+	}
+
+	count, err := StreamNDJSON(echoContextWithBody(body), StreamOptions{}, process)
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []element{{Id: 1}, {Id: 2}, {Id: 3}}, actual)
+}
+
+func TestUnit_StreamNDJSON_WhenLineIsInvalid_ExpectBadRequest(t *testing.T) {
+	body := "{\"id\":1}\nnot-json\n"
+	process := func(value map[string]any) error { return nil }
+
+	count, err := StreamNDJSON(echoContextWithBody(body), StreamOptions{}, process)
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, http.StatusBadRequest, echo.StatusCode(err))
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestUnit_StreamNDJSON_WhenBodyExceedsLimit_ExpectRequestEntityTooLarge(t *testing.T) {
+	body := "{\"id\":1}\n{\"id\":2}\n"
+	process := func(value map[string]any) error { return nil }
+
+	_, err := StreamNDJSON(echoContextWithBody(body), StreamOptions{MaxSize: 12}, process)
+
+	assert.Equal(t, echo.ErrStatusRequestEntityTooLarge, err)
+}
+
+func echoContextWithBody(body string) *echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
@@ -48,3 +48,16 @@ func FetchIdFromQueryParam(key string, c *echo.Context) (exists bool, id uuid.UU
 	id, err = uuid.Parse(maybeId)
 	return exists, id, err
 }
+
+// FetchIdFromPathParam parses the path parameter as an identifier. A
+// missing or invalid identifier results in a ValidationError.
+func FetchIdFromPathParam(key string, c *echo.Context) (uuid.UUID, error) {
+	return PathParam[uuid.UUID](key, c)
+}
+
+// ClientIP returns the IP of the client which sent the request. When the
+// request went through trusted proxies, the IP is resolved from the
+// X-Forwarded-For header, otherwise it is the IP of the remote peer.
+func ClientIP(c *echo.Context) string {
+	return c.RealIP()
+}
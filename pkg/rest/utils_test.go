@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -145,6 +146,53 @@ func TestUnit_FetchIdFromQueryParam_whenIdIsSet_expectExistCorrectIdAndNoError(t
 	assert.Nil(err)
 }
 
+func TestUnit_FetchIdFromPathParam_whenIdIsSet_expectCorrectIdAndNoError(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := generateTestEchoContextWithPathParam(defaultKey, sampleUuid.String())
+
+	actual, err := FetchIdFromPathParam(defaultKey, ctx)
+	assert.Equal(sampleUuid, actual)
+	assert.Nil(err)
+}
+
+func TestUnit_FetchIdFromPathParam_whenIdSyntaxIsWrong_expectValidationError(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := generateTestEchoContextWithPathParam(defaultKey, "not-a-uuid")
+
+	_, err := FetchIdFromPathParam(defaultKey, ctx)
+	var actual *ValidationError
+	assert.ErrorAs(err, &actual)
+	assert.Equal("invalid UUID length: 10", actual.Fields[0].Message)
+}
+
+func TestUnit_ClientIP_ReturnsRemoteAddress(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.10:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.5")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+
+	actual := ClientIP(ctx)
+
+	assert.Equal(t, "192.0.2.10", actual)
+}
+
+func TestUnit_ClientIP_UsesIPExtractor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.10:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.5")
+	ctx, _ := generateTestEchoContextFromRequest(req)
+	ctx.Echo().IPExtractor = echo.ExtractIPFromXFFHeader(echo.TrustIPRange(&net.IPNet{
+		IP:   net.ParseIP("192.0.2.0"),
+		Mask: net.CIDRMask(24, 32),
+	}))
+
+	actual := ClientIP(ctx)
+
+	assert.Equal(t, "203.0.113.5", actual)
+}
+
 func generateRequestWithQueryParams(key string, value string) *http.Request {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 
@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v5"
+)
+
+const validationFailedMessage = "request validation failed"
+
+var validate = newValidator()
+
+type FieldError struct {
+	// Field is the path of the field as named in the json body.
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError is returned when the body of a request does not satisfy
+// the `validate` tags of the type it is bound to. It results in a 400 with
+// the details of the invalid fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fields = append(fields, field.Field)
+	}
+
+	return fmt.Sprintf("%s: %s", validationFailedMessage, strings.Join(fields, ", "))
+}
+
+// Unwrap returns one error per invalid field, see errors.Errors.
+func (e *ValidationError) Unwrap() []error {
+	out := make([]error, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		out = append(out, field)
+	}
+
+	return out
+}
+
+func (e *ValidationError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message string       `json:"message"`
+		Fields  []FieldError `json:"fields"`
+	}{
+		Message: validationFailedMessage,
+		Fields:  e.Fields,
+	})
+}
+
+// BindAndValidate binds the request to a value of type T (see echo's
+// Bind) and validates it against its `validate` tags. Binding failures
+// result in a 400 and validation failures in a ValidationError.
+func BindAndValidate[T any](c *echo.Context) (T, error) {
+	var out T
+	if err := c.Bind(&out); err != nil {
+		return out, err
+	}
+
+	return out, Validate(out)
+}
+
+// Validate checks the value against its `validate` tags. It returns a
+// ValidationError when some fields are invalid. The elements of slices
+// and maps are validated individually while other values, which can't
+// have tags, are always valid.
+func Validate(v any) error {
+	var err error
+	switch indirectKind(reflect.ValueOf(v)) {
+	case reflect.Struct:
+		err = validate.Struct(v)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		err = validate.Var(v, "dive")
+	default:
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !stderrors.As(err, &validationErrs) {
+		return err
+	}
+
+	out := &ValidationError{}
+	for _, fieldErr := range validationErrs {
+		out.Fields = append(out.Fields, newFieldError(fieldErr))
+	}
+
+	return out
+}
+
+func indirectKind(value reflect.Value) reflect.Kind {
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value.Kind()
+}
+
+// RegisterValidation adds a custom rule usable in the `validate` tags. It
+// is meant to be called once when the service starts.
+func RegisterValidation(tag string, fn validator.Func) error {
+	return validate.RegisterValidation(tag, fn)
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report the fields with the name used in the json body.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, ok := jsonFieldName(field)
+		if !ok {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	return v
+}
+
+func newFieldError(err validator.FieldError) FieldError {
+	// The namespace starts with the name of the validated type which is
+	// not relevant for the client.
+	_, field, found := strings.Cut(err.Namespace(), ".")
+	if !found {
+		field = err.Field()
+	}
+
+	message := fmt.Sprintf("failed on the '%s' rule", err.Tag())
+	if err.Param() != "" {
+		message = fmt.Sprintf("failed on the '%s=%s' rule", err.Tag(), err.Param())
+	}
+
+	return FieldError{
+		Field:   field,
+		Rule:    err.Tag(),
+		Message: message,
+	}
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationNested struct {
+	Value int `json:"value" validate:"gte=0"`
+}
+
+type validationSample struct {
+	Name   string           `json:"name" validate:"required"`
+	Email  string           `json:"email" validate:"omitempty,email"`
+	Nested validationNested `json:"nested"`
+}
+
+type customValidationSample struct {
+	Code string `json:"code" validate:"even_length"`
+}
+
+func TestUnit_Validate_WhenValueIsValid_ExpectNoError(t *testing.T) {
+	err := Validate(validationSample{Name: "foo"})
+
+	assert.NoError(t, err, "Actual err: %v", err)
+}
+
+func TestUnit_Validate_WhenValueIsInvalid_ExpectFieldErrors(t *testing.T) {
+	in := validationSample{
+		Email:  "not-an-email",
+		Nested: validationNested{Value: -1},
+	}
+
+	err := Validate(in)
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	expected := []FieldError{
+		{Field: "name", Rule: "required", Message: "failed on the 'required' rule"},
+		{Field: "email", Rule: "email", Message: "failed on the 'email' rule"},
+		{Field: "nested.value", Rule: "gte", Message: "failed on the 'gte=0' rule"},
+	}
+	assert.Equal(t, expected, actual.Fields)
+	assert.Equal(t, http.StatusBadRequest, actual.StatusCode())
+}
+
+func TestUnit_Validate_WhenValueIsNotAStruct(t *testing.T) {
+	t.Run("accepts values without tags", func(t *testing.T) {
+		for _, v := range []any{42, "foo", []map[string]int{{"a": 1}}, map[string]string{"a": "b"}, nil} {
+			err := Validate(v)
+
+			assert.NoError(t, err, "Value: %v, actual err: %v", v, err)
+		}
+	})
+
+	t.Run("validates elements of slices", func(t *testing.T) {
+		err := Validate([]validationSample{{Name: "foo"}, {}})
+
+		var actual *ValidationError
+		require.ErrorAs(t, err, &actual)
+		require.Len(t, actual.Fields, 1)
+		assert.Equal(t, "required", actual.Fields[0].Rule)
+	})
+
+	t.Run("validates pointer to struct", func(t *testing.T) {
+		err := Validate(&validationSample{})
+
+		var actual *ValidationError
+		assert.ErrorAs(t, err, &actual)
+	})
+}
+
+func TestUnit_ValidationError_MarshalsFieldDetails(t *testing.T) {
+	err := &ValidationError{
+		Fields: []FieldError{{Field: "name", Rule: "required", Message: "failed on the 'required' rule"}},
+	}
+
+	out, marshalErr := json.Marshal(err)
+
+	require.NoError(t, marshalErr, "Actual err: %v", marshalErr)
+	expectedJson := `
+	{
+		"message": "request validation failed",
+		"fields": [
+			{"field": "name", "rule": "required", "message": "failed on the 'required' rule"}
+		]
+	}`
+	assert.JSONEq(t, expectedJson, string(out))
+}
+
+func TestUnit_ValidationError_ExpectOneErrorPerField(t *testing.T) {
+	err := Validate(validationSample{Email: "not-an-email"})
+
+	actual := errors.Errors(err)
+
+	require.Len(t, actual, 2)
+	assert.EqualError(t, actual[0], "name: failed on the 'required' rule")
+	assert.EqualError(t, actual[1], "email: failed on the 'email' rule")
+}
+
+func TestUnit_RegisterValidation_ExpectRuleToBeUsed(t *testing.T) {
+	err := RegisterValidation("even_length", func(fl validator.FieldLevel) bool {
+		return len(fl.Field().String())%2 == 0
+	})
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = Validate(customValidationSample{Code: "abc"})
+
+	var actual *ValidationError
+	require.ErrorAs(t, err, &actual)
+	require.Len(t, actual.Fields, 1)
+	assert.Equal(t, "even_length", actual.Fields[0].Rule)
+}
+
+func TestUnit_BindAndValidate(t *testing.T) {
+	t.Run("returns bound value when valid", func(t *testing.T) {
+		c := jsonEchoContext(`{"name": "foo"}`)
+
+		actual, err := BindAndValidate[validationSample](c)
+
+		require.NoError(t, err, "Actual err: %v", err)
+		assert.Equal(t, "foo", actual.Name)
+	})
+
+	t.Run("returns bad request when body is malformed", func(t *testing.T) {
+		c := jsonEchoContext(`{"name": `)
+
+		_, err := BindAndValidate[validationSample](c)
+
+		assert.Equal(t, http.StatusBadRequest, echo.StatusCode(err))
+	})
+
+	t.Run("returns validation error when invalid", func(t *testing.T) {
+		c := jsonEchoContext(`{"email": "foo"}`)
+
+		_, err := BindAndValidate[validationSample](c)
+
+		var actual *ValidationError
+		require.ErrorAs(t, err, &actual)
+		assert.Len(t, actual.Fields, 2)
+	})
+}
+
+func jsonEchoContext(body string) *echo.Context {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	om "github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+// adminServer hosts the operational routes (health, metrics, ...) on a
+// dedicated port so that they are not exposed on the public one. It
+// does not have the CORS and request tracking middlewares of the main
+// server.
+type adminServer struct {
+	echo            *echo.Echo
+	port            uint16
+	shutdownTimeout time.Duration
+	router          *echo.Group
+	metadata        *routeMetadataRegistry
+}
+
+func newAdminServer(config AdminConfig, shutdownTimeout time.Duration, log *slog.Logger) *adminServer {
+	metadata := newRouteMetadataRegistry()
+
+	e := echo.New()
+	e.Logger = log
+	e.Use(metadata.middleware())
+	e.Use(om.RequestLogger())
+
+	return &adminServer{
+		echo:            e,
+		port:            config.Port,
+		shutdownTimeout: shutdownTimeout,
+		router:          e.Group(""),
+		metadata:        metadata,
+	}
+}
+
+func (a *adminServer) addRoute(route rest.Route) error {
+	// The limits of the main server do not apply to the admin routes:
+	// they usually are cheap or, like profiling, long on purpose.
+	middlewares := buildMiddlewaresForRoute(route, routeConfig{})
+	if err := registerRoute(a.router, route.Path(), route, middlewares); err != nil {
+		return err
+	}
+	a.metadata.register(route.Method(), route.Path(), route.Metadata())
+
+	a.echo.Logger.Debug("Registered admin route", slog.String("method", route.Method()), slog.String("path", route.Path()))
+
+	return nil
+}
+
+func (a *adminServer) start(ctx context.Context) error {
+	address := fmt.Sprintf(":%d", a.port)
+
+	a.echo.Logger.Info("Starting admin server", slog.String("address", address))
+
+	sc := echo.StartConfig{
+		Address:         address,
+		HideBanner:      true,
+		HidePort:        true,
+		GracefulTimeout: a.shutdownTimeout,
+	}
+
+	if err := sc.Start(ctx, a.echo); err != nil {
+		a.echo.Logger.Error("Admin server failed", slog.String("address", address), slog.Any("error", err))
+		return err
+	}
+
+	a.echo.Logger.Info("Admin server gracefully shutdown", slog.String("address", address))
+
+	return nil
+}
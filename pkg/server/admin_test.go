@@ -0,0 +1,96 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Server_AddAdminRoute_WhenAdminServerDisabled_ExpectError(t *testing.T) {
+	s := newTestServer(4012)
+
+	route := rest.NewRawRoute(http.MethodGet, "/health", testHttpHandler)
+	err := s.AddAdminRoute(route)
+
+	assert.Equal(t, ErrAdminServerDisabled, err, "Actual err: %v", err)
+}
+
+func TestUnit_Server_AddAdminRoute_WhenMethodIsNotSupported_ExpectError(t *testing.T) {
+	s := newTestServerWithAdmin(4013, 4014)
+
+	route := rest.NewRawRoute(http.MethodPut, "/health", testHttpHandler)
+	err := s.AddAdminRoute(route)
+
+	assert.Equal(t, ErrUnsupportedMethod, err, "Actual err: %v", err)
+}
+
+func TestUnit_Server_ServesAdminRoutesOnAdminPortOnly(t *testing.T) {
+	s := newTestServerWithAdmin(4015, 4016)
+	healthHandler := func(c *echo.Context) error {
+		return c.String(http.StatusOK, "healthy")
+	}
+	route := rest.NewRawRoute(http.MethodGet, "/health", healthHandler)
+	err := s.AddAdminRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	adminResponse := doRequest(t, http.MethodGet, "http://localhost:4016/health")
+	publicResponse := doRequest(t, http.MethodGet, "http://localhost:4015/health")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, adminResponse.StatusCode)
+	body, err := io.ReadAll(adminResponse.Body)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "healthy", string(body))
+	assert.Equal(t, http.StatusNotFound, publicResponse.StatusCode)
+}
+
+func TestUnit_Server_WhenAdminServerFailsToStart_ExpectServerToStopWithError(t *testing.T) {
+	listener, err := net.Listen("tcp", ":4018")
+	require.NoError(t, err, "Actual err: %v", err)
+	defer func() {
+		err := listener.Close()
+		require.NoError(t, err, "Actual err: %v", err)
+	}()
+
+	s := newTestServerWithAdmin(4017, 4018)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Start()
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "Server did not stop after admin server failure")
+	}
+
+	assert.Error(t, err)
+}
+
+func newTestServerWithAdmin(port uint16, adminPort uint16) Server {
+	config := Config{
+		BasePath:        "/",
+		Port:            port,
+		ShutdownTimeout: 2 * time.Second,
+		Admin: AdminConfig{
+			Enabled: true,
+			Port:    adminPort,
+		},
+	}
+
+	return NewWithLogger(config, slog.Default())
+}
@@ -1,9 +1,92 @@
 package server
 
-import "time"
+import (
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"go.opentelemetry.io/otel/trace"
+)
 
 type Config struct {
 	BasePath        string
 	Port            uint16
 	ShutdownTimeout time.Duration
+	// DrainTimeout defines how long in-flight requests are allowed to run
+	// once the server is asked to stop. Requests still running after this
+	// delay have their context cancelled. A value of 0 disables the drain
+	// phase and lets requests run until the shutdown timeout expires.
+	DrainTimeout time.Duration
+	// RequestTimeout defines how long handlers are allowed to run before
+	// their context is cancelled and a 504 is returned. Routes can use a
+	// different value with rest.WithTimeout. A value of 0 disables it.
+	RequestTimeout time.Duration
+	// MaxRequestBodySize defines the maximum size in bytes of the body of
+	// requests. Larger requests are rejected with a 413. A value of 0
+	// disables the limit.
+	MaxRequestBodySize int64
+	// TrustedProxies lists the IPs or CIDR ranges of the proxies (e.g. load
+	// balancers) in front of the server. The client IP is resolved from the
+	// X-Forwarded-For header set by those proxies.
+	TrustedProxies []string
+	// RateLimit defines the limits applied to all the routes of the main
+	// server. It is disabled when no limit is set.
+	RateLimit middleware.RateLimitConfig
+	// ConcurrencyLimiter caps the number of requests processed at the
+	// same time by the main server when set. The caller keeps a reference
+	// to it to export its statistics as metrics.
+	ConcurrencyLimiter *middleware.ConcurrencyLimiter
+	// SecureHeaders adds security related headers (HSTS, CSP, ...) to all
+	// the responses of the main server when enabled.
+	SecureHeaders middleware.SecureHeadersConfig
+	// Compression compresses the responses of the main server when
+	// enabled. Streams and already compressed content are not compressed.
+	Compression middleware.CompressionConfig
+	// Locale resolves the locale of the requests of the main server from
+	// the Accept-Language header when supported locales are configured.
+	Locale middleware.LocaleConfig
+	// RequestLogger defines the fields logged for each request of the
+	// main server and the paths which should not be logged.
+	RequestLogger middleware.RequestLoggerConfig
+	// Timing defines whether the time spent in the segments of the
+	// requests of the main server is sent back in the Server-Timing
+	// header. It is only logged by default.
+	Timing middleware.TimingConfig
+	// BodyDump logs the bodies of the requests and responses of the main
+	// server when enabled. It is meant to debug integration issues.
+	BodyDump middleware.BodyDumpConfig
+	// TracerProvider enables the tracing of the requests of the main
+	// server when set. It can't be loaded from the configuration file.
+	TracerProvider trace.TracerProvider
+	// OnPanic is called when a handler of the main server panics, e.g.
+	// to report it to an error tracking service.
+	OnPanic middleware.PanicHandler
+	// ErrorHook receives the panics and the server errors (5xx) of the
+	// main server, e.g. to forward them to an alerting service. It can't
+	// be loaded from the configuration file.
+	ErrorHook logger.ErrorHook
+	// DeprecatedVersions lists the API versions which are deprecated: the
+	// routes added with AddVersionedRoute for those versions send the
+	// deprecation headers in their responses.
+	DeprecatedVersions map[int]middleware.DeprecationConfig
+	// OpenApi describes the service in the OpenAPI specification of the
+	// routes of the main server.
+	OpenApi rest.OpenApiInfo
+	// OpenApiSecuritySchemes are the schemes accepted by the routes
+	// requiring authentication in the OpenAPI specification. A bearer
+	// token is assumed when none is provided.
+	OpenApiSecuritySchemes map[string]rest.OpenApiSecurityScheme
+	Admin                  AdminConfig
+	// EnablePprof registers the pprof handlers under /debug/pprof. They
+	// are served by the admin server if it is enabled and by the main
+	// server otherwise.
+	EnablePprof bool
+}
+
+type AdminConfig struct {
+	// Enabled starts a second listener on the admin port hosting the
+	// routes registered with AddAdminRoute.
+	Enabled bool
+	Port    uint16
 }
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/config"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+)
+
+const configPath = "/debug/config"
+
+type ConfigRouteConfig struct {
+	// Config is the configuration loaded by the service. The fields
+	// marked as secret are masked (see config.Dump).
+	Config any
+	// Username and Password protect the route with basic authentication
+	// when they are set.
+	Username string
+	Password string
+}
+
+// NewConfigRoute returns a raw route rendering the configuration the
+// service runs with as plain text. It is meant to be registered on the
+// admin server with AddAdminRoute.
+func NewConfigRoute(routeConfig ConfigRouteConfig) rest.Route {
+	// The configuration does not change once the service is started.
+	dump := config.Dump(routeConfig.Config)
+
+	handler := func(c *echo.Context) error {
+		return c.String(http.StatusOK, dump)
+	}
+
+	if routeConfig.Username != "" || routeConfig.Password != "" {
+		handler = middleware.BasicAuth(credentialsValidator(routeConfig.Username, routeConfig.Password))(handler)
+	}
+
+	return rest.NewRawRoute(http.MethodGet, configPath, handler)
+}
+
+func credentialsValidator(username string, password string) middleware.BasicAuthValidator {
+	return func(c *echo.Context, user string, pass string) (bool, error) {
+		validUser := subtle.ConstantTimeCompare([]byte(user), []byte(username)) == 1
+		validPass := subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+		return validUser && validPass, nil
+	}
+}
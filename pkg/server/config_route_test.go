@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configRouteSample struct {
+	Host     string
+	Password string `secret:"true"`
+}
+
+func TestUnit_ConfigRoute_Path(t *testing.T) {
+	r := NewConfigRoute(ConfigRouteConfig{})
+
+	assert.Equal(t, http.MethodGet, r.Method())
+	assert.Equal(t, "/debug/config", r.Path())
+	assert.False(t, r.UseResponseEnvelope())
+}
+
+func TestUnit_ConfigRoute_DumpsRedactedConfiguration(t *testing.T) {
+	r := NewConfigRoute(ConfigRouteConfig{
+		Config: configRouteSample{Host: "localhost", Password: "my-password"},
+	})
+
+	rw, err := callDiagnosticsRoute(r, nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "host: \"localhost\"\npassword: ******\n", rw.Body.String())
+}
+
+func TestUnit_ConfigRoute_WhenCredentialsAreInvalid_ExpectUnauthorized(t *testing.T) {
+	r := NewConfigRoute(ConfigRouteConfig{Username: "admin", Password: "secret"})
+
+	_, err := callDiagnosticsRoute(r, func(req *http.Request) {
+		req.SetBasicAuth("admin", "not-the-secret")
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, echo.StatusCode(err))
+}
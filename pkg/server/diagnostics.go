@@ -0,0 +1,142 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+const diagnosticsPath = "/debug/diagnostics"
+
+var processStart = time.Now()
+
+type DiagnosticsConfig struct {
+	// Config is the configuration loaded by the service. Only a digest of
+	// it is exposed so that secrets do not leak.
+	Config any
+	// PoolStats, when set, reports the state of the database connection
+	// pool, e.g. with the Stats of a db.Connection.
+	PoolStats func() any
+	// Auth protects the route when set, e.g. with middleware.ApiKeyAuth or
+	// echo's BasicAuth.
+	Auth echo.MiddlewareFunc
+}
+
+type diagnostics struct {
+	Uptime       string      `json:"uptime"`
+	Goroutines   int         `json:"goroutines"`
+	Memory       memoryStats `json:"memory"`
+	Build        buildInfo   `json:"build"`
+	ConfigDigest string      `json:"configDigest,omitempty"`
+	Pool         any         `json:"pool,omitempty"`
+}
+
+type memoryStats struct {
+	Alloc        uint64 `json:"alloc"`
+	TotalAlloc   uint64 `json:"totalAlloc"`
+	Sys          uint64 `json:"sys"`
+	HeapObjects  uint64 `json:"heapObjects"`
+	NumGC        uint32 `json:"numGC"`
+	PauseTotalNs uint64 `json:"pauseTotalNs"`
+}
+
+type buildInfo struct {
+	GoVersion string `json:"goVersion"`
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+// NewDiagnosticsRoute returns a raw route dumping runtime information
+// about the process. It is meant to be registered on the admin server
+// with AddAdminRoute to help triaging production issues.
+func NewDiagnosticsRoute(config DiagnosticsConfig) rest.Route {
+	digest := configDigest(config.Config)
+	build := readBuildInfo()
+
+	handler := func(c *echo.Context) error {
+		out := diagnostics{
+			Uptime:       time.Since(processStart).Round(time.Second).String(),
+			Goroutines:   runtime.NumGoroutine(),
+			Memory:       readMemoryStats(),
+			Build:        build,
+			ConfigDigest: digest,
+		}
+
+		if config.PoolStats != nil {
+			out.Pool = config.PoolStats()
+		}
+
+		return c.JSON(http.StatusOK, out)
+	}
+
+	if config.Auth != nil {
+		handler = config.Auth(handler)
+	}
+
+	return rest.NewRawRoute(http.MethodGet, diagnosticsPath, handler)
+}
+
+func configDigest(config any) string {
+	if config == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readMemoryStats() memoryStats {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return memoryStats{
+		Alloc:        stats.Alloc,
+		TotalAlloc:   stats.TotalAlloc,
+		Sys:          stats.Sys,
+		HeapObjects:  stats.HeapObjects,
+		NumGC:        stats.NumGC,
+		PauseTotalNs: stats.PauseTotalNs,
+	}
+}
+
+func readBuildInfo() buildInfo {
+	out := buildInfo{
+		GoVersion: runtime.Version(),
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return out
+	}
+
+	out.Path = info.Main.Path
+	out.Version = info.Main.Version
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			out.Revision = setting.Value
+		case "vcs.time":
+			out.Time = setting.Value
+		case "vcs.modified":
+			out.Modified = setting.Value == "true"
+		}
+	}
+
+	return out
+}
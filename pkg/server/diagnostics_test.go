@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_DiagnosticsRoute_Path(t *testing.T) {
+	r := NewDiagnosticsRoute(DiagnosticsConfig{})
+
+	assert.Equal(t, http.MethodGet, r.Method())
+	assert.Equal(t, "/debug/diagnostics", r.Path())
+	assert.False(t, r.UseResponseEnvelope())
+}
+
+func TestUnit_DiagnosticsRoute_DumpsRuntimeInformation(t *testing.T) {
+	config := DiagnosticsConfig{
+		Config: map[string]string{"key": "value"},
+		PoolStats: func() any {
+			return db.PoolStats{MaxConns: 4, IdleConns: 2}
+		},
+	}
+	r := NewDiagnosticsRoute(config)
+
+	rw, err := callDiagnosticsRoute(r, nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	var actual struct {
+		diagnostics
+		Pool *db.PoolStats `json:"pool"`
+	}
+	err = json.Unmarshal(rw.Body.Bytes(), &actual)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Positive(t, actual.Goroutines)
+	assert.Positive(t, actual.Memory.Sys)
+	assert.NotEmpty(t, actual.Build.GoVersion)
+	assert.Equal(t, configDigest(config.Config), actual.ConfigDigest)
+	assert.Len(t, actual.ConfigDigest, 64)
+	require.NotNil(t, actual.Pool)
+	assert.Equal(t, int32(4), actual.Pool.MaxConns)
+	assert.Equal(t, int32(2), actual.Pool.IdleConns)
+}
+
+func TestUnit_DiagnosticsRoute_WhenNoPoolStats_ExpectNoPoolStats(t *testing.T) {
+	r := NewDiagnosticsRoute(DiagnosticsConfig{})
+
+	rw, err := callDiagnosticsRoute(r, nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.NotContains(t, rw.Body.String(), `"pool"`)
+	assert.NotContains(t, rw.Body.String(), `"configDigest"`)
+}
+
+func TestUnit_DiagnosticsRoute_WhenApiKeyIsInvalid_ExpectUnauthorized(t *testing.T) {
+	r := NewDiagnosticsRoute(DiagnosticsConfig{Auth: middleware.ApiKeyAuth(diagnosticsApiKeyLookup)})
+
+	_, err := callDiagnosticsRoute(r, func(req *http.Request) {
+		req.Header.Set("X-Api-Key", "not-the-key")
+	})
+
+	assert.Equal(t, middleware.ErrInvalidApiKey, err)
+}
+
+func TestUnit_DiagnosticsRoute_WhenApiKeyIsValid_ExpectSuccess(t *testing.T) {
+	r := NewDiagnosticsRoute(DiagnosticsConfig{Auth: middleware.ApiKeyAuth(diagnosticsApiKeyLookup)})
+
+	rw, err := callDiagnosticsRoute(r, func(req *http.Request) {
+		req.Header.Set("X-Api-Key", "admin-key")
+	})
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, rw.Code)
+}
+
+func callDiagnosticsRoute(r rest.Route, prepare func(*http.Request)) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(http.MethodGet, diagnosticsPath, nil)
+	if prepare != nil {
+		prepare(req)
+	}
+	rw := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rw)
+
+	err := r.Handler()(ctx)
+	return rw, err
+}
+
+func diagnosticsApiKeyLookup(ctx context.Context, key string) (middleware.Principal, error) {
+	if key != "admin-key" {
+		return middleware.Principal{}, middleware.ErrInvalidApiKey
+	}
+	return middleware.Principal{Id: "admin"}, nil
+}
@@ -3,9 +3,23 @@ package server
 import "github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
 
 const (
-	errUnsupportedMethod errors.ErrorCode = 300
+	errUnsupportedMethod   errors.ErrorCode = 300
+	errAdminServerDisabled errors.ErrorCode = 301
+	errInvalidApiVersion   errors.ErrorCode = 302
 )
 
 var (
-	ErrUnsupportedMethod = errors.FromCode(errUnsupportedMethod)
+	ErrUnsupportedMethod   = errors.FromCode(errUnsupportedMethod)
+	ErrAdminServerDisabled = errors.FromCode(errAdminServerDisabled)
+	ErrInvalidApiVersion   = errors.FromCode(errInvalidApiVersion)
 )
+
+func init() {
+	for _, info := range []errors.CodeInfo{
+		{Code: errUnsupportedMethod, Name: "server.unsupported_method"},
+		{Code: errAdminServerDisabled, Name: "server.admin_server_disabled"},
+		{Code: errInvalidApiVersion, Name: "server.invalid_api_version"},
+	} {
+		errors.Register(info)
+	}
+}
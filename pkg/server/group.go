@@ -0,0 +1,64 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/errors"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+)
+
+// Group runs several servers (e.g. the public API and a metrics server) as
+// a single process.Runnable. The servers are started together and as soon
+// as one of them stops, whether it failed or not, the others are stopped
+// as well. Start returns once all of them stopped, with their errors
+// aggregated in an errors.MultiError.
+type Group struct {
+	servers  []process.Runnable
+	stopOnce sync.Once
+	stopErr  error
+}
+
+func NewGroup(servers ...Server) *Group {
+	g := &Group{}
+	for _, s := range servers {
+		g.servers = append(g.servers, s)
+	}
+
+	return g
+}
+
+func (g *Group) Start() error {
+	done := make(chan error, len(g.servers))
+	for _, s := range g.servers {
+		go func() {
+			done <- s.Start()
+		}()
+	}
+
+	var errs []error
+	for range g.servers {
+		if err := <-done; err != nil {
+			errs = append(errs, err)
+		}
+
+		g.stopAll()
+	}
+
+	errs = append(errs, g.stopErr)
+	return errors.Join(errs...)
+}
+
+func (g *Group) Stop() error {
+	g.stopAll()
+	return g.stopErr
+}
+
+func (g *Group) stopAll() {
+	g.stopOnce.Do(func() {
+		var errs []error
+		for _, s := range g.servers {
+			errs = append(errs, s.Stop())
+		}
+		g.stopErr = errors.Join(errs...)
+	})
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Group_StartsAndStopsAllServers(t *testing.T) {
+	g := NewGroup(
+		newTestServerWithOkHandler(t, 4034),
+		newTestServerWithOkHandler(t, 4035),
+	)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, g)
+
+	first := doRequest(t, http.MethodGet, "http://localhost:4034")
+	second := doRequest(t, http.MethodGet, "http://localhost:4035")
+
+	err := g.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, first)
+	assertIsOkResponse(t, second)
+}
+
+func TestUnit_Group_WhenOneServerFails_ExpectOthersToBeStopped(t *testing.T) {
+	// Both servers use the same port: one of them fails to start.
+	g := NewGroup(
+		newTestServerWithOkHandler(t, 4036),
+		newTestServerWithOkHandler(t, 4036),
+	)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Start()
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "address already in use")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Group did not stop after one of its servers failed")
+	}
+}
+
+func TestUnit_Group_WhenStoppedTwice_ExpectNoError(t *testing.T) {
+	g := NewGroup(newTestServerWithOkHandler(t, 4037))
+
+	done := asyncRunServerAndAssertStopWithoutError(t, g)
+
+	err := g.Stop()
+	<-done
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = g.Stop()
+	assert.NoError(t, err, "Actual err: %v", err)
+}
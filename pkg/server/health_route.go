@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+const (
+	livenessPath  = "/livez"
+	readinessPath = "/readyz"
+)
+
+type healthResponse struct {
+	Status     string                    `json:"status"`
+	Components []process.ComponentHealth `json:"components"`
+}
+
+// NewHealthRoutes returns raw routes reporting the liveness (/livez) and
+// the readiness (/readyz) of the service from the state of its components.
+// They answer with a 503 when the service is not live or ready and are
+// meant to be registered on the admin server with AddAdminRoute.
+func NewHealthRoutes(health *process.Health) rest.Routes {
+	return rest.Routes{
+		rest.NewRawRoute(http.MethodGet, livenessPath, healthHandler(health, health.Live)),
+		rest.NewRawRoute(http.MethodGet, readinessPath, healthHandler(health, health.Ready)),
+	}
+}
+
+func healthHandler(health *process.Health, check func() bool) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		status, out := http.StatusOK, healthResponse{Status: "ok"}
+		if !check() {
+			status, out.Status = http.StatusServiceUnavailable, "unavailable"
+		}
+		out.Components = health.Components()
+
+		return c.JSON(status, out)
+	}
+}
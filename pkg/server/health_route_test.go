@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_HealthRoutes_Paths(t *testing.T) {
+	routes := NewHealthRoutes(process.NewHealth())
+
+	require.Len(t, routes, 2)
+	assert.Equal(t, http.MethodGet, routes[0].Method())
+	assert.Equal(t, "/livez", routes[0].Path())
+	assert.Equal(t, http.MethodGet, routes[1].Method())
+	assert.Equal(t, "/readyz", routes[1].Path())
+}
+
+func TestUnit_HealthRoutes_WhenComponentsAreRunning_ExpectReady(t *testing.T) {
+	health := process.NewHealth()
+	health.Report("server", process.StateRunning, nil)
+	health.Report("consumer", process.StateDegraded, fmt.Errorf("broker unreachable"))
+	routes := NewHealthRoutes(health)
+
+	rw := callHealthRoute(t, routes[1])
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	actual := unmarshalHealthResponse(t, rw)
+	assert.Equal(t, "ok", actual.Status)
+	require.Len(t, actual.Components, 2)
+	assert.Equal(t, "consumer", actual.Components[0].Name)
+	assert.Equal(t, process.StateDegraded, actual.Components[0].State)
+	assert.Equal(t, "broker unreachable", actual.Components[0].LastError)
+}
+
+func TestUnit_HealthRoutes_WhenComponentIsStarting_ExpectNotReadyButLive(t *testing.T) {
+	health := process.NewHealth()
+	health.Report("server", process.StateRunning, nil)
+	health.Report("consumer", process.StateStarting, nil)
+	routes := NewHealthRoutes(health)
+
+	rw := callHealthRoute(t, routes[1])
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, "unavailable", unmarshalHealthResponse(t, rw).Status)
+
+	rw = callHealthRoute(t, routes[0])
+	assert.Equal(t, http.StatusOK, rw.Code)
+}
+
+func TestUnit_HealthRoutes_WhenComponentFailed_ExpectNotLive(t *testing.T) {
+	health := process.NewHealth()
+	health.Report("consumer", process.StateStopped, fmt.Errorf("some error"))
+	routes := NewHealthRoutes(health)
+
+	rw := callHealthRoute(t, routes[0])
+
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+}
+
+func callHealthRoute(t *testing.T, r rest.Route) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(r.Method(), r.Path(), nil)
+	rw := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rw)
+
+	err := r.Handler()(ctx)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	return rw
+}
+
+func unmarshalHealthResponse(t *testing.T, rw *httptest.ResponseRecorder) healthResponse {
+	var out healthResponse
+	err := json.Unmarshal(rw.Body.Bytes(), &out)
+	require.NoError(t, err, "Actual err: %v", err)
+	return out
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net"
+	"sync"
+)
+
+type StartHook func(addr net.Addr)
+type StopHook func()
+type RouteHook func(method string, path string)
+
+type hooks struct {
+	lock    sync.Mutex
+	onStart []StartHook
+	onStop  []StopHook
+	onRoute []RouteHook
+}
+
+func (h *hooks) addStartHook(hook StartHook) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.onStart = append(h.onStart, hook)
+}
+
+func (h *hooks) addStopHook(hook StopHook) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.onStop = append(h.onStop, hook)
+}
+
+func (h *hooks) addRouteHook(hook RouteHook) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.onRoute = append(h.onRoute, hook)
+}
+
+func (h *hooks) started(addr net.Addr) {
+	for _, hook := range h.startHooks() {
+		hook(addr)
+	}
+}
+
+func (h *hooks) stopping() {
+	for _, hook := range h.stopHooks() {
+		hook()
+	}
+}
+
+func (h *hooks) routeRegistered(method string, path string) {
+	for _, hook := range h.routeHooks() {
+		hook(method, path)
+	}
+}
+
+// The accessors below return a copy of the hooks so that they can be
+// called without holding the lock: this allows a hook to register new
+// hooks without deadlocking.
+func (h *hooks) startHooks() []StartHook {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]StartHook(nil), h.onStart...)
+}
+
+func (h *hooks) stopHooks() []StopHook {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]StopHook(nil), h.onStop...)
+}
+
+func (h *hooks) routeHooks() []RouteHook {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]RouteHook(nil), h.onRoute...)
+}
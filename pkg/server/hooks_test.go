@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Hooks_CallsRegisteredHooksInOrder(t *testing.T) {
+	var h hooks
+	var calls []string
+
+	h.addStopHook(func() { calls = append(calls, "first") })
+	h.addStopHook(func() { calls = append(calls, "second") })
+
+	h.stopping()
+
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestUnit_Hooks_ForwardsArgumentsToHooks(t *testing.T) {
+	var h hooks
+
+	var actualAddr net.Addr
+	h.addStartHook(func(addr net.Addr) { actualAddr = addr })
+	var actualMethod, actualPath string
+	h.addRouteHook(func(method string, path string) {
+		actualMethod = method
+		actualPath = path
+	})
+
+	addr := &net.TCPAddr{Port: 1234}
+	h.started(addr)
+	h.routeRegistered("GET", "/path")
+
+	assert.Equal(t, addr, actualAddr)
+	assert.Equal(t, "GET", actualMethod)
+	assert.Equal(t, "/path", actualPath)
+}
+
+func TestUnit_Hooks_WhenHookRegistersAnotherHook_ExpectNoDeadlock(t *testing.T) {
+	var h hooks
+	var called int
+
+	h.addStopHook(func() {
+		called++
+		h.addStopHook(func() { called++ })
+	})
+
+	h.stopping()
+
+	assert.Equal(t, 1, called)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+)
+
+const logLevelPath = "/debug/log-level"
+
+type LogLevelRouteConfig struct {
+	// Level is the minimum level of the loggers of the service, typically
+	// retrieved with logger.LevelOf.
+	Level *logger.LevelVar
+	// Username and Password protect the routes with basic authentication
+	// when they are set.
+	Username string
+	Password string
+}
+
+type logLevel struct {
+	Level string `json:"level" validate:"required,oneof=trace debug info warn error"`
+}
+
+// NewLogLevelRoutes returns raw routes to read (GET) and change (POST) the
+// minimum level of the logs while the service is running. They are meant
+// to be registered on the admin server with AddAdminRoute.
+func NewLogLevelRoutes(config LogLevelRouteConfig) rest.Routes {
+	if config.Level == nil {
+		panic("log level routes require a level")
+	}
+
+	getHandler := func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, logLevel{Level: config.Level.Level().String()})
+	}
+
+	setHandler := func(c *echo.Context) error {
+		in, err := rest.BindJson[logLevel](c)
+		if err != nil {
+			return err
+		}
+
+		level, err := logger.ParseLevel(in.Level)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		previous := config.Level.Level()
+		config.Level.SetLevel(level)
+		c.Logger().Warn(
+			"Changed log level",
+			slog.String("previous", previous.String()),
+			slog.String("level", level.String()),
+		)
+
+		return c.JSON(http.StatusOK, logLevel{Level: level.String()})
+	}
+
+	if config.Username != "" || config.Password != "" {
+		auth := middleware.BasicAuth(credentialsValidator(config.Username, config.Password))
+		getHandler = auth(getHandler)
+		setHandler = auth(setHandler)
+	}
+
+	return rest.Routes{
+		rest.NewRawRoute(http.MethodGet, logLevelPath, getHandler),
+		rest.NewRawRoute(http.MethodPost, logLevelPath, setHandler),
+	}
+}
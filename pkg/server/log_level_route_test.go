@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_LogLevelRoutes_Paths(t *testing.T) {
+	routes := NewLogLevelRoutes(LogLevelRouteConfig{Level: logger.NewLevelVar(zerolog.InfoLevel)})
+
+	require.Len(t, routes, 2)
+	assert.Equal(t, http.MethodGet, routes[0].Method())
+	assert.Equal(t, "/debug/log-level", routes[0].Path())
+	assert.Equal(t, http.MethodPost, routes[1].Method())
+	assert.Equal(t, "/debug/log-level", routes[1].Path())
+}
+
+func TestUnit_LogLevelRoutes_WhenNoLevel_ExpectPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewLogLevelRoutes(LogLevelRouteConfig{})
+	})
+}
+
+func TestUnit_LogLevelRoutes_Get_ExpectCurrentLevel(t *testing.T) {
+	routes := NewLogLevelRoutes(LogLevelRouteConfig{Level: logger.NewLevelVar(zerolog.WarnLevel)})
+
+	rw, err := callLogLevelRoute(routes[0], "", nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"level":"warn"}`, rw.Body.String())
+}
+
+func TestUnit_LogLevelRoutes_Post_ExpectLevelChanged(t *testing.T) {
+	level := logger.NewLevelVar(zerolog.InfoLevel)
+	routes := NewLogLevelRoutes(LogLevelRouteConfig{Level: level})
+
+	rw, err := callLogLevelRoute(routes[1], `{"level":"debug"}`, nil)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, rw.Body.String())
+	assert.Equal(t, zerolog.DebugLevel, level.Level())
+}
+
+func TestUnit_LogLevelRoutes_Post_WhenLevelIsInvalid_ExpectValidationError(t *testing.T) {
+	level := logger.NewLevelVar(zerolog.InfoLevel)
+	routes := NewLogLevelRoutes(LogLevelRouteConfig{Level: level})
+
+	_, err := callLogLevelRoute(routes[1], `{"level":"verbose"}`, nil)
+
+	var validationErr *rest.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "level", validationErr.Fields[0].Field)
+	assert.Equal(t, zerolog.InfoLevel, level.Level())
+}
+
+func TestUnit_LogLevelRoutes_WhenCredentialsAreInvalid_ExpectUnauthorized(t *testing.T) {
+	level := logger.NewLevelVar(zerolog.InfoLevel)
+	routes := NewLogLevelRoutes(LogLevelRouteConfig{Level: level, Username: "admin", Password: "secret"})
+
+	_, err := callLogLevelRoute(routes[1], `{"level":"debug"}`, func(req *http.Request) {
+		req.SetBasicAuth("admin", "not-the-secret")
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, echo.StatusCode(err))
+	assert.Equal(t, zerolog.InfoLevel, level.Level())
+}
+
+func callLogLevelRoute(r rest.Route, body string, prepare func(*http.Request)) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(r.Method(), logLevelPath, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if prepare != nil {
+		prepare(req)
+	}
+	rw := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rw)
+
+	err := r.Handler()(ctx)
+	return rw, err
+}
@@ -1,12 +1,33 @@
 package server
 
 import (
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
 	"github.com/labstack/echo/v5"
+	em "github.com/labstack/echo/v5/middleware"
 )
 
-func buildMiddlewaresForRoute(route rest.Route) []echo.MiddlewareFunc {
+// routeConfig defines the server-wide settings applied to the routes.
+// A zero value disables the corresponding middlewares.
+type routeConfig struct {
+	requestTimeout     time.Duration
+	maxRequestBodySize int64
+	// rateLimit is shared by all the routes so that they use the same
+	// store and thus count the requests globally.
+	rateLimit        echo.MiddlewareFunc
+	apiKeyRateLimit  echo.MiddlewareFunc
+	concurrencyLimit echo.MiddlewareFunc
+	tracing          echo.MiddlewareFunc
+	bodyDump         echo.MiddlewareFunc
+	timing           middleware.TimingConfig
+	onPanic          middleware.PanicHandler
+	errorHook        logger.ErrorHook
+}
+
+func buildMiddlewaresForRoute(route rest.Route, config routeConfig) []echo.MiddlewareFunc {
 	var out []echo.MiddlewareFunc
 
 	if route.UseResponseEnvelope() {
@@ -16,9 +37,68 @@ func buildMiddlewaresForRoute(route rest.Route) []echo.MiddlewareFunc {
 	out = append(
 		out,
 		middleware.RequestTracer(),
-		middleware.ErrorConverter(),
-		middleware.Recover(),
 	)
 
+	// The tracing comes after the request tracer so that the logger has
+	// both the request and trace identifiers.
+	if config.tracing != nil {
+		out = append(out, config.tracing)
+	}
+	if config.bodyDump != nil {
+		out = append(out, config.bodyDump)
+	}
+
+	out = append(
+		out,
+		middleware.TimingWithConfig(config.timing),
+		middleware.ErrorConverterWithConfig(middleware.ErrorConverterConfig{ErrorHook: config.errorHook}),
+		middleware.RecoverWithConfig(middleware.RecoverConfig{OnPanic: config.onPanic, ErrorHook: config.errorHook}),
+	)
+
+	// The IP limit comes before the middlewares of the route so that the
+	// requests failing the authentication are also limited.
+	if config.rateLimit != nil {
+		out = append(out, config.rateLimit)
+	}
+
+	// Requests rejected by the rate limit should not use a slot.
+	if config.concurrencyLimit != nil {
+		out = append(out, config.concurrencyLimit)
+	}
+
+	out = append(out, route.Middlewares()...)
+
+	// The route is not served when none of its middlewares authenticated
+	// the request.
+	if route.Metadata().RequiresAuth {
+		out = append(out, middleware.RequireAuth())
+	}
+
+	// The API key limit needs the key to be authenticated by the
+	// middlewares of the route.
+	if config.apiKeyRateLimit != nil {
+		out = append(out, config.apiKeyRateLimit)
+	}
+
+	// The body limit comes after the envelope so that oversized requests
+	// are also reported in it.
+	if config.maxRequestBodySize > 0 {
+		out = append(out, em.BodyLimit(config.maxRequestBodySize))
+	}
+
+	// The fallback needs to wrap the timeout to be able to replace the
+	// error it generates.
+	if fallback := route.Fallback(); fallback != nil {
+		out = append(out, middleware.Fallback(fallback))
+	}
+
+	timeout := route.Timeout()
+	if timeout == 0 {
+		timeout = config.requestTimeout
+	}
+	if timeout > 0 {
+		out = append(out, middleware.Timeout(timeout))
+	}
+
 	return out
 }
@@ -2,29 +2,151 @@ package server
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
 	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestUnit_BuildMiddlewaresForRoute_ForRoute(t *testing.T) {
 	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
 
-	actual := buildMiddlewaresForRoute(r)
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
 
 	// We can't compare functions in Go so we just check the length
 	// of the middlewares slice
-	assert.Len(t, actual, 4)
+	assert.Len(t, actual, 5)
 }
 
 func TestUnit_BuildMiddlewaresForRoute_ForRawRoute(t *testing.T) {
 	r := rest.NewRawRoute(http.MethodGet, "/path", testHandler)
 
-	actual := buildMiddlewaresForRoute(r)
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	assert.Len(t, actual, 4)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenDefaultTimeoutIsSet_ExpectTimeoutMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{requestTimeout: time.Second})
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRouteDefinesTimeout_ExpectTimeoutMiddleware(t *testing.T) {
+	r := rest.WithTimeout(rest.NewRawRoute(http.MethodGet, "/path", testHandler), time.Second)
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	assert.Len(t, actual, 5)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenMaxBodySizeIsSet_ExpectBodyLimitMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodPost, "/path", testHandler)
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{maxRequestBodySize: 1024})
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRouteDefinesFallback_ExpectFallbackMiddleware(t *testing.T) {
+	r := rest.WithFallback(rest.NewRoute(http.MethodGet, "/path", testHandler), testHandler)
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRateLimitIsSet_ExpectRateLimitMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
+	config := routeConfig{
+		rateLimit: middleware.RateLimit(middleware.RateLimitConfig{}),
+	}
+
+	actual := buildMiddlewaresForRoute(r, config)
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenApiKeyRateLimitIsSet_ExpectMiddlewareAfterRouteMiddlewares(t *testing.T) {
+	routeMiddleware := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	r := rest.WithMiddlewares(rest.NewRoute(http.MethodGet, "/path", testHandler), routeMiddleware)
+	config := routeConfig{
+		rateLimit:       middleware.RateLimit(middleware.RateLimitConfig{}),
+		apiKeyRateLimit: middleware.ApiKeyRateLimit(middleware.RateLimitConfig{}),
+	}
+
+	actual := buildMiddlewaresForRoute(r, config)
 
-	assert.Len(t, actual, 3)
+	require.Len(t, actual, 8)
+	assert.Equal(t, reflect.ValueOf(routeMiddleware).Pointer(), reflect.ValueOf(actual[6]).Pointer())
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRouteRequiresAuth_ExpectRequireAuthMiddleware(t *testing.T) {
+	r := rest.WithMetadata(rest.NewRoute(http.MethodGet, "/path", testHandler), rest.RouteMetadata{RequiresAuth: true})
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenConcurrencyLimitIsSet_ExpectConcurrencyLimitMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
+	limiter := middleware.NewConcurrencyLimiter(middleware.ConcurrencyLimitConfig{Global: 1})
+	config := routeConfig{
+		concurrencyLimit: limiter.Middleware(),
+	}
+
+	actual := buildMiddlewaresForRoute(r, config)
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenTracingIsSet_ExpectTracingMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
+	config := routeConfig{
+		tracing: middleware.Otel(noop.NewTracerProvider()),
+	}
+
+	actual := buildMiddlewaresForRoute(r, config)
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenBodyDumpIsSet_ExpectBodyDumpMiddleware(t *testing.T) {
+	r := rest.NewRoute(http.MethodGet, "/path", testHandler)
+	config := routeConfig{
+		bodyDump: middleware.BodyDump(middleware.BodyDumpConfig{}),
+	}
+
+	actual := buildMiddlewaresForRoute(r, config)
+
+	assert.Len(t, actual, 6)
+}
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRouteDefinesMiddlewares_ExpectThemToBeAdded(t *testing.T) {
+	noop := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	r := rest.WithMiddlewares(rest.NewRoute(http.MethodGet, "/path", testHandler), noop, noop)
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	assert.Len(t, actual, 7)
 }
 
 var testHandler = func(c *echo.Context) error { return nil }
+
+func TestUnit_BuildMiddlewaresForRoute_WhenRouteDefinesMetadata_ExpectNoAdditionalMiddleware(t *testing.T) {
+	r := rest.WithMetadata(rest.NewRoute(http.MethodGet, "/path", testHandler), rest.RouteMetadata{Name: "get-path"})
+
+	actual := buildMiddlewaresForRoute(r, routeConfig{})
+
+	// The metadata is attached by the registry of the server.
+	assert.Len(t, actual, 5)
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+const openApiPath = "/openapi.json"
+
+// NewOpenApiRoute creates a route serving the OpenAPI specification of
+// the server. The specification is generated for each request so that
+// it includes the routes registered after this one.
+func NewOpenApiRoute(s Server) rest.Route {
+	handler := func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, s.OpenApiSpec())
+	}
+
+	return rest.NewRawRoute(http.MethodGet, openApiPath, handler)
+}
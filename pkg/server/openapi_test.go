@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Server_OpenApiSpec_ListsRegisteredRoutes(t *testing.T) {
+	config := Config{
+		BasePath: "prefix",
+		OpenApi:  rest.OpenApiInfo{Title: "my-service", Version: "v1.2.3"},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.WithDoc(rest.NewRoute(http.MethodGet, "/users/:id", testHttpHandler), rest.RouteDoc{Summary: "Get a user"})
+	err := s.AddVersionedRoute(1, route)
+	require.NoError(t, err, "Actual err: %v", err)
+	err = s.AddRoute(rest.NewRoute(http.MethodDelete, "/users/:id", testHttpHandler))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual := s.OpenApiSpec()
+
+	assert.Equal(t, config.OpenApi, actual.Info)
+	require.Contains(t, actual.Paths, "/prefix/v1/users/{id}")
+	assert.Equal(t, "Get a user", actual.Paths["/prefix/v1/users/{id}"]["get"].Summary)
+	require.Contains(t, actual.Paths, "/prefix/users/{id}")
+	assert.Contains(t, actual.Paths["/prefix/users/{id}"], "delete")
+}
+
+func TestUnit_Server_OpenApiSpec_ExpectConfiguredSecuritySchemes(t *testing.T) {
+	scheme := rest.OpenApiSecurityScheme{Type: "apiKey", In: "header", Name: "X-Api-Key"}
+	config := Config{
+		OpenApiSecuritySchemes: map[string]rest.OpenApiSecurityScheme{"apiKey": scheme},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.WithMetadata(rest.NewRoute(http.MethodGet, "/users", testHttpHandler), rest.RouteMetadata{RequiresAuth: true})
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual := s.OpenApiSpec()
+
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}}, actual.Paths["/users"]["get"].Security)
+	assert.Equal(t, map[string]rest.OpenApiSecurityScheme{"apiKey": scheme}, actual.Components.SecuritySchemes)
+}
+
+func TestUnit_OpenApiRoute_ServesSpecification(t *testing.T) {
+	s := NewWithLogger(Config{}, slog.Default())
+	r := NewOpenApiRoute(s)
+
+	err := s.AddRoute(r)
+	require.NoError(t, err, "Actual err: %v", err)
+	err = s.AddRoute(rest.NewRoute(http.MethodGet, "/after", testHttpHandler))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rw := httptest.NewRecorder()
+	err = r.Handler()(echo.New().NewContext(req, rw))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	var actual rest.OpenApiDocument
+	err = json.Unmarshal(rw.Body.Bytes(), &actual)
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Contains(t, actual.Paths, "/openapi.json")
+	assert.Contains(t, actual.Paths, "/after")
+}
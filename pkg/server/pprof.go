@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+// The pprof handlers expect to be served under this exact prefix so the
+// routes are not prefixed with the base path of the server.
+const pprofBasePath = "/debug/pprof"
+
+func pprofRoutes() rest.Routes {
+	return rest.Routes{
+		// The index handler serves both the list of profiles (which needs
+		// a trailing slash for its links to work) and the named profiles.
+		rest.NewRawRoute(http.MethodGet, pprofBasePath+"/*", echo.WrapHandler(http.HandlerFunc(pprof.Index))),
+		rest.NewRawRoute(http.MethodGet, pprofBasePath+"/cmdline", echo.WrapHandler(http.HandlerFunc(pprof.Cmdline))),
+		rest.NewRawRoute(http.MethodGet, pprofBasePath+"/profile", echo.WrapHandler(http.HandlerFunc(pprof.Profile))),
+		rest.NewRawRoute(http.MethodGet, pprofBasePath+"/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol))),
+		rest.NewRawRoute(http.MethodPost, pprofBasePath+"/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol))),
+		rest.NewRawRoute(http.MethodGet, pprofBasePath+"/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace))),
+	}
+}
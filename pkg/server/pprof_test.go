@@ -0,0 +1,80 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_PprofRoutes_AreRawRoutes(t *testing.T) {
+	for _, route := range pprofRoutes() {
+		assert.False(t, route.UseResponseEnvelope(), "Route %s should be raw", route.Path())
+	}
+}
+
+func TestUnit_Server_WhenPprofEnabled_ExpectPprofRoutesServed(t *testing.T) {
+	config := Config{
+		BasePath:        "/prefix",
+		Port:            4019,
+		ShutdownTimeout: 2 * time.Second,
+		EnablePprof:     true,
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	indexResponse := doRequest(t, http.MethodGet, "http://localhost:4019/debug/pprof/")
+	cmdlineResponse := doRequest(t, http.MethodGet, "http://localhost:4019/debug/pprof/cmdline")
+	heapResponse := doRequest(t, http.MethodGet, "http://localhost:4019/debug/pprof/heap")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, indexResponse.StatusCode)
+	assert.Equal(t, http.StatusOK, cmdlineResponse.StatusCode)
+	assert.Equal(t, http.StatusOK, heapResponse.StatusCode)
+}
+
+func TestUnit_Server_WhenPprofEnabledWithAdminServer_ExpectPprofRoutesOnAdminPort(t *testing.T) {
+	config := Config{
+		Port:            4020,
+		ShutdownTimeout: 2 * time.Second,
+		Admin: AdminConfig{
+			Enabled: true,
+			Port:    4021,
+		},
+		EnablePprof: true,
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	adminResponse := doRequest(t, http.MethodGet, "http://localhost:4021/debug/pprof/")
+	publicResponse := doRequest(t, http.MethodGet, "http://localhost:4020/debug/pprof/")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, adminResponse.StatusCode)
+	assert.Equal(t, http.StatusNotFound, publicResponse.StatusCode)
+}
+
+func TestUnit_Server_WhenPprofDisabled_ExpectPprofRoutesNotServed(t *testing.T) {
+	s := newTestServer(4022)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4022/debug/pprof/")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
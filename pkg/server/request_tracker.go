@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+type requestTracker struct {
+	lock   sync.Mutex
+	nextId uint64
+	active map[uint64]context.CancelFunc
+	idle   chan struct{}
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{
+		active: make(map[uint64]context.CancelFunc),
+	}
+}
+
+func (rt *requestTracker) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			ctx, cancel := context.WithCancel(c.Request().Context())
+			defer cancel()
+
+			id := rt.register(cancel)
+			defer rt.unregister(id)
+
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+func (rt *requestTracker) activeRequests() int {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	return len(rt.active)
+}
+
+// drain waits for the in-flight requests to complete for at most the
+// provided timeout. The requests still running after that are aborted
+// by cancelling their context. The number of aborted requests is then
+// returned.
+func (rt *requestTracker) drain(timeout time.Duration) int {
+	rt.lock.Lock()
+	if len(rt.active) == 0 {
+		rt.lock.Unlock()
+		return 0
+	}
+
+	idle := make(chan struct{})
+	rt.idle = idle
+	rt.lock.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-time.After(timeout):
+	}
+
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	aborted := len(rt.active)
+	for _, cancel := range rt.active {
+		cancel()
+	}
+
+	return aborted
+}
+
+func (rt *requestTracker) register(cancel context.CancelFunc) uint64 {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	id := rt.nextId
+	rt.nextId++
+	rt.active[id] = cancel
+
+	return id
+}
+
+func (rt *requestTracker) unregister(id uint64) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	delete(rt.active, id)
+
+	if len(rt.active) == 0 && rt.idle != nil {
+		close(rt.idle)
+		rt.idle = nil
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_RequestTracker_TracksInFlightRequests(t *testing.T) {
+	rt := newRequestTracker()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := func(c *echo.Context) error {
+		close(started)
+		<-release
+		return nil
+	}
+
+	done := runTrackedHandler(rt, handler)
+	<-started
+
+	assert.Equal(t, 1, rt.activeRequests())
+
+	close(release)
+	<-done
+
+	assert.Equal(t, 0, rt.activeRequests())
+}
+
+func TestUnit_RequestTracker_Drain_WhenNoActiveRequest_ExpectNothingAborted(t *testing.T) {
+	rt := newRequestTracker()
+
+	actual := rt.drain(time.Second)
+
+	assert.Equal(t, 0, actual)
+}
+
+func TestUnit_RequestTracker_Drain_WhenRequestCompletesInTime_ExpectNothingAborted(t *testing.T) {
+	rt := newRequestTracker()
+
+	started := make(chan struct{})
+	handler := func(c *echo.Context) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+
+	done := runTrackedHandler(rt, handler)
+	<-started
+
+	actual := rt.drain(time.Second)
+	<-done
+
+	assert.Equal(t, 0, actual)
+}
+
+func TestUnit_RequestTracker_Drain_WhenRequestTakesTooLong_ExpectContextCancelled(t *testing.T) {
+	rt := newRequestTracker()
+
+	started := make(chan struct{})
+	var ctxErr error
+	handler := func(c *echo.Context) error {
+		close(started)
+		<-c.Request().Context().Done()
+		ctxErr = c.Request().Context().Err()
+		return nil
+	}
+
+	done := runTrackedHandler(rt, handler)
+	<-started
+
+	actual := rt.drain(50 * time.Millisecond)
+	<-done
+
+	assert.Equal(t, 1, actual)
+	require.Error(t, ctxErr)
+	assert.Equal(t, 0, rt.activeRequests())
+}
+
+func runTrackedHandler(rt *requestTracker, handler echo.HandlerFunc) <-chan struct{} {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+	callable := rt.middleware()(handler)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Voluntarily ignoring errors: the handlers used in tests don't
+		// return any.
+		_ = callable(ctx)
+	}()
+
+	return done
+}
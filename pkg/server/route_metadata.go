@@ -0,0 +1,52 @@
+package server
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/labstack/echo/v5"
+)
+
+// routeMetadataRegistry resolves the metadata of the route serving a
+// request. Its middleware is the first one of the echo server: as echo
+// routes the request before running the global middlewares, all of them
+// can then access the metadata.
+type routeMetadataRegistry struct {
+	lock     sync.RWMutex
+	metadata map[string]rest.RouteMetadata
+}
+
+func newRouteMetadataRegistry() *routeMetadataRegistry {
+	return &routeMetadataRegistry{
+		metadata: make(map[string]rest.RouteMetadata),
+	}
+}
+
+func (r *routeMetadataRegistry) register(method string, path string, metadata rest.RouteMetadata) {
+	if reflect.ValueOf(metadata).IsZero() {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.metadata[method+" "+path] = metadata
+}
+
+func (r *routeMetadataRegistry) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			route := c.RouteInfo()
+
+			r.lock.RLock()
+			metadata, ok := r.metadata[route.Method+" "+route.Path]
+			r.lock.RUnlock()
+
+			if ok {
+				rest.SetRouteMetadata(c, metadata)
+			}
+
+			return next(c)
+		}
+	}
+}
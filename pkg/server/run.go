@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+)
+
+// RunWithSignalHandler creates a server with the provided routes and runs
+// it until the context is cancelled or an interruption signal is received.
+func RunWithSignalHandler(
+	ctx context.Context,
+	config Config,
+	log *slog.Logger,
+	routes ...rest.Route,
+) error {
+	s := NewWithLogger(config, log)
+
+	for _, route := range routes {
+		if err := s.AddRoute(route); err != nil {
+			return err
+		}
+	}
+
+	wait, err := process.StartWithSignalHandler(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	return wait()
+}
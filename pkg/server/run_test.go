@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_RunWithSignalHandler_WhenRouteIsInvalid_ExpectError(t *testing.T) {
+	config := Config{Port: 4010}
+	route := rest.NewRoute(http.MethodHead, "/", testHttpHandler)
+
+	err := RunWithSignalHandler(context.Background(), config, slog.Default(), route)
+
+	assert.Equal(t, ErrUnsupportedMethod, err, "Actual err: %v", err)
+}
+
+func TestUnit_RunWithSignalHandler_ServesRoutesUntilContextIsCancelled(t *testing.T) {
+	config := Config{
+		Port:            4011,
+		ShutdownTimeout: 2 * time.Second,
+	}
+	route := rest.NewRoute(http.MethodGet, "/", testHttpHandler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- RunWithSignalHandler(ctx, config, slog.Default(), route)
+	}()
+
+	const reasonableTimeForServerToBeUp = 50 * time.Millisecond
+	time.Sleep(reasonableTimeForServerToBeUp)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4011")
+
+	cancel()
+	err := <-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, response)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	om "github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
@@ -15,56 +19,245 @@ import (
 
 type Server interface {
 	AddRoute(route rest.Route) error
-	Start() error
-	Stop() error
+	// AddVersionedRoute registers a route under the path of the version
+	// of the API, e.g. /v2/route. The version should be strictly positive.
+	AddVersionedRoute(version int, route rest.Route) error
+	// AddAdminRoute registers a route on the admin server. This fails in
+	// case the admin server is not enabled in the configuration.
+	AddAdminRoute(route rest.Route) error
+	// OpenApiSpec generates the OpenAPI specification of the routes of
+	// the main server. See also NewOpenApiRoute.
+	OpenApiSpec() *rest.OpenApiDocument
+	// Routes lists the routes registered on the main server along with
+	// their metadata.
+	Routes() []RouteInfo
+	// Port returns the port the server is listening on. When the server
+	// is configured with port 0 the actual port is only known once the
+	// listener is bound: before that this returns the configured port.
+	Port() uint16
+
+	// OnStart registers a hook called once the listener is bound.
+	OnStart(hook StartHook)
+	// OnStop registers a hook called when the server starts shutting down.
+	OnStop(hook StopHook)
+	// OnRouteRegistered registers a hook called for each route added to
+	// the server after the hook was registered.
+	OnRouteRegistered(hook RouteHook)
+
+	process.Runnable
 }
 
 type serverImpl struct {
 	echo            *echo.Echo
 	basePath        string
 	port            uint16
+	boundPort       atomic.Uint32
 	shutdownTimeout time.Duration
+	drainTimeout    time.Duration
+	routeConfig     routeConfig
+	deprecations    map[int]om.DeprecationConfig
+	openApiInfo     rest.OpenApiInfo
+	securitySchemes map[string]rest.OpenApiSecurityScheme
+	routesLock      sync.Mutex
+	routes          []registeredRoute
+	metadata        *routeMetadataRegistry
+	tracker         *requestTracker
 	router          *echo.Group
+	admin           *adminServer
+	hooks           hooks
 	stopChan        chan struct{}
 }
 
+type registeredRoute struct {
+	path  string
+	route rest.Route
+}
+
+type RouteInfo struct {
+	Method   string
+	Path     string
+	Metadata rest.RouteMetadata
+}
+
 func NewWithLogger(config Config, log *slog.Logger) Server {
-	echoServer := createEchoServer(log)
+	metadata := newRouteMetadataRegistry()
+	echoServer := createEchoServer(config, log, metadata)
 
 	s := &serverImpl{
 		echo:            echoServer,
 		basePath:        config.BasePath,
 		port:            config.Port,
 		shutdownTimeout: config.ShutdownTimeout,
+		drainTimeout:    config.DrainTimeout,
+		routeConfig: routeConfig{
+			requestTimeout:     config.RequestTimeout,
+			maxRequestBodySize: config.MaxRequestBodySize,
+			onPanic:            config.OnPanic,
+			errorHook:          config.ErrorHook,
+			timing:             config.Timing,
+		},
+		deprecations:    config.DeprecatedVersions,
+		openApiInfo:     config.OpenApi,
+		securitySchemes: config.OpenApiSecuritySchemes,
+		metadata:        metadata,
+		tracker:         newRequestTracker(),
 		router:          echoServer.Group(""),
 		stopChan:        make(chan struct{}, 1),
 	}
 
+	echoServer.Use(s.tracker.middleware())
+
+	if config.SecureHeaders.Enabled {
+		echoServer.Use(om.SecureHeaders(config.SecureHeaders))
+	}
+
+	if config.Compression.Enabled {
+		echoServer.Use(om.Compress(config.Compression))
+	}
+
+	if config.Locale.Enabled() {
+		echoServer.Use(om.Locale(config.Locale))
+	}
+
+	if config.RateLimit.Enabled() {
+		// Both limits use the same store so that it is only created once.
+		if config.RateLimit.Store == nil {
+			config.RateLimit.Store = om.NewInMemoryRateLimitStore()
+		}
+		s.routeConfig.rateLimit = om.RateLimit(config.RateLimit)
+		s.routeConfig.apiKeyRateLimit = om.ApiKeyRateLimit(config.RateLimit)
+	}
+
+	if config.ConcurrencyLimiter != nil {
+		s.routeConfig.concurrencyLimit = config.ConcurrencyLimiter.Middleware()
+	}
+
+	if config.TracerProvider != nil {
+		s.routeConfig.tracing = om.Otel(config.TracerProvider)
+	}
+
+	if config.BodyDump.Enabled {
+		s.routeConfig.bodyDump = om.BodyDump(config.BodyDump)
+	}
+
+	if config.Admin.Enabled {
+		s.admin = newAdminServer(config.Admin, config.ShutdownTimeout, log)
+	}
+
+	if config.EnablePprof {
+		s.registerPprofRoutes()
+	}
+
 	return s
 }
 
 func (s *serverImpl) AddRoute(route rest.Route) error {
-	path := rest.ConcatenateEndpoints(s.basePath, route.Path())
-	middlewares := buildMiddlewaresForRoute(route)
+	return s.addRoute(route.Path(), route)
+}
 
-	switch route.Method() {
-	case http.MethodGet:
-		s.router.GET(path, route.Handler(), middlewares...)
-	case http.MethodPost:
-		s.router.POST(path, route.Handler(), middlewares...)
-	case http.MethodDelete:
-		s.router.DELETE(path, route.Handler(), middlewares...)
-	case http.MethodPatch:
-		s.router.PATCH(path, route.Handler(), middlewares...)
-	default:
-		return ErrUnsupportedMethod
+func (s *serverImpl) addRoute(routePath string, route rest.Route) error {
+	path := rest.ConcatenateEndpoints(s.basePath, routePath)
+	middlewares := buildMiddlewaresForRoute(route, s.routeConfig)
+
+	if err := registerRoute(s.router, path, route, middlewares); err != nil {
+		return err
 	}
 
+	s.metadata.register(route.Method(), path, route.Metadata())
+
+	s.routesLock.Lock()
+	s.routes = append(s.routes, registeredRoute{path: path, route: route})
+	s.routesLock.Unlock()
+
 	s.echo.Logger.Debug("Registered route", slog.String("method", route.Method()), slog.String("path", path))
+	s.hooks.routeRegistered(route.Method(), path)
 
 	return nil
 }
 
+func (s *serverImpl) AddVersionedRoute(version int, route rest.Route) error {
+	if version < 1 {
+		return ErrInvalidApiVersion
+	}
+
+	if deprecation, ok := s.deprecations[version]; ok {
+		route = rest.WithMiddlewares(route, om.Deprecation(deprecation))
+	}
+
+	versionPath := rest.ConcatenateEndpoints(fmt.Sprintf("v%d", version), route.Path())
+	return s.addRoute(versionPath, route)
+}
+
+func (s *serverImpl) AddAdminRoute(route rest.Route) error {
+	if s.admin == nil {
+		return ErrAdminServerDisabled
+	}
+
+	return s.admin.addRoute(route)
+}
+
+func (s *serverImpl) OpenApiSpec() *rest.OpenApiDocument {
+	s.routesLock.Lock()
+	defer s.routesLock.Unlock()
+
+	doc := rest.NewOpenApiDocument(s.openApiInfo)
+	for name, scheme := range s.securitySchemes {
+		doc.AddSecurityScheme(name, scheme)
+	}
+	for _, r := range s.routes {
+		doc.AddRoute(r.path, r.route)
+	}
+
+	return doc
+}
+
+func (s *serverImpl) Routes() []RouteInfo {
+	s.routesLock.Lock()
+	defer s.routesLock.Unlock()
+
+	out := make([]RouteInfo, 0, len(s.routes))
+	for _, r := range s.routes {
+		out = append(out, RouteInfo{
+			Method:   r.route.Method(),
+			Path:     r.path,
+			Metadata: r.route.Metadata(),
+		})
+	}
+
+	return out
+}
+
+func (s *serverImpl) registerPprofRoutes() {
+	for _, route := range pprofRoutes() {
+		// The pprof routes only use supported methods so no error can
+		// occur when registering them.
+		if s.admin != nil {
+			_ = s.admin.addRoute(route)
+		} else {
+			_ = registerRoute(s.router, route.Path(), route, buildMiddlewaresForRoute(route, routeConfig{}))
+		}
+	}
+}
+
+func (s *serverImpl) Port() uint16 {
+	if port := s.boundPort.Load(); port != 0 {
+		return uint16(port)
+	}
+	return s.port
+}
+
+func (s *serverImpl) OnStart(hook StartHook) {
+	s.hooks.addStartHook(hook)
+}
+
+func (s *serverImpl) OnStop(hook StopHook) {
+	s.hooks.addStopHook(hook)
+}
+
+func (s *serverImpl) OnRouteRegistered(hook RouteHook) {
+	s.hooks.addRouteHook(hook)
+}
+
 func (s *serverImpl) Start() error {
 	// https://echo.labstack.com/docs/cookbook/graceful-shutdown
 	// This approach deviates a bit from what is recommended in the
@@ -77,24 +270,57 @@ func (s *serverImpl) Start() error {
 	s.echo.Logger.Info("Starting server", slog.String("address", address))
 
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	aborted := make(chan int, 1)
 	go func() {
-		<-s.stopChan
-		cancel()
+		select {
+		case <-s.stopChan:
+			s.hooks.stopping()
+			// Cancelling the context stops accepting new connections: the
+			// drain phase then gives the in-flight requests some time to
+			// complete before aborting them.
+			cancel()
+			aborted <- s.drain()
+		case <-ctx.Done():
+			// One of the listeners failed: nothing to drain.
+			aborted <- 0
+		}
 	}()
 
+	adminDone := s.startAdmin(ctx, cancel)
+
 	sc := echo.StartConfig{
 		Address:         address,
 		HideBanner:      true,
 		HidePort:        true,
 		GracefulTimeout: s.shutdownTimeout,
+		ListenerAddrFunc: func(addr net.Addr) {
+			if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+				s.boundPort.Store(uint32(tcpAddr.Port))
+				s.echo.Logger.Info("Server listening", slog.String("address", addr.String()))
+			}
+			s.hooks.started(addr)
+		},
 	}
 
-	if err := sc.Start(ctx, s.echo); err != nil {
+	err := sc.Start(ctx, s.echo)
+	// The main listener stopping also stops the admin one.
+	cancel()
+	if adminErr := <-adminDone; err == nil {
+		err = adminErr
+	}
+
+	if err != nil {
 		s.echo.Logger.Error("Server failed", slog.String("address", address), slog.Any("error", err))
 		return err
 	}
 
-	s.echo.Logger.Info("Server gracefully shutdown", slog.String("address", address))
+	s.echo.Logger.Info(
+		"Server gracefully shutdown",
+		slog.String("address", address),
+		slog.Int("aborted", <-aborted),
+	)
 
 	return nil
 }
@@ -104,16 +330,76 @@ func (s *serverImpl) Stop() error {
 	return nil
 }
 
-func createEchoServer(log *slog.Logger) *echo.Echo {
+func (s *serverImpl) startAdmin(ctx context.Context, cancel context.CancelFunc) <-chan error {
+	done := make(chan error, 1)
+
+	if s.admin == nil {
+		done <- nil
+		return done
+	}
+
+	go func() {
+		err := s.admin.start(ctx)
+		if err != nil {
+			// The admin server failing also stops the main one.
+			cancel()
+		}
+		done <- err
+	}()
+
+	return done
+}
+
+func (s *serverImpl) drain() int {
+	if s.drainTimeout == 0 {
+		return 0
+	}
+
+	active := s.tracker.activeRequests()
+	s.echo.Logger.Info(
+		"Draining in-flight requests",
+		slog.Int("active", active),
+		slog.Duration("timeout", s.drainTimeout),
+	)
+
+	return s.tracker.drain(s.drainTimeout)
+}
+
+func registerRoute(
+	router *echo.Group,
+	path string,
+	route rest.Route,
+	middlewares []echo.MiddlewareFunc,
+) error {
+	switch route.Method() {
+	case http.MethodGet:
+		router.GET(path, route.Handler(), middlewares...)
+	case http.MethodPost:
+		router.POST(path, route.Handler(), middlewares...)
+	case http.MethodDelete:
+		router.DELETE(path, route.Handler(), middlewares...)
+	case http.MethodPatch:
+		router.PATCH(path, route.Handler(), middlewares...)
+	default:
+		return ErrUnsupportedMethod
+	}
+
+	return nil
+}
+
+func createEchoServer(config Config, log *slog.Logger, metadata *routeMetadataRegistry) *echo.Echo {
 	e := echo.New()
 	e.Logger = log
+	e.IPExtractor = createIPExtractor(config.TrustedProxies, log)
+
+	e.Use(metadata.middleware())
 
-	registerBaseMiddlewares(e)
+	registerBaseMiddlewares(e, config.RequestLogger)
 
 	return e
 }
 
-func registerBaseMiddlewares(e *echo.Echo) {
+func registerBaseMiddlewares(e *echo.Echo, loggerConfig om.RequestLoggerConfig) {
 	// https://stackoverflow.com/questions/74020538/cors-preflight-did-not-succeed
 	// https://stackoverflow.com/questions/6660019/restful-api-methods-head-options
 	corsConf := middleware.CORSConfig{
@@ -130,5 +416,5 @@ func registerBaseMiddlewares(e *echo.Echo) {
 	}
 
 	e.Use(middleware.CORSWithConfig(corsConf))
-	e.Use(om.RequestLogger())
+	e.Use(om.RequestLoggerWithConfig(loggerConfig))
 }
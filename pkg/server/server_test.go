@@ -1,15 +1,21 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/db"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/logger"
+	"github.com/Knoblauchpilze/backend-toolkit/pkg/middleware"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/process"
 	"github.com/Knoblauchpilze/backend-toolkit/pkg/rest"
 	"github.com/labstack/echo/v5"
@@ -111,7 +117,7 @@ func TestUnit_Server_WhenHandlerPanics_ExpectErrorResponseEnvelope(t *testing.T)
 	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
 	actual := unmarshalResponseAndAssertRequestId(t, response)
 	assert.Equal(t, "ERROR", actual.Status)
-	assert.Equal(t, `{"message":"this handler panics"}`, string(actual.Details))
+	assert.Equal(t, `{"message":"an unexpected error occurred"}`, string(actual.Details))
 }
 
 func TestUnit_Server_WhenHandlerReturnsError_ExpectErrorResponseEnvelope(t *testing.T) {
@@ -134,7 +140,437 @@ func TestUnit_Server_WhenHandlerReturnsError_ExpectErrorResponseEnvelope(t *test
 	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
 	actual := unmarshalResponseAndAssertRequestId(t, response)
 	assert.Equal(t, "ERROR", actual.Status)
-	assert.Equal(t, `{"message":"an unexpected error occurred. Code: 102"}`, string(actual.Details))
+	assert.Equal(t, `{"message":"an unexpected error occurred"}`, string(actual.Details))
+}
+
+func TestUnit_Server_Port_WhenNotStarted_ExpectConfiguredPort(t *testing.T) {
+	s := newTestServer(4007)
+
+	assert.Equal(t, uint16(4007), s.Port())
+}
+
+func TestUnit_Server_Port_WhenConfiguredWithPortZero_ExpectBoundPort(t *testing.T) {
+	s := newTestServerWithOkHandler(t, 0)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	port := s.Port()
+	require.NotEqual(t, uint16(0), port)
+	response := doRequest(t, http.MethodGet, fmt.Sprintf("http://localhost:%d", port))
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, response)
+}
+
+func TestUnit_Server_WhenStoppedWithRequestInFlight_ExpectRequestCancelledAfterDrainTimeout(t *testing.T) {
+	config := Config{
+		Port:            4008,
+		ShutdownTimeout: 2 * time.Second,
+		DrainTimeout:    50 * time.Millisecond,
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	slowHandler := func(c *echo.Context) error {
+		close(started)
+		<-c.Request().Context().Done()
+		close(cancelled)
+		return c.NoContent(http.StatusServiceUnavailable)
+	}
+	route := rest.NewRawRoute(http.MethodGet, "/", slowHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	responses := make(chan *http.Response, 1)
+	go func() {
+		// Not using doRequest as it is not safe to call require from a
+		// goroutine other than the one running the test. In case of error
+		// the response is nil which is checked below.
+		response, _ := http.Get("http://localhost:4008")
+		responses <- response
+	}()
+	<-started
+
+	err = s.Stop()
+	require.NoError(t, err, "Actual err: %v", err)
+
+	<-cancelled
+	<-done
+
+	response := <-responses
+	require.NotNil(t, response)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+}
+
+func TestUnit_Server_CallsLifecycleHooks(t *testing.T) {
+	s := newTestServer(4009)
+
+	var routes []string
+	s.OnRouteRegistered(func(method string, path string) {
+		routes = append(routes, method+" "+path)
+	})
+	started := make(chan net.Addr, 1)
+	s.OnStart(func(addr net.Addr) {
+		started <- addr
+	})
+	stopping := make(chan struct{}, 1)
+	s.OnStop(func() {
+		stopping <- struct{}{}
+	})
+
+	route := rest.NewRoute(http.MethodGet, "/route", testHttpHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	addr, ok := (<-started).(*net.TCPAddr)
+	require.True(t, ok)
+	assert.Equal(t, 4009, addr.Port)
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Len(t, stopping, 1)
+	assert.Equal(t, []string{"GET /route"}, routes)
+}
+
+func TestUnit_Server_WhenHandlerExceedsRequestTimeout_ExpectGatewayTimeoutEnvelope(t *testing.T) {
+	config := Config{
+		Port:            4023,
+		ShutdownTimeout: 2 * time.Second,
+		RequestTimeout:  50 * time.Millisecond,
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.NewRoute(http.MethodGet, "/", slowHttpHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4023")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusGatewayTimeout, response.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, response)
+	assert.Equal(t, "ERROR", actual.Status)
+	assert.Equal(t, `{"message":"request timeout"}`, string(actual.Details))
+}
+
+func TestUnit_Server_WhenRouteDefinesTimeout_ExpectItOverridesServerTimeout(t *testing.T) {
+	s := newTestServer(4024)
+
+	route := rest.WithTimeout(rest.NewRoute(http.MethodGet, "/", slowHttpHandler), 50*time.Millisecond)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4024")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusGatewayTimeout, response.StatusCode)
+}
+
+func TestUnit_Server_WhenBodyExceedsMaxSize_ExpectRequestEntityTooLargeEnvelope(t *testing.T) {
+	config := Config{
+		Port:               4025,
+		ShutdownTimeout:    2 * time.Second,
+		MaxRequestBodySize: 16,
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	handler := func(c *echo.Context) error {
+		_, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+	route := rest.NewRoute(http.MethodPost, "/", handler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	body := strings.NewReader(strings.Repeat("a", 32))
+	response, err := http.Post("http://localhost:4025", "text/plain", body)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, response)
+	assert.Equal(t, "ERROR", actual.Status)
+}
+
+func TestUnit_Server_WhenRouteTimesOutWithFallback_ExpectDegradedEnvelope(t *testing.T) {
+	s := newTestServer(4026)
+
+	fallback := func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, "cached")
+	}
+	route := rest.NewRoute(http.MethodGet, "/", slowHttpHandler)
+	route = rest.WithFallback(rest.WithTimeout(route, 50*time.Millisecond), fallback)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4026")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, response)
+	assert.Equal(t, "DEGRADED", actual.Status)
+	assert.Equal(t, `"cached"`, string(actual.Details))
+}
+
+func TestUnit_Server_WhenRateLimitExceeded_ExpectTooManyRequestsEnvelope(t *testing.T) {
+	config := Config{
+		Port:            4027,
+		ShutdownTimeout: 2 * time.Second,
+		RateLimit: middleware.RateLimitConfig{
+			PerIP: middleware.RateLimitRule{Rate: 0.1, Burst: 1},
+		},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.NewRoute(http.MethodGet, "/", testHttpHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	first := doRequest(t, http.MethodGet, "http://localhost:4027")
+	second := doRequest(t, http.MethodGet, "http://localhost:4027")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, first)
+	assert.Equal(t, http.StatusTooManyRequests, second.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, second)
+	assert.Equal(t, "ERROR", actual.Status)
+}
+
+func TestUnit_Server_WhenApiKeysAreInvalid_ExpectTooManyRequests(t *testing.T) {
+	config := Config{
+		Port:            4040,
+		ShutdownTimeout: 2 * time.Second,
+		RateLimit: middleware.RateLimitConfig{
+			PerIP:     middleware.RateLimitRule{Rate: 0.1, Burst: 2},
+			PerApiKey: middleware.RateLimitRule{Rate: 10, Burst: 20},
+		},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	lookup := func(ctx context.Context, key string) (middleware.Principal, error) {
+		return middleware.Principal{}, middleware.ErrInvalidApiKey
+	}
+	route := rest.WithMiddlewares(rest.NewRoute(http.MethodGet, "/", testHttpHandler), middleware.ApiKeyAuth(lookup))
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	var statuses []int
+	for i := range 3 {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:4040", nil)
+		require.NoError(t, err, "Actual err: %v", err)
+		req.Header.Set("X-Api-Key", fmt.Sprintf("guess-%d", i))
+		response, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Actual err: %v", err)
+		statuses = append(statuses, response.StatusCode)
+	}
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	expected := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	assert.Equal(t, expected, statuses)
+}
+
+func TestUnit_Server_WhenRouteRequiresAuthentication_ExpectOnlyThisRouteProtected(t *testing.T) {
+	s := newTestServer(4028)
+
+	auth := middleware.JwtAuth(middleware.JwtConfig{HmacSecret: []byte("secret")})
+	private := rest.WithMiddlewares(rest.NewRoute(http.MethodGet, "/private", testHttpHandler), auth)
+	public := rest.NewRoute(http.MethodGet, "/public", testHttpHandler)
+	for _, route := range []rest.Route{private, public} {
+		err := s.AddRoute(route)
+		require.NoError(t, err, "Actual err: %v", err)
+	}
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	privateResponse := doRequest(t, http.MethodGet, "http://localhost:4028/private")
+	publicResponse := doRequest(t, http.MethodGet, "http://localhost:4028/public")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusUnauthorized, privateResponse.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, privateResponse)
+	assert.Equal(t, "ERROR", actual.Status)
+	assertIsOkResponse(t, publicResponse)
+}
+
+func TestUnit_Server_WhenSecureHeadersEnabled_ExpectHeadersInResponse(t *testing.T) {
+	config := Config{
+		Port:            4029,
+		ShutdownTimeout: 2 * time.Second,
+		SecureHeaders: middleware.SecureHeadersConfig{
+			Enabled:               true,
+			ContentSecurityPolicy: "default-src 'none'",
+		},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.NewRoute(http.MethodGet, "/", testHttpHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4029")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, "nosniff", response.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", response.Header.Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", response.Header.Get("Content-Security-Policy"))
+	assertIsOkResponse(t, response)
+}
+
+func TestUnit_Server_AddVersionedRoute_WhenVersionIsInvalid_ExpectFailure(t *testing.T) {
+	s := newTestServer(4030)
+
+	route := rest.NewRoute(http.MethodGet, "/route", testHttpHandler)
+	err := s.AddVersionedRoute(0, route)
+
+	assert.Equal(t, ErrInvalidApiVersion, err, "Actual err: %v", err)
+}
+
+func TestUnit_Server_WhenRoutesAreVersioned_ExpectDeprecationHeadersForDeprecatedVersions(t *testing.T) {
+	config := Config{
+		BasePath:        "prefix",
+		Port:            4031,
+		ShutdownTimeout: 2 * time.Second,
+		DeprecatedVersions: map[int]middleware.DeprecationConfig{
+			1: {Sunset: time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)},
+		},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	for _, version := range []int{1, 2} {
+		route := rest.NewRoute(http.MethodGet, "/route", testHttpHandler)
+		err := s.AddVersionedRoute(version, route)
+		require.NoError(t, err, "Actual err: %v", err)
+	}
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	v1 := doRequest(t, http.MethodGet, "http://localhost:4031/prefix/v1/route")
+	v2 := doRequest(t, http.MethodGet, "http://localhost:4031/prefix/v2/route")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, v1)
+	assert.Equal(t, "true", v1.Header.Get("Deprecation"))
+	assert.Equal(t, "Tue, 30 Jun 2026 12:00:00 GMT", v1.Header.Get("Sunset"))
+	assertIsOkResponse(t, v2)
+	assert.Empty(t, v2.Header.Get("Deprecation"))
+}
+
+func TestUnit_Server_WhenBodyFailsValidation_ExpectBadRequestEnvelopeWithFields(t *testing.T) {
+	type body struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	s := newTestServer(4032)
+	route := rest.WithMiddlewares(
+		rest.NewRoute(http.MethodPost, "/", testHttpHandler),
+		middleware.ValidateBody[body](),
+	)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:4032", strings.NewReader(`{}`))
+	require.NoError(t, err, "Actual err: %v", err)
+	req.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	envelope := unmarshalResponseAndAssertRequestId(t, response)
+	assert.Equal(t, "ERROR", envelope.Status)
+	expectedJson := `
+	{
+		"message": "request validation failed",
+		"fields": [
+			{"field": "name", "rule": "required", "message": "failed on the 'required' rule"}
+		]
+	}`
+	assert.JSONEq(t, expectedJson, string(envelope.Details))
+}
+
+func TestUnit_Server_WhenCompressionEnabled_ExpectCompressedEnvelope(t *testing.T) {
+	config := Config{
+		Port:            4033,
+		ShutdownTimeout: 2 * time.Second,
+		Compression:     middleware.CompressionConfig{Enabled: true, MinSize: 1},
+	}
+	s := NewWithLogger(config, slog.Default())
+
+	route := rest.NewRoute(http.MethodGet, "/", testHttpHandler)
+	err := s.AddRoute(route)
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	// Letting the transport negotiate the compression so that the body
+	// is transparently decompressed.
+	response := doRequest(t, http.MethodGet, "http://localhost:4033")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.True(t, response.Uncompressed)
+	assertIsOkResponse(t, response)
 }
 
 type responseEnvelope struct {
@@ -143,6 +579,130 @@ type responseEnvelope struct {
 	Details   json.RawMessage `json:"details"`
 }
 
+func TestUnit_Server_Routes_ListsRegisteredRoutesWithMetadata(t *testing.T) {
+	s := newTestServerWithPath(4038, "/prefix")
+	metadata := rest.RouteMetadata{Name: "list-samples", RequiresAuth: true}
+	err := s.AddRoute(rest.WithMetadata(rest.NewRoute(http.MethodGet, "/samples", testHttpHandler), metadata))
+	require.NoError(t, err, "Actual err: %v", err)
+	err = s.AddVersionedRoute(2, rest.NewRoute(http.MethodPost, "/samples", testHttpHandler))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	actual := s.Routes()
+
+	expected := []RouteInfo{
+		{Method: http.MethodGet, Path: "/prefix/samples", Metadata: metadata},
+		{Method: http.MethodPost, Path: "/prefix/v2/samples"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnit_Server_WhenRouteDefinesMetadata_ExpectAvailableToHandler(t *testing.T) {
+	s := newTestServer(4038)
+	var actual rest.RouteMetadata
+	handler := func(c *echo.Context) error {
+		actual, _ = rest.GetRouteMetadata(c)
+		return c.NoContent(http.StatusOK)
+	}
+	metadata := rest.RouteMetadata{Name: "get-root", Tags: []string{"root"}}
+	err := s.AddRoute(rest.WithMetadata(rest.NewRawRoute(http.MethodGet, "/", handler), metadata))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4038")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, metadata, actual)
+}
+
+func TestUnit_Server_WhenRouteDefinesMetadata_ExpectAvailableToGlobalMiddlewares(t *testing.T) {
+	s := newTestServer(4041)
+	var actual rest.RouteMetadata
+	var found bool
+	s.(*serverImpl).echo.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			actual, found = rest.GetRouteMetadata(c)
+			return next(c)
+		}
+	})
+	metadata := rest.RouteMetadata{Name: "get-user"}
+	err := s.AddRoute(rest.WithMetadata(rest.NewRoute(http.MethodGet, "/users/:id", testHttpHandler), metadata))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4041/users/1")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assertIsOkResponse(t, response)
+	assert.True(t, found)
+	assert.Equal(t, metadata, actual)
+}
+
+func TestUnit_Server_WhenRouteRequiresAuthWithoutAuthentication_ExpectUnauthorized(t *testing.T) {
+	s := newTestServer(4042)
+	metadata := rest.RouteMetadata{RequiresAuth: true}
+	err := s.AddRoute(rest.WithMetadata(rest.NewRoute(http.MethodGet, "/", testHttpHandler), metadata))
+	require.NoError(t, err, "Actual err: %v", err)
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	response := doRequest(t, http.MethodGet, "http://localhost:4042")
+
+	err = s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	actual := unmarshalResponseAndAssertRequestId(t, response)
+	assert.Equal(t, "ERROR", actual.Status)
+}
+
+func TestUnit_Server_WhenErrorHookIsSet_ExpectPanicsAndServerErrorsReported(t *testing.T) {
+	var lock sync.Mutex
+	var messages []string
+	config := Config{
+		BasePath:        "/",
+		Port:            4039,
+		ShutdownTimeout: 2 * time.Second,
+		ErrorHook: func(ctx context.Context, event logger.ErrorEvent) {
+			lock.Lock()
+			defer lock.Unlock()
+			messages = append(messages, event.Message)
+		},
+	}
+	s := NewWithLogger(config, slog.Default())
+	panicHandler := func(c *echo.Context) error {
+		panic(fmt.Errorf("this handler panics"))
+	}
+	errorHandler := func(c *echo.Context) error {
+		return fmt.Errorf("this handler fails")
+	}
+	require.NoError(t, s.AddRoute(rest.NewRoute(http.MethodGet, "/panic", panicHandler)))
+	require.NoError(t, s.AddRoute(rest.NewRoute(http.MethodGet, "/error", errorHandler)))
+
+	done := asyncRunServerAndAssertStopWithoutError(t, s)
+
+	doRequest(t, http.MethodGet, "http://localhost:4039/panic")
+	doRequest(t, http.MethodGet, "http://localhost:4039/error")
+	doRequest(t, http.MethodGet, "http://localhost:4039/not-found")
+
+	err := s.Stop()
+	<-done
+
+	require.NoError(t, err, "Actual err: %v", err)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"panic: this handler panics", "this handler fails"}, messages)
+}
+
 func newTestServer(port uint16) Server {
 	return newTestServerWithPath(port, "/")
 }
@@ -173,8 +733,13 @@ func testHttpHandler(c *echo.Context) error {
 	return c.JSON(http.StatusOK, "OK")
 }
 
+func slowHttpHandler(c *echo.Context) error {
+	<-c.Request().Context().Done()
+	return c.Request().Context().Err()
+}
+
 func asyncRunServerAndAssertStopWithoutError(
-	t *testing.T, s Server,
+	t *testing.T, s process.Runnable,
 ) <-chan struct{} {
 	t.Helper()
 
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"log/slog"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+// createIPExtractor resolves the client IP from the X-Forwarded-For header
+// only when the request comes from one of the trusted proxies. Without any
+// trusted proxy the headers are ignored as they can be forged by clients.
+func createIPExtractor(trustedProxies []string, log *slog.Logger) echo.IPExtractor {
+	var ranges []echo.TrustOption
+	for _, proxy := range trustedProxies {
+		ipRange, err := parseIPRange(proxy)
+		if err != nil {
+			log.Warn("Ignoring invalid trusted proxy", slog.String("proxy", proxy), slog.Any("error", err))
+			continue
+		}
+
+		ranges = append(ranges, echo.TrustIPRange(ipRange))
+	}
+
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	// Only the configured proxies are trusted, not the whole private
+	// network as echo does by default.
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	options = append(options, ranges...)
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+func parseIPRange(in string) (*net.IPNet, error) {
+	if strings.Contains(in, "/") {
+		_, ipRange, err := net.ParseCIDR(in)
+		return ipRange, err
+	}
+
+	ip := net.ParseIP(in)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: in}
+	}
+
+	bits := 8 * net.IPv4len
+	if ip.To4() == nil {
+		bits = 8 * net.IPv6len
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
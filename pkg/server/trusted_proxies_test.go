@@ -0,0 +1,85 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestUnit_CreateIPExtractor_WhenNoTrustedProxy_ExpectHeaderIgnored(t *testing.T) {
+	extractor := createIPExtractor(nil, discardLogger)
+
+	actual := extractor(newForwardedRequest("10.0.0.2:1234", "203.0.113.5"))
+
+	assert.Equal(t, "10.0.0.2", actual)
+}
+
+func TestUnit_CreateIPExtractor_WhenProxyIsTrusted_ExpectForwardedIP(t *testing.T) {
+	type testCase struct {
+		proxies    []string
+		remoteAddr string
+	}
+
+	testCases := map[string]testCase{
+		"ip": {
+			proxies:    []string{"10.0.0.2"},
+			remoteAddr: "10.0.0.2:1234",
+		},
+		"cidr": {
+			proxies:    []string{"10.0.0.0/24"},
+			remoteAddr: "10.0.0.2:1234",
+		},
+		"ipv6": {
+			proxies:    []string{"2001:db8::1"},
+			remoteAddr: "[2001:db8::1]:1234",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			extractor := createIPExtractor(tc.proxies, discardLogger)
+
+			actual := extractor(newForwardedRequest(tc.remoteAddr, "203.0.113.5"))
+
+			assert.Equal(t, "203.0.113.5", actual)
+		})
+	}
+}
+
+func TestUnit_CreateIPExtractor_WhenProxyIsNotTrusted_ExpectRemoteIP(t *testing.T) {
+	extractor := createIPExtractor([]string{"10.0.0.0/24"}, discardLogger)
+
+	actual := extractor(newForwardedRequest("10.0.1.2:1234", "203.0.113.5"))
+
+	assert.Equal(t, "10.0.1.2", actual)
+}
+
+func TestUnit_CreateIPExtractor_WhenChainHasUntrustedHop_ExpectFirstUntrustedIP(t *testing.T) {
+	extractor := createIPExtractor([]string{"10.0.0.0/24"}, discardLogger)
+
+	actual := extractor(newForwardedRequest("10.0.0.2:1234", "203.0.113.5, 198.51.100.7, 10.0.0.3"))
+
+	assert.Equal(t, "198.51.100.7", actual)
+}
+
+func TestUnit_CreateIPExtractor_WhenProxyIsInvalid_ExpectIgnored(t *testing.T) {
+	extractor := createIPExtractor([]string{"not-an-ip"}, discardLogger)
+
+	actual := extractor(newForwardedRequest("10.0.0.2:1234", "203.0.113.5"))
+
+	assert.Equal(t, "10.0.0.2", actual)
+}
+
+func newForwardedRequest(remoteAddr string, forwardedFor string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	return req
+}
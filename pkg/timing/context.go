@@ -0,0 +1,36 @@
+package timing
+
+import (
+	"context"
+	"time"
+)
+
+type recorderKey struct{}
+
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// FromContext returns the recorder attached to the context or nil if
+// there is none.
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return recorder
+}
+
+// Track starts measuring a segment and returns the function to call to
+// stop the measure. It is a no-op when no recorder is attached to the
+// context so that it can be used unconditionally:
+//
+//	defer timing.Track(ctx, timing.SegmentDb)()
+func Track(ctx context.Context, segment string) func() {
+	recorder := FromContext(ctx)
+	if recorder == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		recorder.Record(segment, time.Since(start))
+	}
+}
@@ -0,0 +1,40 @@
+package timing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_FromContext_WhenNoRecorder_ExpectNil(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+}
+
+func TestUnit_FromContext_ReturnsAttachedRecorder(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	assert.Same(t, r, FromContext(ctx))
+}
+
+func TestUnit_Track_RecordsSegment(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	stop := Track(ctx, SegmentDb)
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	segments := r.Segments()
+	assert.Len(t, segments, 1)
+	assert.Equal(t, SegmentDb, segments[0].Name)
+	assert.GreaterOrEqual(t, segments[0].Duration, 5*time.Millisecond)
+}
+
+func TestUnit_Track_WhenNoRecorder_ExpectNoPanic(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Track(context.Background(), SegmentDb)()
+	})
+}
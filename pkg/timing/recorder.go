@@ -0,0 +1,73 @@
+package timing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SegmentDb       = "db"
+	SegmentCache    = "cache"
+	SegmentExternal = "external"
+)
+
+type Segment struct {
+	Name     string
+	Duration time.Duration
+	Count    int
+}
+
+// Recorder accumulates the time spent in the various segments of a
+// request. Recording several times the same segment sums the durations.
+// It is safe to use from concurrent goroutines.
+type Recorder struct {
+	lock     sync.Mutex
+	segments []Segment
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Record(name string, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id := range r.segments {
+		if r.segments[id].Name == name {
+			r.segments[id].Duration += duration
+			r.segments[id].Count++
+			return
+		}
+	}
+
+	r.segments = append(r.segments, Segment{Name: name, Duration: duration, Count: 1})
+}
+
+// Segments returns the recorded segments in the order in which they
+// were first recorded.
+func (r *Recorder) Segments() []Segment {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]Segment(nil), r.segments...)
+}
+
+func (r *Recorder) ServerTiming() string {
+	return FormatServerTiming(r.Segments())
+}
+
+// FormatServerTiming formats the segments as expected by the
+// Server-Timing header. Durations are expressed in milliseconds.
+// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Server-Timing
+func FormatServerTiming(segments []Segment) string {
+	var metrics []string
+	for _, segment := range segments {
+		ms := float64(segment.Duration) / float64(time.Millisecond)
+		metrics = append(metrics, fmt.Sprintf("%s;dur=%.3f", segment.Name, ms))
+	}
+
+	return strings.Join(metrics, ", ")
+}
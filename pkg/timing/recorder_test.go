@@ -0,0 +1,67 @@
+package timing
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_Recorder_Record_KeepsInsertionOrder(t *testing.T) {
+	r := NewRecorder()
+
+	r.Record(SegmentDb, time.Millisecond)
+	r.Record(SegmentCache, 2*time.Millisecond)
+
+	expected := []Segment{
+		{Name: SegmentDb, Duration: time.Millisecond, Count: 1},
+		{Name: SegmentCache, Duration: 2 * time.Millisecond, Count: 1},
+	}
+	assert.Equal(t, expected, r.Segments())
+}
+
+func TestUnit_Recorder_Record_WhenSameSegment_ExpectDurationsSummed(t *testing.T) {
+	r := NewRecorder()
+
+	r.Record(SegmentDb, time.Millisecond)
+	r.Record(SegmentDb, 3*time.Millisecond)
+
+	expected := []Segment{
+		{Name: SegmentDb, Duration: 4 * time.Millisecond, Count: 2},
+	}
+	assert.Equal(t, expected, r.Segments())
+}
+
+func TestUnit_Recorder_Record_IsSafeForConcurrentUse(t *testing.T) {
+	r := NewRecorder()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			r.Record(SegmentExternal, time.Millisecond)
+		})
+	}
+	wg.Wait()
+
+	segments := r.Segments()
+	assert.Len(t, segments, 1)
+	assert.Equal(t, 10, segments[0].Count)
+	assert.Equal(t, 10*time.Millisecond, segments[0].Duration)
+}
+
+func TestUnit_Recorder_ServerTiming(t *testing.T) {
+	r := NewRecorder()
+	r.Record(SegmentDb, 12*time.Millisecond+345*time.Microsecond)
+	r.Record(SegmentExternal, 2*time.Millisecond)
+
+	actual := r.ServerTiming()
+
+	assert.Equal(t, "db;dur=12.345, external;dur=2.000", actual)
+}
+
+func TestUnit_Recorder_ServerTiming_WhenNoSegment_ExpectEmpty(t *testing.T) {
+	r := NewRecorder()
+
+	assert.Equal(t, "", r.ServerTiming())
+}